@@ -11,6 +11,10 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(RunMigrate(di.BuildContainer(), os.Args[2:]))
+	}
+
 	shutdownContext, cancel := context.WithCancel(context.Background())
 
 	defer cancel()
@@ -19,22 +23,7 @@ func main() {
 
 	container := di.BuildContainer()
 
-	container = di.AppendDependenciesToContainer(container, []dependency.Dependency{
-		{
-			Constructor: func() context.Context {
-				return shutdownContext
-			},
-			Interface: nil,
-			Token:     "ShutdownContext",
-		},
-		{
-			Constructor: func() *sync.WaitGroup {
-				return &wg
-			},
-			Interface: nil,
-			Token:     "ShutdownWaitGroup",
-		},
-	})
+	container = di.AppendDependenciesToContainer(container, dependency.GetShutdownDependencies(shutdownContext, &wg))
 
 	go StartApplication(container)
 
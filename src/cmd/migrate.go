@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/dig"
+	"os"
+	"rezvin-pro-bot/src/internal/db"
+	"rezvin-pro-bot/src/internal/migrations"
+	"text/tabwriter"
+	"time"
+)
+
+const migrateUsage = "usage: main migrate up|down|status"
+
+type migrateDependencies struct {
+	dig.In
+
+	Database db.IDatabase         `name:"Database"`
+	Migrator migrations.IMigrator `name:"Migrator"`
+}
+
+// RunMigrate handles `migrate up|down|status` and returns the process exit code.
+func RunMigrate(container *dig.Container, args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	var err error
+
+	invokeErr := container.Invoke(func(deps migrateDependencies) {
+		defer deps.Database.Shutdown(context.Background())
+
+		ctx := context.Background()
+
+		switch args[0] {
+		case "up":
+			err = migrateUp(ctx, deps.Migrator)
+		case "down":
+			err = migrateDown(ctx, deps.Migrator)
+		case "status":
+			err = migrateStatus(ctx, deps.Migrator)
+		default:
+			err = fmt.Errorf("unknown migrate command %q, %s", args[0], migrateUsage)
+		}
+	})
+
+	if invokeErr != nil {
+		err = invokeErr
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	return 0
+}
+
+func migrateUp(ctx context.Context, migrator migrations.IMigrator) error {
+	applied, err := migrator.Up(ctx)
+
+	for _, migration := range applied {
+		fmt.Printf("applied %d_%s\n", migration.Version, migration.Name)
+	}
+
+	if err == nil && len(applied) == 0 {
+		fmt.Println("no pending migrations")
+	}
+
+	return err
+}
+
+func migrateDown(ctx context.Context, migrator migrations.IMigrator) error {
+	reverted, err := migrator.Down(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	if reverted == nil {
+		fmt.Println("no applied migrations")
+		return nil
+	}
+
+	fmt.Printf("reverted %d_%s\n", reverted.Version, reverted.Name)
+
+	return nil
+}
+
+func migrateStatus(ctx context.Context, migrator migrations.IMigrator) error {
+	statuses, err := migrator.Status(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS")
+
+	for _, status := range statuses {
+		state := "pending"
+
+		if status.AppliedAt != nil {
+			state = "applied " + status.AppliedAt.Format(time.RFC3339)
+		}
+
+		if status.Missing {
+			state += " (missing from this build)"
+		}
+
+		fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, status.Name, state)
+	}
+
+	return w.Flush()
+}
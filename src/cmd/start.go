@@ -3,8 +3,10 @@ package main
 import (
 	"context"
 	"go.uber.org/dig"
+	"rezvin-pro-bot/src/config"
 	"rezvin-pro-bot/src/internal/bot"
 	"rezvin-pro-bot/src/internal/db"
+	"rezvin-pro-bot/src/internal/migrations"
 	"rezvin-pro-bot/src/services"
 	"rezvin-pro-bot/src/types"
 	"rezvin-pro-bot/src/utils"
@@ -15,11 +17,17 @@ type runAppDependencies struct {
 
 	ShutdownContext context.Context `name:"ShutdownContext"`
 
-	Database db.IDatabase `name:"Database"`
+	Config   config.IConfig       `name:"Config"`
+	Database db.IDatabase         `name:"Database"`
+	Migrator migrations.IMigrator `name:"Migrator"`
 
-	LockService         services.ILockService         `name:"LockService"`
-	ShutdownService     services.IShutdownService     `name:"ShutdownService"`
-	ConversationService services.IConversationService `name:"ConversationService"`
+	LockService      services.ILockService      `name:"LockService"`
+	ShutdownService  services.IShutdownService  `name:"ShutdownService"`
+	RestTimerService services.IRestTimerService `name:"RestTimerService"`
+	AlertService     services.IAlertService     `name:"AlertService"`
+
+	CallbackStateService services.ICallbackStateService `name:"CallbackStateService"`
+	OutboundService      services.IOutboundService      `name:"OutboundService"`
 
 	Bot bot.IBot `name:"Bot"`
 }
@@ -34,20 +42,44 @@ func StartApplication(container *dig.Container) {
 			4,
 		)
 
-		conversationServiceShutdownCallback := types.NewShutdownCallback(
-			"ConversationService",
+		lockServiceShutdownCallback := types.NewShutdownCallback(
+			"LockService",
 			func(ctx context.Context) error {
-				return deps.ConversationService.Shutdown(ctx)
+				return deps.LockService.Shutdown(ctx)
+			},
+			1,
+		)
+
+		restTimerServiceShutdownCallback := types.NewShutdownCallback(
+			"RestTimerService",
+			func(ctx context.Context) error {
+				return deps.RestTimerService.Shutdown(ctx)
 			},
 			2,
 		)
 
-		lockServiceShutdownCallback := types.NewShutdownCallback(
-			"LockService",
+		callbackStateServiceShutdownCallback := types.NewShutdownCallback(
+			"CallbackStateService",
 			func(ctx context.Context) error {
-				return deps.LockService.Shutdown(ctx)
+				return deps.CallbackStateService.Shutdown(ctx)
 			},
-			1,
+			2,
+		)
+
+		outboundServiceShutdownCallback := types.NewShutdownCallback(
+			"OutboundService",
+			func(ctx context.Context) error {
+				return deps.OutboundService.Shutdown(ctx)
+			},
+			2,
+		)
+
+		alertServiceShutdownCallback := types.NewShutdownCallback(
+			"AlertService",
+			func(ctx context.Context) error {
+				return deps.AlertService.Shutdown(ctx)
+			},
+			4,
 		)
 
 		databaseShutdownCallback := types.NewShutdownCallback(
@@ -59,9 +91,18 @@ func StartApplication(container *dig.Container) {
 		)
 
 		deps.ShutdownService.AddShutdownCallback(botShutdownCallback)
-		deps.ShutdownService.AddShutdownCallback(conversationServiceShutdownCallback)
 		deps.ShutdownService.AddShutdownCallback(lockServiceShutdownCallback)
+		deps.ShutdownService.AddShutdownCallback(restTimerServiceShutdownCallback)
+		deps.ShutdownService.AddShutdownCallback(callbackStateServiceShutdownCallback)
+		deps.ShutdownService.AddShutdownCallback(outboundServiceShutdownCallback)
 		deps.ShutdownService.AddShutdownCallback(databaseShutdownCallback)
+		deps.ShutdownService.AddShutdownCallback(alertServiceShutdownCallback)
+
+		if deps.Config.RunMigrations() {
+			_, err := deps.Migrator.Up(deps.ShutdownContext)
+
+			utils.PanicIfError(err)
+		}
 
 		go deps.Bot.Start(deps.ShutdownContext)
 	})
@@ -3,7 +3,7 @@ package config
 import (
 	"fmt"
 	"github.com/joho/godotenv"
-	"go.uber.org/dig"
+	"os"
 	"rezvin-pro-bot/src/constants"
 	"rezvin-pro-bot/src/internal/logger"
 	"time"
@@ -11,8 +11,11 @@ import (
 
 type IConfig interface {
 	AppEnv() constants.AppEnv
+	LogLevel() constants.LogLevel
+	LogFormat() constants.LogFormat
 
 	BotToken() string
+	BotApiUrl() string
 	WebhookSecretToken() string
 	RequestTimeout() time.Duration
 	AlertChatId() int64
@@ -20,49 +23,55 @@ type IConfig interface {
 	ErrorStackTraceSizeInKb() int
 
 	PostgresDSN() string
+	PostgresMaxOpenConns() int
 	RunMigrations() bool
+	LockBackend() constants.LockBackend
 
 	HttpPort() string
+	AdminPort() string
 	SSLCertPath() string
 	SSLKeyPath() string
 }
 
-type configDependencies struct {
-	dig.In
-
-	Logger logger.ILogger `name:"Logger"`
-}
-
 type config struct {
 	logger logger.ILogger
 
-	appEnv constants.AppEnv
+	appEnv    constants.AppEnv
+	logLevel  constants.LogLevel
+	logFormat constants.LogFormat
 
 	botToken                string
+	botApiUrl               string
 	webhookSecretToken      string
 	requestTimeoutInSeconds int
 	alertChatId             int64
 
 	errorStackTraceSizeInKb int
 
-	postgresDsn    string
-	runMigrations  bool
-	postgresSchema string
+	postgresDsn          string
+	postgresMaxOpenConns int
+	runMigrations        bool
+	postgresSchema       string
+	lockBackend          constants.LockBackend
 
 	httpPort    string
+	adminPort   string
 	sslCertPath string
 	sslKeyPath  string
 }
 
-func NewConfig(deps configDependencies) *config {
-	_logger := deps.Logger
-
+// NewConfig builds its own logger from LOG_LEVEL and LOG_FORMAT, because the application
+// logger is constructed from this config and cannot be injected here.
+func NewConfig() *config {
 	godotenv.Load() // ignore error, because in deployment we pass all env variables via docker run command
 
 	config := &config{
-		logger: _logger,
+		logLevel:  parseLogLevel(os.Getenv("LOG_LEVEL")),
+		logFormat: parseLogFormat(os.Getenv("LOG_FORMAT")),
 	}
 
+	config.logger = logger.NewLogger(config.logLevel, config.logFormat)
+
 	appEnv := config.getRequiredString("APP_ENV")
 
 	switch appEnv {
@@ -78,12 +87,26 @@ func NewConfig(deps configDependencies) *config {
 	}
 
 	config.botToken = config.getRequiredString("BOT_TOKEN")
+	config.botApiUrl = config.getOptionalString("BOT_API_URL", "")
 	config.postgresDsn = config.getRequiredString("POSTGRES_DSN")
+	config.postgresMaxOpenConns = config.getOptionalInt("POSTGRES_MAX_OPEN_CONNS", 20)
 	config.alertChatId = config.getRequiredInt64("ALERT_CHAT_ID")
 	config.runMigrations = config.getOptionalBool("RUN_MIGRATIONS", false)
 	config.requestTimeoutInSeconds = config.getOptionalInt("REQUEST_TIMEOUT_IN_SECONDS", 60)
 	config.errorStackTraceSizeInKb = config.getOptionalInt("ERROR_STACK_TRACE_SIZE_IN_KB", 4)
 	config.httpPort = config.getOptionalString("HTTP_PORT", ":8080")
+	config.adminPort = config.getOptionalString("ADMIN_PORT", ":9090")
+
+	lockBackend := config.getOptionalString("LOCK_BACKEND", string(constants.MemoryLockBackend))
+
+	switch lockBackend {
+	case string(constants.MemoryLockBackend):
+		config.lockBackend = constants.MemoryLockBackend
+	case string(constants.PostgresLockBackend):
+		config.lockBackend = constants.PostgresLockBackend
+	default:
+		panic(fmt.Sprintf("Invalid LOCK_BACKEND value: %s. Supported values: %s, %s", lockBackend, constants.MemoryLockBackend, constants.PostgresLockBackend))
+	}
 
 	return config
 }
@@ -92,6 +115,39 @@ func (c *config) AppEnv() constants.AppEnv {
 	return c.appEnv
 }
 
+func (c *config) LogLevel() constants.LogLevel {
+	return c.logLevel
+}
+
+func (c *config) LogFormat() constants.LogFormat {
+	return c.logFormat
+}
+
+func parseLogLevel(value string) constants.LogLevel {
+	switch constants.LogLevel(value) {
+	case "":
+		return constants.DebugLogLevel
+	case constants.DebugLogLevel, constants.InfoLogLevel, constants.WarnLogLevel, constants.ErrorLogLevel:
+		return constants.LogLevel(value)
+	default:
+		panic(fmt.Sprintf(
+			"Invalid LOG_LEVEL value: %s. Supported values: %s, %s, %s, %s",
+			value, constants.DebugLogLevel, constants.InfoLogLevel, constants.WarnLogLevel, constants.ErrorLogLevel,
+		))
+	}
+}
+
+func parseLogFormat(value string) constants.LogFormat {
+	switch constants.LogFormat(value) {
+	case "":
+		return constants.JSONLogFormat
+	case constants.JSONLogFormat, constants.TextLogFormat:
+		return constants.LogFormat(value)
+	default:
+		panic(fmt.Sprintf("Invalid LOG_FORMAT value: %s. Supported values: %s, %s", value, constants.JSONLogFormat, constants.TextLogFormat))
+	}
+}
+
 func (c *config) BotToken() string {
 	return c.botToken
 }
@@ -100,6 +156,10 @@ func (c *config) SSLCertPath() string {
 	return c.sslCertPath
 }
 
+func (c *config) BotApiUrl() string {
+	return c.botApiUrl
+}
+
 func (c *config) WebhookSecretToken() string {
 	return c.webhookSecretToken
 }
@@ -116,6 +176,10 @@ func (c *config) PostgresDSN() string {
 	return c.postgresDsn
 }
 
+func (c *config) PostgresMaxOpenConns() int {
+	return c.postgresMaxOpenConns
+}
+
 func (c *config) PostgresSchema() string {
 	return c.postgresSchema
 }
@@ -124,6 +188,10 @@ func (c *config) RunMigrations() bool {
 	return c.runMigrations
 }
 
+func (c *config) LockBackend() constants.LockBackend {
+	return c.lockBackend
+}
+
 func (c *config) RequestTimeout() time.Duration {
 	return time.Duration(c.requestTimeoutInSeconds) * time.Second
 }
@@ -132,6 +200,10 @@ func (c *config) HttpPort() string {
 	return c.httpPort
 }
 
+func (c *config) AdminPort() string {
+	return c.adminPort
+}
+
 func (c *config) AlertChatId() int64 {
 	return c.alertChatId
 }
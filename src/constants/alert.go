@@ -0,0 +1,21 @@
+package constants
+
+import "time"
+
+type AlertKind string
+
+const (
+	PanicAlertKind      AlertKind = "panic"
+	RepositoryAlertKind AlertKind = "repository"
+	TelegramAlertKind   AlertKind = "telegram"
+)
+
+const (
+	AlertSuppressWindow        = 15 * time.Minute
+	AlertDigestInterval        = 15 * time.Minute
+	MaxAlertsPerDigestInterval = 20
+	AlertQueueSize             = 100
+	AlertSendTimeout           = 10 * time.Second
+	AlertMessageMaxLength      = 4096
+	AlertErrorMaxLength        = 500
+)
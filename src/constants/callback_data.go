@@ -17,10 +17,13 @@ const (
 	ExerciseAdd        = "ea"
 	ExerciseDelete     = "ed"
 	ExerciseDeleteItem = "edi"
+	ExerciseRepSchemes = "erl"
+	ExerciseRepScheme  = "ers"
 
 	ClientPrefix   = "cc"
 	ClientList     = "ccl"
 	ClientSelected = "ccls"
+	ClientExport   = "cce"
 
 	ClientProgramPrefix   = "cp"
 	ClientProgramList     = "cpl"
@@ -35,20 +38,33 @@ const (
 	ClientMeasureAdd      = "cma"
 	ClientMeasureDelete   = "cmd"
 	ClientMeasureResult   = "cmr"
+	ClientMeasureChart    = "cmc"
 
 	ClientResultPrefix           = "cr"
 	ClientResultList             = "crl"
 	ClientResultExercisesList    = "crel"
 	ClientResultExerciseSelected = "cres"
 	ClientResultExerciseReps     = "crer"
+	ClientResultHistory          = "crh"
+	ClientResultChart            = "crc"
 
-	ProgramPrefix   = "pr"
-	ProgramSelected = "prs"
-	ProgramRename   = "prr"
-	ProgramDelete   = "prd"
-	ProgramMenu     = "prm"
-	ProgramList     = "prl"
-	ProgramAdd      = "pra"
+	ClientWorkoutPrefix   = "cw"
+	ClientWorkoutList     = "cwl"
+	ClientWorkoutSelected = "cws"
+
+	ProgramPrefix          = "pr"
+	ProgramSelected        = "prs"
+	ProgramRename          = "prr"
+	ProgramDelete          = "prd"
+	ProgramMenu            = "prm"
+	ProgramList            = "prl"
+	ProgramAdd             = "pra"
+	ProgramFormula         = "prf"
+	ProgramFormulaEpley    = "prfe"
+	ProgramFormulaBrzycki  = "prfb"
+	ProgramFormulaLombardi = "prfl"
+	ProgramFormulaBest     = "prfx"
+	ProgramRepScheme       = "prp"
 
 	MeasurePrefix      = "me"
 	MeasureMenu        = "mem"
@@ -65,6 +81,16 @@ const (
 	PendingUsersApprove  = "pua"
 	PendingUsersDecline  = "pud"
 
+	SettingsPrefix       = "st"
+	SettingsMenu         = "stm"
+	SettingsWeightUnitKg = "stk"
+	SettingsWeightUnitLb = "stl"
+
+	ImportPrefix  = "im"
+	ImportStart   = "ims"
+	ImportConfirm = "imc"
+	ImportCancel  = "imx"
+
 	RegisterPrefix = "r"
 	UserRegister   = "ru"
 
@@ -77,6 +103,19 @@ const (
 	UserResultExerciseList     = "urel"
 	UserResultExerciseSelected = "ures"
 	UserResultExerciseReps     = "urer"
+	UserResultHistory          = "urh"
+	UserResultRecords          = "urr"
+	UserResultChart            = "urc"
+
+	UserWorkoutPrefix     = "uw"
+	UserWorkoutStart      = "uws"
+	UserWorkoutAddSet     = "uwa"
+	UserWorkoutNext       = "uwn"
+	UserWorkoutFinish     = "uwf"
+	UserWorkoutCancel     = "uwc"
+	UserWorkoutResume     = "uwr"
+	UserWorkoutRest       = "uwt"
+	UserWorkoutRestCancel = "uwx"
 
 	UserMeasurePrefix   = "um"
 	UserMeasureList     = "uml"
@@ -84,4 +123,13 @@ const (
 	UserMeasureAdd      = "uma"
 	UserMeasureDelete   = "umd"
 	UserMeasureResult   = "umr"
+	UserMeasureChart    = "umc"
+
+	WizardPrefix  = "wz"
+	WizardBack    = "wzb"
+	WizardCancel  = "wzc"
+	WizardConfirm = "wzo"
+
+	DeadLetterPrefix = "dl"
+	DeadLetterList   = "dll"
 )
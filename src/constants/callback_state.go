@@ -0,0 +1,10 @@
+package constants
+
+import "time"
+
+const (
+	CallbackStateSeparator   = "~"
+	CallbackStateTokenLength = 12
+	CallbackStateTTL         = 48 * time.Hour
+	CallbackStateGCInterval  = time.Hour
+)
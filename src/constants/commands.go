@@ -1,6 +1,7 @@
 package constants
 
 const (
-	CommandStart = "/start"
-	CommandHelp  = "/help"
+	CommandStart  = "/start"
+	CommandHelp   = "/help"
+	CommandCancel = "/cancel"
 )
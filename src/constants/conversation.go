@@ -0,0 +1,27 @@
+package constants
+
+type ConversationState string
+
+const (
+	ProgramAddNameState     ConversationState = "program_add_name"
+	ProgramRenameState      ConversationState = "program_rename"
+	ProgramRepSchemeState   ConversationState = "program_rep_scheme"
+	ExerciseAddNameState    ConversationState = "exercise_add_name"
+	ExerciseRepSchemeState  ConversationState = "exercise_rep_scheme"
+	MeasureAddState         ConversationState = "measure_add"
+	MeasureRenameState      ConversationState = "measure_rename"
+	MeasureChangeUnitsState ConversationState = "measure_change_units"
+	UserMeasureValueState   ConversationState = "user_measure_value"
+	ClientMeasureValueState ConversationState = "client_measure_value"
+	UserResultWeightState   ConversationState = "user_result_weight"
+	ClientResultWeightState ConversationState = "client_result_weight"
+	UserWorkoutSetState     ConversationState = "user_workout_set"
+)
+
+const (
+	ProgramNameConversationKey  = "programName"
+	ExerciseNameConversationKey = "exerciseName"
+	MeasureNameConversationKey  = "measureName"
+	MeasureUnitsConversationKey = "measureUnits"
+	ResultWeightConversationKey = "resultWeight"
+)
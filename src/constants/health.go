@@ -0,0 +1,13 @@
+package constants
+
+import "time"
+
+const (
+	HealthCheckTimeout     = 3 * time.Second
+	TelegramHealthCacheTTL = 30 * time.Second
+)
+
+const (
+	HealthStatusOk          = "ok"
+	HealthStatusUnavailable = "unavailable"
+)
@@ -0,0 +1,6 @@
+package constants
+
+const (
+	MaxImportFileSize = 1 << 20
+	ImportPlanDataKey = "plan"
+)
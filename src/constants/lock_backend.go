@@ -0,0 +1,8 @@
+package constants
+
+type LockBackend string
+
+const (
+	MemoryLockBackend   LockBackend = "memory"
+	PostgresLockBackend LockBackend = "postgres"
+)
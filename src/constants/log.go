@@ -0,0 +1,25 @@
+package constants
+
+type LogLevel string
+
+const (
+	DebugLogLevel LogLevel = "debug"
+	InfoLogLevel  LogLevel = "info"
+	WarnLogLevel  LogLevel = "warn"
+	ErrorLogLevel LogLevel = "error"
+)
+
+type LogFormat string
+
+const (
+	JSONLogFormat LogFormat = "json"
+	TextLogFormat LogFormat = "text"
+)
+
+const (
+	LogFieldRequestId      = "request_id"
+	LogFieldUpdateId       = "update_id"
+	LogFieldChatId         = "chat_id"
+	LogFieldUserId         = "user_id"
+	LogFieldCallbackPrefix = "callback_prefix"
+)
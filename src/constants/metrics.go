@@ -0,0 +1,14 @@
+package constants
+
+import "time"
+
+const MetricsCollectTimeout = 5 * time.Second
+
+const (
+	TelegramCallOk             = "ok"
+	TelegramCallError          = "error"
+	TelegramCallTransientError = "transient_error"
+	TelegramCallRateLimited    = "rate_limited"
+	TelegramCallCanceled       = "canceled"
+	TelegramCallDeadLettered   = "dead_lettered"
+)
@@ -0,0 +1,12 @@
+package constants
+
+type OneRepMaxFormula string
+
+const (
+	EpleyFormula    OneRepMaxFormula = "epley"
+	BrzyckiFormula  OneRepMaxFormula = "brzycki"
+	LombardiFormula OneRepMaxFormula = "lombardi"
+	BestFormula     OneRepMaxFormula = "best"
+
+	DefaultOneRepMaxFormula = BestFormula
+)
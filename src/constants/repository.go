@@ -0,0 +1,8 @@
+package constants
+
+import "time"
+
+const (
+	RepositoryMaxAttempts    = 3
+	RepositoryRetryBaseDelay = 100 * time.Millisecond
+)
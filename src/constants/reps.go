@@ -3,11 +3,9 @@ package constants
 type Reps uint
 
 const (
-	Six    Reps = 6
-	Eight  Reps = 8
-	Ten    Reps = 10
-	Twelve Reps = 12
-	Zero   Reps = 0
-)
+	Zero    Reps = 0
+	MaxReps Reps = 100
 
-var RepsList = []Reps{Six, Eight, Ten, Twelve}
+	MaxRepSchemeLength = 8
+	DefaultRepScheme   = "6,8,10,12"
+)
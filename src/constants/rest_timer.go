@@ -0,0 +1,15 @@
+package constants
+
+type RestSeconds uint
+
+var RestDurations = []RestSeconds{60, 90, 120, 180}
+
+func IsValidRestDuration(seconds RestSeconds) bool {
+	for _, duration := range RestDurations {
+		if duration == seconds {
+			return true
+		}
+	}
+
+	return false
+}
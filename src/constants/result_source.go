@@ -0,0 +1,8 @@
+package constants
+
+type ResultSource string
+
+const (
+	ClientResultSource  ResultSource = "client"
+	TrainerResultSource ResultSource = "trainer"
+)
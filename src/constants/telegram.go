@@ -0,0 +1,18 @@
+package constants
+
+import "time"
+
+const (
+	GlobalMessagesPerSecond  = 30
+	ChatMessagesPerSecond    = 1
+	ChatMessagesBurst        = 3
+	GroupMessagesPerMinute   = 20
+	MaxSendAttempts          = 5
+	SendRetryBaseDelay       = time.Second
+	SendRetryMaxDelay        = 30 * time.Second
+	ChatRateLimiterIdleAfter = 10 * time.Minute
+	MessageEditableFor       = 48 * time.Hour
+	MaxQueuedSendsPerChat    = 100
+	OutboundSendTimeout      = 2 * time.Minute
+	MaxCallbackDataLength    = 64
+)
@@ -0,0 +1,12 @@
+package constants
+
+type WeightUnit string
+
+const (
+	KilogramUnit WeightUnit = "kg"
+	PoundUnit    WeightUnit = "lb"
+
+	DefaultWeightUnit = KilogramUnit
+
+	MaxWeightKg = 1000
+)
@@ -1,9 +1,11 @@
 package di
 
 import (
+	"context"
 	"go.uber.org/dig"
 	"rezvin-pro-bot/src/di/dependency"
 	"rezvin-pro-bot/src/utils"
+	"sync"
 )
 
 func BuildContainer() *dig.Container {
@@ -18,6 +20,22 @@ func BuildContainer() *dig.Container {
 	return c
 }
 
+// BuildMemoryContainer wires the same services, handlers and bot against in-memory repositories
+// and a database stub, so nothing in it needs a database connection. The shutdown context is
+// never cancelled, whoever uses the container shuts its services down directly.
+func BuildMemoryContainer() *dig.Container {
+	c := dig.New()
+
+	c = AppendDependenciesToContainer(c, dependency.GetMemoryRequiredDependencies())
+	c = AppendDependenciesToContainer(c, dependency.GetShutdownDependencies(context.Background(), &sync.WaitGroup{}))
+	c = AppendDependenciesToContainer(c, dependency.GetMemoryRepositoriesDependencies())
+	c = AppendDependenciesToContainer(c, dependency.GetServicesDependencies())
+	c = AppendDependenciesToContainer(c, dependency.GetHandlersDependencies())
+	c = AppendDependenciesToContainer(c, dependency.GetBotDependencies())
+
+	return c
+}
+
 func AppendDependenciesToContainer(container *dig.Container, dependencies []dependency.Dependency) *dig.Container {
 	for _, dep := range dependencies {
 		mustProvideDependency(container, dep)
@@ -87,5 +87,35 @@ func GetHandlersDependencies() []Dependency {
 			Interface:   new(cb_handlers.IUserMeasureHandler),
 			Token:       "UserMeasureHandler",
 		},
+		{
+			Constructor: cb_handlers.NewSettingsHandler,
+			Interface:   new(cb_handlers.ISettingsHandler),
+			Token:       "SettingsHandler",
+		},
+		{
+			Constructor: cb_handlers.NewUserWorkoutHandler,
+			Interface:   new(cb_handlers.IUserWorkoutHandler),
+			Token:       "UserWorkoutHandler",
+		},
+		{
+			Constructor: cb_handlers.NewClientWorkoutHandler,
+			Interface:   new(cb_handlers.IClientWorkoutHandler),
+			Token:       "ClientWorkoutHandler",
+		},
+		{
+			Constructor: cb_handlers.NewProgramImportHandler,
+			Interface:   new(cb_handlers.IProgramImportHandler),
+			Token:       "ProgramImportHandler",
+		},
+		{
+			Constructor: cb_handlers.NewWizardHandler,
+			Interface:   new(cb_handlers.IWizardHandler),
+			Token:       "WizardHandler",
+		},
+		{
+			Constructor: cb_handlers.NewDeadLetterHandler,
+			Interface:   new(cb_handlers.IDeadLetterHandler),
+			Token:       "DeadLetterHandler",
+		},
 	}
 }
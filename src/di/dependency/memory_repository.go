@@ -0,0 +1,90 @@
+package dependency
+
+import (
+	"rezvin-pro-bot/src/repositories"
+	memory_repositories "rezvin-pro-bot/src/repositories/memory"
+)
+
+func GetMemoryRepositoriesDependencies() []Dependency {
+	return []Dependency{
+		{
+			Constructor: memory_repositories.NewStore,
+			Token:       "MemoryStore",
+		},
+		{
+			Constructor: memory_repositories.NewUserRepository,
+			Interface:   new(repositories.IUserRepository),
+			Token:       "UserRepository",
+		},
+		{
+			Constructor: memory_repositories.NewProgramRepository,
+			Interface:   new(repositories.IProgramRepository),
+			Token:       "ProgramRepository",
+		},
+		{
+			Constructor: memory_repositories.NewExerciseRepository,
+			Interface:   new(repositories.IExerciseRepository),
+			Token:       "ExerciseRepository",
+		},
+		{
+			Constructor: memory_repositories.NewUserProgramRepository,
+			Interface:   new(repositories.IUserProgramRepository),
+			Token:       "UserProgramRepository",
+		},
+		{
+			Constructor: memory_repositories.NewUserResultRepository,
+			Interface:   new(repositories.IUserResultRepository),
+			Token:       "UserResultRepository",
+		},
+		{
+			Constructor: memory_repositories.NewUserResultHistoryRepository,
+			Interface:   new(repositories.IUserResultHistoryRepository),
+			Token:       "UserResultHistoryRepository",
+		},
+		{
+			Constructor: memory_repositories.NewLastUserMessageRepository,
+			Interface:   new(repositories.ILastUserMessageRepository),
+			Token:       "LastUserMessageRepository",
+		},
+		{
+			Constructor: memory_repositories.NewMeasureRepository,
+			Interface:   new(repositories.IMeasureRepository),
+			Token:       "MeasureRepository",
+		},
+		{
+			Constructor: memory_repositories.NewUserMeasureRepository,
+			Interface:   new(repositories.IUserMeasureRepository),
+			Token:       "UserMeasureRepository",
+		},
+		{
+			Constructor: memory_repositories.NewWorkoutSessionRepository,
+			Interface:   new(repositories.IWorkoutSessionRepository),
+			Token:       "WorkoutSessionRepository",
+		},
+		{
+			Constructor: memory_repositories.NewWorkoutSetRepository,
+			Interface:   new(repositories.IWorkoutSetRepository),
+			Token:       "WorkoutSetRepository",
+		},
+		{
+			Constructor: memory_repositories.NewRestTimerRepository,
+			Interface:   new(repositories.IRestTimerRepository),
+			Token:       "RestTimerRepository",
+		},
+		{
+			Constructor: memory_repositories.NewConversationRepository,
+			Interface:   new(repositories.IConversationRepository),
+			Token:       "ConversationRepository",
+		},
+		{
+			Constructor: memory_repositories.NewDeadLetterRepository,
+			Interface:   new(repositories.IDeadLetterRepository),
+			Token:       "DeadLetterRepository",
+		},
+		{
+			Constructor: memory_repositories.NewCallbackStateRepository,
+			Interface:   new(repositories.ICallbackStateRepository),
+			Token:       "CallbackStateRepository",
+		},
+	}
+}
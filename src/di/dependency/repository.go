@@ -29,6 +29,11 @@ func GetRepositoriesDependencies() []Dependency {
 			Interface:   new(repositories.IUserResultRepository),
 			Token:       "UserResultRepository",
 		},
+		{
+			Constructor: repositories.NewUserResultHistoryRepository,
+			Interface:   new(repositories.IUserResultHistoryRepository),
+			Token:       "UserResultHistoryRepository",
+		},
 		{
 			Constructor: repositories.NewLastUserMessageRepository,
 			Interface:   new(repositories.ILastUserMessageRepository),
@@ -44,5 +49,35 @@ func GetRepositoriesDependencies() []Dependency {
 			Interface:   new(repositories.IUserMeasureRepository),
 			Token:       "UserMeasureRepository",
 		},
+		{
+			Constructor: repositories.NewWorkoutSessionRepository,
+			Interface:   new(repositories.IWorkoutSessionRepository),
+			Token:       "WorkoutSessionRepository",
+		},
+		{
+			Constructor: repositories.NewWorkoutSetRepository,
+			Interface:   new(repositories.IWorkoutSetRepository),
+			Token:       "WorkoutSetRepository",
+		},
+		{
+			Constructor: repositories.NewRestTimerRepository,
+			Interface:   new(repositories.IRestTimerRepository),
+			Token:       "RestTimerRepository",
+		},
+		{
+			Constructor: repositories.NewConversationRepository,
+			Interface:   new(repositories.IConversationRepository),
+			Token:       "ConversationRepository",
+		},
+		{
+			Constructor: repositories.NewDeadLetterRepository,
+			Interface:   new(repositories.IDeadLetterRepository),
+			Token:       "DeadLetterRepository",
+		},
+		{
+			Constructor: repositories.NewCallbackStateRepository,
+			Interface:   new(repositories.ICallbackStateRepository),
+			Token:       "CallbackStateRepository",
+		},
 	}
 }
@@ -1,27 +1,87 @@
 package dependency
 
 import (
+	"context"
+	"go.uber.org/dig"
 	"rezvin-pro-bot/src/config"
 	"rezvin-pro-bot/src/internal/db"
 	"rezvin-pro-bot/src/internal/logger"
+	"rezvin-pro-bot/src/internal/metrics"
+	"rezvin-pro-bot/src/internal/migrations"
+	"sync"
 )
 
-func GetRequiredDependencies() []Dependency {
+func GetCoreDependencies() []Dependency {
 	return []Dependency{
 		{
-			Constructor: logger.NewLogger,
+			Constructor: config.NewConfig,
+			Interface:   new(config.IConfig),
+			Token:       "Config",
+		},
+		{
+			Constructor: newLogger,
 			Interface:   new(logger.ILogger),
 			Token:       "Logger",
 		},
 		{
-			Constructor: config.NewConfig,
-			Interface:   new(config.IConfig),
-			Token:       "Config",
+			Constructor: metrics.NewMetrics,
+			Interface:   new(metrics.IMetrics),
+			Token:       "Metrics",
 		},
+	}
+}
+
+func GetRequiredDependencies() []Dependency {
+	return append(GetCoreDependencies(), []Dependency{
 		{
 			Constructor: db.NewDatabase,
 			Interface:   new(db.IDatabase),
 			Token:       "Database",
 		},
+		{
+			Constructor: migrations.NewMigrator,
+			Interface:   new(migrations.IMigrator),
+			Token:       "Migrator",
+		},
+	}...)
+}
+
+// GetMemoryRequiredDependencies replaces the database with a stub for the in-memory container.
+func GetMemoryRequiredDependencies() []Dependency {
+	return append(GetCoreDependencies(), Dependency{
+		Constructor: db.NewMemoryDatabase,
+		Interface:   new(db.IDatabase),
+		Token:       "Database",
+	})
+}
+
+// GetShutdownDependencies provides the context that is cancelled on shutdown and the wait
+// group the shutdown waits for, both owned by whoever starts the application.
+func GetShutdownDependencies(shutdownContext context.Context, wg *sync.WaitGroup) []Dependency {
+	return []Dependency{
+		{
+			Constructor: func() context.Context {
+				return shutdownContext
+			},
+			Interface: nil,
+			Token:     "ShutdownContext",
+		},
+		{
+			Constructor: func() *sync.WaitGroup {
+				return wg
+			},
+			Interface: nil,
+			Token:     "ShutdownWaitGroup",
+		},
 	}
 }
+
+type loggerDependencies struct {
+	dig.In
+
+	Config config.IConfig `name:"Config"`
+}
+
+func newLogger(deps loggerDependencies) *logger.Logger {
+	return logger.NewLogger(deps.Config.LogLevel(), deps.Config.LogFormat())
+}
@@ -9,6 +9,16 @@ func GetServicesDependencies() []Dependency {
 			Interface:   new(services.IConversationService),
 			Token:       "ConversationService",
 		},
+		{
+			Constructor: services.NewAlertService,
+			Interface:   new(services.IAlertService),
+			Token:       "AlertService",
+		},
+		{
+			Constructor: services.NewOutboundService,
+			Interface:   new(services.IOutboundService),
+			Token:       "OutboundService",
+		},
 		{
 			Constructor: services.NewSenderService,
 			Interface:   new(services.ISenderService),
@@ -16,7 +26,6 @@ func GetServicesDependencies() []Dependency {
 		},
 		{
 			Constructor: services.NewLockService,
-			Interface:   new(services.ILockService),
 			Token:       "LockService",
 		},
 		{
@@ -24,5 +33,35 @@ func GetServicesDependencies() []Dependency {
 			Interface:   new(services.IShutdownService),
 			Token:       "ShutdownService",
 		},
+		{
+			Constructor: services.NewHealthService,
+			Interface:   new(services.IHealthService),
+			Token:       "HealthService",
+		},
+		{
+			Constructor: services.NewUserResultService,
+			Interface:   new(services.IUserResultService),
+			Token:       "UserResultService",
+		},
+		{
+			Constructor: services.NewRestTimerService,
+			Interface:   new(services.IRestTimerService),
+			Token:       "RestTimerService",
+		},
+		{
+			Constructor: services.NewProgramImportService,
+			Interface:   new(services.IProgramImportService),
+			Token:       "ProgramImportService",
+		},
+		{
+			Constructor: services.NewWizardService,
+			Interface:   new(services.IWizardService),
+			Token:       "WizardService",
+		},
+		{
+			Constructor: services.NewCallbackStateService,
+			Interface:   new(services.ICallbackStateService),
+			Token:       "CallbackStateService",
+		},
 	}
 }
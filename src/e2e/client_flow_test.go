@@ -0,0 +1,110 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"context"
+	"go.uber.org/dig"
+	"rezvin-pro-bot/src/internal/test_harness"
+	"rezvin-pro-bot/src/models"
+	"rezvin-pro-bot/src/repositories"
+	"testing"
+)
+
+type clientFlowDependencies struct {
+	dig.In
+
+	ProgramRepository     repositories.IProgramRepository     `name:"ProgramRepository"`
+	ExerciseRepository    repositories.IExerciseRepository    `name:"ExerciseRepository"`
+	UserProgramRepository repositories.IUserProgramRepository `name:"UserProgramRepository"`
+	UserResultRepository  repositories.IUserResultRepository  `name:"UserResultRepository"`
+}
+
+func TestRegisterApproveAssignProgramAndLogResult(t *testing.T) {
+	h := test_harness.New(t)
+
+	admin := test_harness.NewUser(1001, "Тренер", "")
+	client := test_harness.NewUser(2002, "Іван", "Петренко")
+
+	h.CreateAdmin(admin)
+
+	var deps clientFlowDependencies
+	var programId, exerciseId uint
+
+	h.Invoke(func(d clientFlowDependencies) {
+		var err error
+
+		deps = d
+
+		if programId, err = d.ProgramRepository.Create(context.Background(), models.Program{Name: "Сила"}); err != nil {
+			t.Fatalf("failed to create program: %s", err)
+		}
+
+		exerciseId, err = d.ExerciseRepository.Create(context.Background(), models.Exercise{Name: "Присідання", ProgramId: programId})
+
+		if err != nil {
+			t.Fatalf("failed to create exercise: %s", err)
+		}
+	})
+
+	h.Send(client, "/start")
+	h.Click(client, h.ExpectButton(client, "Реєстрація"), "Реєстрація")
+	h.Expect(client, "успішно зареєстрований")
+	h.Expect(admin, "чекає на підтверження")
+
+	h.Send(admin, "/start")
+	h.Click(admin, h.ExpectButton(admin, "Підтвердження клієнтів"), "Підтвердження клієнтів")
+	h.Click(admin, h.ExpectButton(admin, "Іван Петренко"), "Іван Петренко")
+	h.Click(admin, h.ExpectButton(admin, "Підтвердити"), "Підтвердити")
+	h.Expect(admin, "Реєстрацію користувача")
+	h.Expect(client, "підтвердив твою реєстрацію")
+
+	h.Send(admin, "/start")
+	h.Click(admin, h.ExpectButton(admin, "Клієнти"), "Клієнти")
+	h.Click(admin, h.ExpectButton(admin, "Іван Петренко"), "Іван Петренко")
+	h.Click(admin, h.ExpectButton(admin, "Призначити програму"), "Призначити програму")
+	h.Click(admin, h.ExpectButton(admin, "Сила"), "Сила")
+	h.Expect(admin, "успішно призначена")
+	h.Expect(client, "призначив тобі нову програму")
+
+	h.Send(client, "/start")
+	h.Click(client, h.ExpectButton(client, "Мої програми"), "Мої програми")
+	h.Click(client, h.ExpectButton(client, "Сила"), "Сила")
+	h.Click(client, h.ExpectButton(client, "Внести результати"), "Внести результати")
+	h.Click(client, h.ExpectButton(client, "Присідання"), "Присідання")
+	h.Click(client, h.ExpectButton(client, "6 повторень"), "6 повторень")
+	h.Expect(client, "Введи результат")
+	h.Send(client, "100")
+	h.Click(client, h.ExpectButton(client, "Підтвердити"), "Підтвердити")
+	h.Expect(client, "успішно змінено")
+
+	userProgram, err := deps.UserProgramRepository.GetByUserIdAndProgramId(context.Background(), client.ID, programId)
+
+	if err != nil {
+		t.Fatalf("program was not assigned to the client: %s", err)
+	}
+
+	records, err := deps.UserResultRepository.GetAllByUserProgramIdAndExerciseId(context.Background(), userProgram.Id, exerciseId)
+
+	if err != nil {
+		t.Fatalf("failed to load results: %s", err)
+	}
+
+	found := false
+
+	for _, record := range records {
+		if record.Reps != 6 {
+			continue
+		}
+
+		found = true
+
+		if record.Weight != 100 {
+			t.Fatalf("expected 100 kg for 6 reps, got %v", record.Weight)
+		}
+	}
+
+	if !found {
+		t.Fatalf("expected a 6 reps result, got %d results", len(records))
+	}
+}
@@ -30,4 +30,16 @@ func GetAdminName() string {
 	return value
 }
 
+func GetBotToken() string {
+	key := "BOT_TOKEN"
+	godotenv.Load() // ignore error, because in deployment we pass all env variables via docker run command
+
+	value := os.Getenv(key)
+	if value == "" {
+		panic(fmt.Sprintf(`Environment variable "%s" not found`, key))
+	}
+
+	return value
+}
+
 var AdminName = GetAdminName()
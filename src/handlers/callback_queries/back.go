@@ -78,7 +78,7 @@ func (h *backHandler) Handle(ctx context.Context, b *tg_bot.Bot, update *tg_mode
 		return
 	}
 
-	h.logger.Warn(fmt.Sprintf("Unknown back callback query data: %s", callBackQueryData))
+	h.logger.WithContext(ctx).Warn(fmt.Sprintf("Unknown back callback query data: %s", callBackQueryData))
 }
 
 func (h *backHandler) backToProgramMenu(ctx context.Context, b *tg_bot.Bot) {
@@ -94,7 +94,12 @@ func (h *backHandler) backToProgramList(ctx context.Context, b *tg_bot.Bot) {
 	limit := utils_context.GetLimitFromContext(ctx)
 	offset := utils_context.GetOffsetFromContext(ctx)
 
-	programs := h.programRepository.GetAll(ctx, limit, offset)
+	programs, err := h.programRepository.GetAll(ctx, limit, offset)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	if len(programs) == 0 {
 		msg := messages.NoProgramsMessage()
@@ -103,7 +108,12 @@ func (h *backHandler) backToProgramList(ctx context.Context, b *tg_bot.Bot) {
 		return
 	}
 
-	programsCount := h.programRepository.CountAll(ctx)
+	programsCount, err := h.programRepository.CountAll(ctx)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	kb := inline_keyboards.ProgramList(programs, programsCount, limit, offset)
 
@@ -115,7 +125,12 @@ func (h *backHandler) backToMeasureList(ctx context.Context, b *tg_bot.Bot) {
 	limit := utils_context.GetLimitFromContext(ctx)
 	offset := utils_context.GetOffsetFromContext(ctx)
 
-	measures := h.measureRepository.GetAll(ctx, limit, offset)
+	measures, err := h.measureRepository.GetAll(ctx, limit, offset)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	if len(measures) == 0 {
 		msg := messages.MeasuresNotFoundMessage()
@@ -124,7 +139,12 @@ func (h *backHandler) backToMeasureList(ctx context.Context, b *tg_bot.Bot) {
 		return
 	}
 
-	measuresCount := h.measureRepository.CountAll(ctx)
+	measuresCount, err := h.measureRepository.CountAll(ctx)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	kb := inline_keyboards.MeasureList(measures, measuresCount, limit, offset)
 
@@ -136,7 +156,12 @@ func (h *backHandler) backToPendingUsersList(ctx context.Context, b *tg_bot.Bot)
 	limit := utils_context.GetLimitFromContext(ctx)
 	offset := utils_context.GetOffsetFromContext(ctx)
 
-	users := h.userRepository.GetPendingUsers(ctx, limit, offset)
+	users, err := h.userRepository.GetPendingUsers(ctx, limit, offset)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	if len(users) == 0 {
 		msg := messages.NoPendingUsersMessage()
@@ -145,7 +170,12 @@ func (h *backHandler) backToPendingUsersList(ctx context.Context, b *tg_bot.Bot)
 		return
 	}
 
-	usersCount := h.userRepository.CountPendingUsers(ctx)
+	usersCount, err := h.userRepository.CountPendingUsers(ctx)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	kb := inline_keyboards.PendingUsersList(users, usersCount, limit, offset)
 
@@ -157,7 +187,12 @@ func (h *backHandler) backToClientList(ctx context.Context, b *tg_bot.Bot) {
 	limit := utils_context.GetLimitFromContext(ctx)
 	offset := utils_context.GetOffsetFromContext(ctx)
 
-	clients := h.userRepository.GetClients(ctx, limit, offset)
+	clients, err := h.userRepository.GetClients(ctx, limit, offset)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	if len(clients) == 0 {
 		msg := messages.NoClientsMessage()
@@ -166,7 +201,12 @@ func (h *backHandler) backToClientList(ctx context.Context, b *tg_bot.Bot) {
 		return
 	}
 
-	clientsCount := h.userRepository.CountClients(ctx)
+	clientsCount, err := h.userRepository.CountClients(ctx)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	kb := inline_keyboards.ClientList(clients, clientsCount, limit, offset)
 
@@ -11,9 +11,11 @@ import (
 	"rezvin-pro-bot/src/repositories"
 	"rezvin-pro-bot/src/services"
 	"rezvin-pro-bot/src/utils/context"
+	"rezvin-pro-bot/src/utils/export"
 	"rezvin-pro-bot/src/utils/inline_keyboards"
 	"rezvin-pro-bot/src/utils/messages"
 	"strings"
+	"time"
 )
 
 type IClientHandler interface {
@@ -23,22 +25,34 @@ type IClientHandler interface {
 type clientHandlerDependencies struct {
 	dig.In
 
-	Logger         logger.ILogger               `name:"Logger"`
-	SenderService  services.ISenderService      `name:"SenderService"`
-	UserRepository repositories.IUserRepository `name:"UserRepository"`
+	Logger                      logger.ILogger                            `name:"Logger"`
+	SenderService               services.ISenderService                   `name:"SenderService"`
+	UserRepository              repositories.IUserRepository              `name:"UserRepository"`
+	UserProgramRepository       repositories.IUserProgramRepository       `name:"UserProgramRepository"`
+	UserResultRepository        repositories.IUserResultRepository        `name:"UserResultRepository"`
+	UserMeasureRepository       repositories.IUserMeasureRepository       `name:"UserMeasureRepository"`
+	UserResultHistoryRepository repositories.IUserResultHistoryRepository `name:"UserResultHistoryRepository"`
 }
 
 type clientHandler struct {
-	logger         logger.ILogger
-	senderService  services.ISenderService
-	userRepository repositories.IUserRepository
+	logger                      logger.ILogger
+	senderService               services.ISenderService
+	userRepository              repositories.IUserRepository
+	userProgramRepository       repositories.IUserProgramRepository
+	userResultRepository        repositories.IUserResultRepository
+	userMeasureRepository       repositories.IUserMeasureRepository
+	userResultHistoryRepository repositories.IUserResultHistoryRepository
 }
 
 func NewClientHandler(deps clientHandlerDependencies) *clientHandler {
 	return &clientHandler{
-		logger:         deps.Logger,
-		senderService:  deps.SenderService,
-		userRepository: deps.UserRepository,
+		logger:                      deps.Logger,
+		senderService:               deps.SenderService,
+		userRepository:              deps.UserRepository,
+		userProgramRepository:       deps.UserProgramRepository,
+		userResultRepository:        deps.UserResultRepository,
+		userMeasureRepository:       deps.UserMeasureRepository,
+		userResultHistoryRepository: deps.UserResultHistoryRepository,
 	}
 }
 
@@ -50,12 +64,17 @@ func (h *clientHandler) Handle(ctx context.Context, b *tg_bot.Bot, update *tg_mo
 		return
 	}
 
+	if strings.HasPrefix(callBackQueryData, constants.ClientExport) {
+		h.export(ctx, b)
+		return
+	}
+
 	if strings.HasPrefix(callBackQueryData, constants.ClientList) {
 		h.list(ctx, b)
 		return
 	}
 
-	h.logger.Warn(fmt.Sprintf("Unknown client callback query data: %s", callBackQueryData))
+	h.logger.WithContext(ctx).Warn(fmt.Sprintf("Unknown client callback query data: %s", callBackQueryData))
 }
 
 func (h *clientHandler) list(ctx context.Context, b *tg_bot.Bot) {
@@ -63,7 +82,12 @@ func (h *clientHandler) list(ctx context.Context, b *tg_bot.Bot) {
 	limit := utils_context.GetLimitFromContext(ctx)
 	offset := utils_context.GetOffsetFromContext(ctx)
 
-	clients := h.userRepository.GetClients(ctx, limit, offset)
+	clients, err := h.userRepository.GetClients(ctx, limit, offset)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	if len(clients) == 0 {
 		msg := messages.NoClientsMessage()
@@ -72,7 +96,12 @@ func (h *clientHandler) list(ctx context.Context, b *tg_bot.Bot) {
 		return
 	}
 
-	clientCount := h.userRepository.CountClients(ctx)
+	clientCount, err := h.userRepository.CountClients(ctx)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	kb := inline_keyboards.ClientList(clients, clientCount, limit, offset)
 
@@ -87,3 +116,58 @@ func (h *clientHandler) selected(ctx context.Context, b *tg_bot.Bot) {
 
 	h.senderService.SendWithKb(ctx, b, chatId, msg, inline_keyboards.ClientSelectedMenu(user.Id))
 }
+
+func (h *clientHandler) export(ctx context.Context, b *tg_bot.Bot) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+	user := utils_context.GetUserFromContext(ctx)
+
+	userPrograms, err := h.userProgramRepository.GetAllByUserId(ctx, user.Id)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	userMeasures, err := h.userMeasureRepository.GetAllByUserId(ctx, user.Id)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	userHistory, err := h.userResultHistoryRepository.GetAllByUserId(ctx, user.Id)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	data := export.ClientData{
+		UserPrograms: userPrograms,
+		UserHistory:  userHistory,
+		UserMeasures: userMeasures,
+	}
+
+	for _, userProgram := range data.UserPrograms {
+		records, err := h.userResultRepository.GetAllByUserProgramId(ctx, userProgram.Id)
+
+		if err != nil {
+			h.senderService.SendError(ctx, b, chatId, err)
+			return
+		}
+
+		data.UserResults = append(data.UserResults, records...)
+	}
+
+	archive, err := export.ClientArchive(data)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	msg := messages.ClientExportMessage(user.GetPrivateName(), len(data.UserPrograms), len(data.UserResults), len(data.UserMeasures))
+	kb := inline_keyboards.ClientSelectedOk(user.Id)
+
+	h.senderService.SendDocumentWithKb(ctx, b, chatId, export.ClientArchiveName(*user, time.Now()), archive, msg, kb)
+}
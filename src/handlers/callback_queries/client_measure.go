@@ -12,6 +12,7 @@ import (
 	"rezvin-pro-bot/src/models"
 	"rezvin-pro-bot/src/repositories"
 	"rezvin-pro-bot/src/services"
+	"rezvin-pro-bot/src/utils/chart"
 	"rezvin-pro-bot/src/utils/context"
 	"rezvin-pro-bot/src/utils/inline_keyboards"
 	"rezvin-pro-bot/src/utils/messages"
@@ -42,13 +43,17 @@ type clientMeasureHandler struct {
 }
 
 func NewClientMeasureHandler(deps clientMeasureHandlerDependencies) *clientMeasureHandler {
-	return &clientMeasureHandler{
+	h := &clientMeasureHandler{
 		logger:                deps.Logger,
 		senderService:         deps.SenderService,
 		conversationService:   deps.ConversationService,
 		measureRepository:     deps.MeasureRepository,
 		userMeasureRepository: deps.UserMeasureRepository,
 	}
+
+	h.conversationService.RegisterStep(constants.ClientMeasureValueState, h.addValue)
+
+	return h
 }
 
 func (h *clientMeasureHandler) Handle(ctx context.Context, b *tg_bot.Bot, update *tg_models.Update) {
@@ -79,7 +84,12 @@ func (h *clientMeasureHandler) Handle(ctx context.Context, b *tg_bot.Bot, update
 		return
 	}
 
-	h.logger.Warn(fmt.Sprintf("Unknown client measure callback query data: %s", callBackQueryData))
+	if strings.HasPrefix(callBackQueryData, constants.ClientMeasureChart) {
+		h.chart(ctx, b)
+		return
+	}
+
+	h.logger.WithContext(ctx).Warn(fmt.Sprintf("Unknown client measure callback query data: %s", callBackQueryData))
 }
 
 func (h *clientMeasureHandler) selected(ctx context.Context, b *tg_bot.Bot) {
@@ -98,16 +108,24 @@ func (h *clientMeasureHandler) delete(ctx context.Context, b *tg_bot.Bot) {
 	user := utils_context.GetUserFromContext(ctx)
 	measure := utils_context.GetMeasureFromContext(ctx)
 
-	lastUserMeasure := h.userMeasureRepository.GetLastByUserIdAndMeasureId(ctx, user.Id, measure.Id)
+	lastUserMeasure, err := h.userMeasureRepository.GetLastByUserIdAndMeasureId(ctx, user.Id, measure.Id)
 
-	if lastUserMeasure == nil {
+	if errors.Is(err, repositories.ErrNotFound) {
 		msg := messages.NoClientMeasureResultsMessage(user.GetPrivateName(), measure.Name)
 		kb := inline_keyboards.ClientMeasureOk(user.Id, measure.Id)
 		h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
 		return
 	}
 
-	h.userMeasureRepository.DeleteById(ctx, lastUserMeasure.Id)
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	if err := h.userMeasureRepository.DeleteById(ctx, lastUserMeasure.Id); err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	msg := messages.ClientLastMeasureDeletedMessage(user.GetPrivateName(), measure.Name)
 
@@ -121,7 +139,12 @@ func (h *clientMeasureHandler) list(ctx context.Context, b *tg_bot.Bot) {
 	limit := utils_context.GetLimitFromContext(ctx)
 	offset := utils_context.GetOffsetFromContext(ctx)
 
-	measures := h.measureRepository.GetAll(ctx, limit, offset)
+	measures, err := h.measureRepository.GetAll(ctx, limit, offset)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	if len(measures) == 0 {
 		msg := messages.MeasuresNotFoundMessage()
@@ -130,7 +153,12 @@ func (h *clientMeasureHandler) list(ctx context.Context, b *tg_bot.Bot) {
 		return
 	}
 
-	measuresCount := h.measureRepository.CountAll(ctx)
+	measuresCount, err := h.measureRepository.CountAll(ctx)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	msg := messages.SelectClientMeasureMessage(user.GetPrivateName())
 
@@ -141,31 +169,43 @@ func (h *clientMeasureHandler) list(ctx context.Context, b *tg_bot.Bot) {
 
 func (h *clientMeasureHandler) add(ctx context.Context, b *tg_bot.Bot) {
 	chatId := utils_context.GetChatIdFromContext(ctx)
+	params := utils_context.GetParamsFromContext(ctx)
 	user := utils_context.GetUserFromContext(ctx)
 	measure := utils_context.GetMeasureFromContext(ctx)
 
 	msg := messages.EnterClientMeasureValueMessage(user.GetPrivateName(), measure.Name, measure.Units)
 
-	valueMsgId := h.senderService.SendSafe(ctx, b, chatId, msg)
+	h.conversationService.Start(ctx, b, chatId, constants.ClientMeasureValueState, *params, msg)
+}
+
+func (h *clientMeasureHandler) addValue(ctx context.Context, b *tg_bot.Bot, conversation *models.Conversation, answer string) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+	user := utils_context.GetUserFromContext(ctx)
+	measure := utils_context.GetMeasureFromContext(ctx)
 
-	value, err := h.getValue(ctx, b)
+	value, err := validate_data.ValidateValueAnswer(answer)
 
 	if err != nil {
-		h.senderService.Delete(context.Background(), b, chatId, valueMsgId)
+		h.senderService.Send(ctx, b, chatId, err.Error())
 		return
 	}
 
-	h.userMeasureRepository.Create(ctx, models.UserMeasure{
+	err = h.userMeasureRepository.Create(ctx, models.UserMeasure{
 		UserId:    user.Id,
 		MeasureId: measure.Id,
 		Value:     value,
 	})
 
-	msg = messages.ClientMeasureAddedMessage(user.GetPrivateName(), measure.Name, measure.Units, value)
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	msg := messages.ClientMeasureAddedMessage(user.GetPrivateName(), measure.Name, measure.Units, value)
 
 	kb := inline_keyboards.ClientMeasureOk(user.Id, measure.Id)
 
-	h.senderService.Delete(ctx, b, chatId, valueMsgId)
+	h.conversationService.Finish(ctx, b, conversation)
 	h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
 }
 
@@ -174,7 +214,12 @@ func (h *clientMeasureHandler) result(ctx context.Context, b *tg_bot.Bot) {
 	user := utils_context.GetUserFromContext(ctx)
 	measure := utils_context.GetMeasureFromContext(ctx)
 
-	userMeasures := h.userMeasureRepository.GetAllByUserIdAndMeasureId(ctx, user.Id, measure.Id)
+	userMeasures, err := h.userMeasureRepository.GetAllByUserIdAndMeasureId(ctx, user.Id, measure.Id)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	if len(userMeasures) == 0 {
 		msg := messages.NoClientMeasureResultsMessage(user.GetPrivateName(), measure.Name)
@@ -190,24 +235,32 @@ func (h *clientMeasureHandler) result(ctx context.Context, b *tg_bot.Bot) {
 	h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
 }
 
-func (h *clientMeasureHandler) getValue(ctx context.Context, b *tg_bot.Bot) (float64, error) {
+func (h *clientMeasureHandler) chart(ctx context.Context, b *tg_bot.Bot) {
 	chatId := utils_context.GetChatIdFromContext(ctx)
+	user := utils_context.GetUserFromContext(ctx)
+	measure := utils_context.GetMeasureFromContext(ctx)
 
-	conversation := h.conversationService.CreateConversation(chatId)
-	defer h.conversationService.DeleteConversation(chatId)
+	userMeasures, err := h.userMeasureRepository.GetAllByUserIdAndMeasureId(ctx, user.Id, measure.Id)
 
-	answer := conversation.WaitAnswer()
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
-	if ctx.Err() != nil {
-		return 0, errors.New("context canceled")
+	kb := inline_keyboards.ClientMeasureOk(user.Id, measure.Id)
+
+	if len(userMeasures) == 0 {
+		msg := messages.NoClientMeasureResultsMessage(user.GetPrivateName(), measure.Name)
+		h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+		return
 	}
 
-	value, err := validate_data.ValidateValueAnswer(answer)
+	photo, err := chart.RenderLineChart(chart.MeasureSeries(userMeasures))
 
 	if err != nil {
-		h.senderService.Send(ctx, b, chatId, err.Error())
-		return h.getValue(ctx, b)
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
 	}
 
-	return value, nil
+	h.senderService.SendPhotoWithKb(ctx, b, chatId, photo, messages.ClientMeasureChartMessage(user.GetPrivateName(), *measure), kb)
 }
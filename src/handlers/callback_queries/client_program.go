@@ -2,6 +2,7 @@ package callback_queries
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	tg_bot "github.com/go-telegram/bot"
 	tg_models "github.com/go-telegram/bot/models"
@@ -29,6 +30,7 @@ type clientProgramHandlerDependencies struct {
 	ProgramRepository     repositories.IProgramRepository     `name:"ProgramRepository"`
 	UserProgramRepository repositories.IUserProgramRepository `name:"UserProgramRepository"`
 	UserResultRepository  repositories.IUserResultRepository  `name:"UserResultRepository"`
+	UserResultService     services.IUserResultService         `name:"UserResultService"`
 }
 
 type clientProgramHandler struct {
@@ -37,6 +39,7 @@ type clientProgramHandler struct {
 	programRepository     repositories.IProgramRepository
 	userProgramRepository repositories.IUserProgramRepository
 	userResultRepository  repositories.IUserResultRepository
+	userResultService     services.IUserResultService
 }
 
 func NewClientProgramHandler(deps clientProgramHandlerDependencies) *clientProgramHandler {
@@ -46,6 +49,7 @@ func NewClientProgramHandler(deps clientProgramHandlerDependencies) *clientProgr
 		programRepository:     deps.ProgramRepository,
 		userProgramRepository: deps.UserProgramRepository,
 		userResultRepository:  deps.UserResultRepository,
+		userResultService:     deps.UserResultService,
 	}
 }
 
@@ -77,7 +81,7 @@ func (h *clientProgramHandler) Handle(ctx context.Context, b *tg_bot.Bot, update
 		return
 	}
 
-	h.logger.Warn(fmt.Sprintf("Unknown client program callback query data: %s", callBackQueryData))
+	h.logger.WithContext(ctx).Warn(fmt.Sprintf("Unknown client program callback query data: %s", callBackQueryData))
 }
 
 func (h *clientProgramHandler) selected(ctx context.Context, b *tg_bot.Bot) {
@@ -86,7 +90,7 @@ func (h *clientProgramHandler) selected(ctx context.Context, b *tg_bot.Bot) {
 	userProgram := utils_context.GetUserProgramFromContext(ctx)
 
 	if userProgram.UserId != user.Id {
-		h.logger.Error(fmt.Sprintf("UserProgram %d not assigned for user %d", userProgram.Id, user.Id))
+		h.logger.WithContext(ctx).Error(fmt.Sprintf("UserProgram %d not assigned for user %d", userProgram.Id, user.Id))
 		msg := messages.ClientProgramNotAssignedMessage(user.GetPrivateName(), userProgram.Name())
 		kb := inline_keyboards.ClientSelectedOk(user.Id)
 		h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
@@ -105,15 +109,21 @@ func (h *clientProgramHandler) delete(ctx context.Context, b *tg_bot.Bot) {
 	userProgram := utils_context.GetUserProgramFromContext(ctx)
 
 	if userProgram.UserId != user.Id {
-		h.logger.Error(fmt.Sprintf("UserProgram %d not assigned for user %d", userProgram.Id, user.Id))
+		h.logger.WithContext(ctx).Error(fmt.Sprintf("UserProgram %d not assigned for user %d", userProgram.Id, user.Id))
 		msg := messages.ClientProgramNotAssignedMessage(user.GetPrivateName(), userProgram.Name())
 		kb := inline_keyboards.ClientSelectedOk(user.Id)
 		h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
 		return
 	}
 
-	h.userProgramRepository.DeleteById(ctx, userProgram.Id)
-	h.userResultRepository.DeleteByUserProgramId(ctx, userProgram.Id)
+	if err := h.userProgramRepository.DeleteById(ctx, userProgram.Id); err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+	if err := h.userResultRepository.DeleteByUserProgramId(ctx, userProgram.Id); err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	userMsg := messages.UserProgramUnassignedMessage(userProgram.Name())
 	userKb := inline_keyboards.UserMenuOk()
@@ -131,7 +141,12 @@ func (h *clientProgramHandler) list(ctx context.Context, b *tg_bot.Bot) {
 	limit := utils_context.GetLimitFromContext(ctx)
 	offset := utils_context.GetOffsetFromContext(ctx)
 
-	programs := h.userProgramRepository.GetByUserId(ctx, user.Id, limit, offset)
+	programs, err := h.userProgramRepository.GetByUserId(ctx, user.Id, limit, offset)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	if len(programs) == 0 {
 		msg := messages.NoClientProgramsMessage(user.GetPrivateName())
@@ -140,7 +155,12 @@ func (h *clientProgramHandler) list(ctx context.Context, b *tg_bot.Bot) {
 		return
 	}
 
-	programsCount := h.userProgramRepository.CountAllByUserId(ctx, user.Id)
+	programsCount, err := h.userProgramRepository.CountAllByUserId(ctx, user.Id)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	msg := messages.SelectClientProgramMessage(user.GetPrivateName())
 
@@ -155,7 +175,12 @@ func (h *clientProgramHandler) add(ctx context.Context, b *tg_bot.Bot) {
 	limit := utils_context.GetLimitFromContext(ctx)
 	offset := utils_context.GetOffsetFromContext(ctx)
 
-	programs := h.programRepository.GetNotAssignedToUser(ctx, user.Id, limit, offset)
+	programs, err := h.programRepository.GetNotAssignedToUser(ctx, user.Id, limit, offset)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	if len(programs) == 0 {
 		msg := messages.NoProgramsForClientMessage(user.GetPrivateName())
@@ -164,7 +189,12 @@ func (h *clientProgramHandler) add(ctx context.Context, b *tg_bot.Bot) {
 		return
 	}
 
-	programsCount := h.programRepository.CountNotAssignedToUser(ctx, user.Id)
+	programsCount, err := h.programRepository.CountNotAssignedToUser(ctx, user.Id)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	msg := messages.SelectClientProgramMessage(user.GetPrivateName())
 
@@ -178,34 +208,41 @@ func (h *clientProgramHandler) assign(ctx context.Context, b *tg_bot.Bot) {
 	user := utils_context.GetUserFromContext(ctx)
 	program := utils_context.GetProgramFromContext(ctx)
 
-	userProgram := h.userProgramRepository.GetByUserIdAndProgramId(ctx, user.Id, program.Id)
+	_, err := h.userProgramRepository.GetByUserIdAndProgramId(ctx, user.Id, program.Id)
 
-	if userProgram != nil {
+	if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	if err == nil {
 		msg := messages.ClientProgramAlreadyAssignedMessage(user.GetPrivateName(), program.Name)
 		kb := inline_keyboards.ClientSelectedOk(user.Id)
 		h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
 		return
 	}
 
-	userProgramId := h.userProgramRepository.Create(ctx, models.UserProgram{
+	userProgramId, err := h.userProgramRepository.Create(ctx, models.UserProgram{
 		UserId:    user.Id,
 		ProgramId: program.Id,
 	})
 
-	records := make([]models.UserResult, 0, 4*len(program.Exercises))
+	if errors.Is(err, repositories.ErrConflict) {
+		msg := messages.ClientProgramAlreadyAssignedMessage(user.GetPrivateName(), program.Name)
+		kb := inline_keyboards.ClientSelectedOk(user.Id)
+		h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+		return
+	}
 
-	for _, exercise := range program.Exercises {
-		for _, rep := range constants.RepsList {
-			records = append(records, models.UserResult{
-				UserProgramId: userProgramId,
-				ExerciseId:    exercise.Id,
-				Weight:        0,
-				Reps:          uint(rep),
-			})
-		}
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
 	}
 
-	h.userResultRepository.CreateMany(ctx, records)
+	if err = h.userResultService.SyncResults(ctx, userProgramId, *program, program.Exercises); err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	userMsg := messages.UserProgramAssignedMessage(program.Name)
 	userKb := inline_keyboards.UserMenuOk()
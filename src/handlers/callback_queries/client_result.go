@@ -2,20 +2,20 @@ package callback_queries
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	tg_bot "github.com/go-telegram/bot"
 	tg_models "github.com/go-telegram/bot/models"
 	"go.uber.org/dig"
 	"rezvin-pro-bot/src/constants"
 	"rezvin-pro-bot/src/internal/logger"
-	"rezvin-pro-bot/src/models"
 	"rezvin-pro-bot/src/repositories"
 	"rezvin-pro-bot/src/services"
+	"rezvin-pro-bot/src/types"
+	"rezvin-pro-bot/src/utils/chart"
 	"rezvin-pro-bot/src/utils/context"
 	"rezvin-pro-bot/src/utils/inline_keyboards"
 	"rezvin-pro-bot/src/utils/messages"
-	"rezvin-pro-bot/src/utils/validate"
+	"rezvin-pro-bot/src/utils/rep_scheme"
 	"strings"
 )
 
@@ -26,29 +26,57 @@ type IClientResultHandler interface {
 type clientResultHandlerDependencies struct {
 	dig.In
 
-	Logger               logger.ILogger                     `name:"Logger"`
-	ConversationService  services.IConversationService      `name:"ConversationService"`
-	SenderService        services.ISenderService            `name:"SenderService"`
-	ExerciseRepository   repositories.IExerciseRepository   `name:"ExerciseRepository"`
-	UserResultRepository repositories.IUserResultRepository `name:"UserResultRepository"`
+	Logger                      logger.ILogger                            `name:"Logger"`
+	WizardService               services.IWizardService                   `name:"WizardService"`
+	SenderService               services.ISenderService                   `name:"SenderService"`
+	ExerciseRepository          repositories.IExerciseRepository          `name:"ExerciseRepository"`
+	UserResultRepository        repositories.IUserResultRepository        `name:"UserResultRepository"`
+	UserResultHistoryRepository repositories.IUserResultHistoryRepository `name:"UserResultHistoryRepository"`
+	UserResultService           services.IUserResultService               `name:"UserResultService"`
 }
 
 type clientResultHandler struct {
-	logger               logger.ILogger
-	conversationService  services.IConversationService
-	senderService        services.ISenderService
-	exerciseRepository   repositories.IExerciseRepository
-	userResultRepository repositories.IUserResultRepository
+	logger                      logger.ILogger
+	wizardService               services.IWizardService
+	senderService               services.ISenderService
+	exerciseRepository          repositories.IExerciseRepository
+	userResultRepository        repositories.IUserResultRepository
+	userResultHistoryRepository repositories.IUserResultHistoryRepository
+	userResultService           services.IUserResultService
 }
 
 func NewClientResultHandler(deps clientResultHandlerDependencies) *clientResultHandler {
-	return &clientResultHandler{
-		logger:               deps.Logger,
-		conversationService:  deps.ConversationService,
-		senderService:        deps.SenderService,
-		exerciseRepository:   deps.ExerciseRepository,
-		userResultRepository: deps.UserResultRepository,
+	h := &clientResultHandler{
+		logger:                      deps.Logger,
+		wizardService:               deps.WizardService,
+		senderService:               deps.SenderService,
+		exerciseRepository:          deps.ExerciseRepository,
+		userResultRepository:        deps.UserResultRepository,
+		userResultHistoryRepository: deps.UserResultHistoryRepository,
+		userResultService:           deps.UserResultService,
 	}
+
+	h.wizardService.Register(types.Wizard{
+		State: constants.ClientResultWeightState,
+		Steps: []types.WizardStep{
+			resultWeightStep(func(ctx context.Context) string {
+				trainer := utils_context.GetCurrentUserFromContext(ctx)
+				user := utils_context.GetUserFromContext(ctx)
+				record := utils_context.GetUserResultFromContext(ctx)
+
+				return messages.EnterClientResultMessage(user.GetPrivateName(), record.Name(), trainer.GetWeightUnit())
+			}),
+		},
+		Summary: func(ctx context.Context, data types.WizardData) string {
+			user := utils_context.GetUserFromContext(ctx)
+			record := utils_context.GetUserResultFromContext(ctx)
+
+			return messages.ClientResultSummaryMessage(user.GetPrivateName(), record.Name(), record.Reps, resultWeightText(ctx, data))
+		},
+		Complete: h.exerciseRepsComplete,
+	})
+
+	return h
 }
 
 func (h *clientResultHandler) Handle(ctx context.Context, b *tg_bot.Bot, update *tg_models.Update) {
@@ -74,23 +102,41 @@ func (h *clientResultHandler) Handle(ctx context.Context, b *tg_bot.Bot, update
 		return
 	}
 
-	h.logger.Warn(fmt.Sprintf("Unknown client result callback query data: %s", callBackQueryData))
+	if strings.HasPrefix(callBackQueryData, constants.ClientResultHistory) {
+		h.history(ctx, b)
+		return
+	}
+
+	if strings.HasPrefix(callBackQueryData, constants.ClientResultChart) {
+		h.chart(ctx, b)
+		return
+	}
+
+	h.logger.WithContext(ctx).Warn(fmt.Sprintf("Unknown client result callback query data: %s", callBackQueryData))
 }
 
 func (h *clientResultHandler) list(ctx context.Context, b *tg_bot.Bot) {
 	chatId := utils_context.GetChatIdFromContext(ctx)
+	trainer := utils_context.GetCurrentUserFromContext(ctx)
 	user := utils_context.GetUserFromContext(ctx)
 	userProgram := utils_context.GetUserProgramFromContext(ctx)
 
 	if userProgram.UserId != user.Id {
-		h.logger.Error(fmt.Sprintf("UserProgram %d not assigned for user %d", userProgram.Id, user.Id))
+		h.logger.WithContext(ctx).Error(fmt.Sprintf("UserProgram %d not assigned for user %d", userProgram.Id, user.Id))
 		msg := messages.ClientProgramNotAssignedMessage(user.GetPrivateName(), userProgram.Name())
 		kb := inline_keyboards.ClientSelectedOk(user.Id)
 		h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
 		return
 	}
 
-	records := h.userResultRepository.GetAllByUserProgramId(ctx, userProgram.Id)
+	results, err := h.userResultRepository.GetAllByUserProgramId(ctx, userProgram.Id)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	records := rep_scheme.FilterResults(userProgram.Program, results)
 
 	if len(records) == 0 {
 		msg := messages.NoRecordsForClientProgramMessage(user.GetPrivateName(), userProgram.Name())
@@ -99,7 +145,7 @@ func (h *clientResultHandler) list(ctx context.Context, b *tg_bot.Bot) {
 		return
 	}
 
-	msg := messages.ClientProgramResultsMessage(user.GetPrivateName(), userProgram.Name(), records)
+	msg := messages.ClientProgramResultsMessage(user.GetPrivateName(), userProgram.Name(), userProgram.Program.GetOneRepMaxFormula(), records, trainer.GetWeightUnit())
 
 	kb := inline_keyboards.ClientProgramSelectedOk(user.Id, userProgram.Id)
 	h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
@@ -113,14 +159,19 @@ func (h *clientResultHandler) exerciseList(ctx context.Context, b *tg_bot.Bot) {
 	offset := utils_context.GetOffsetFromContext(ctx)
 
 	if userProgram.UserId != user.Id {
-		h.logger.Error(fmt.Sprintf("UserProgram %d not assigned for user %d", userProgram.Id, user.Id))
+		h.logger.WithContext(ctx).Error(fmt.Sprintf("UserProgram %d not assigned for user %d", userProgram.Id, user.Id))
 		msg := messages.ClientProgramNotAssignedMessage(user.GetPrivateName(), userProgram.Name())
 		kb := inline_keyboards.ClientSelectedOk(user.Id)
 		h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
 		return
 	}
 
-	exercises := h.exerciseRepository.GetByProgramId(ctx, userProgram.ProgramId, limit, offset)
+	exercises, err := h.exerciseRepository.GetByProgramId(ctx, userProgram.ProgramId, limit, offset)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	if len(exercises) == 0 {
 		msg := messages.NoExercisesMessage(userProgram.Name())
@@ -129,7 +180,12 @@ func (h *clientResultHandler) exerciseList(ctx context.Context, b *tg_bot.Bot) {
 		return
 	}
 
-	exercisesCount := h.exerciseRepository.CountByProgramId(ctx, userProgram.ProgramId)
+	exercisesCount, err := h.exerciseRepository.CountByProgramId(ctx, userProgram.ProgramId)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	msg := messages.ClientProgramResultsSelectExerciseMessage(user.GetPrivateName(), userProgram.Name())
 
@@ -138,35 +194,20 @@ func (h *clientResultHandler) exerciseList(ctx context.Context, b *tg_bot.Bot) {
 	h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
 }
 
-func (h *clientResultHandler) getWeight(ctx context.Context, b *tg_bot.Bot) (int, error) {
-	chatId := utils_context.GetChatIdFromContext(ctx)
-
-	conversation := h.conversationService.CreateConversation(chatId)
-	defer h.conversationService.DeleteConversation(chatId)
-
-	answer := conversation.WaitAnswer()
-
-	if ctx.Err() != nil {
-		return 0, errors.New("context canceled")
-	}
-
-	weight, err := validate_data.ValidateWeightAnswer(answer)
-
-	if err != nil {
-		h.senderService.Send(ctx, b, chatId, err.Error())
-		return h.getWeight(ctx, b)
-	}
-
-	return weight, nil
-}
-
 func (h *clientResultHandler) exerciseSelected(ctx context.Context, b *tg_bot.Bot) {
 	chatId := utils_context.GetChatIdFromContext(ctx)
 	user := utils_context.GetUserFromContext(ctx)
 	userProgram := utils_context.GetUserProgramFromContext(ctx)
 	exercise := utils_context.GetExerciseFromContext(ctx)
 
-	records := h.userResultRepository.GetAllByUserProgramIdAndExerciseId(ctx, userProgram.Id, exercise.Id)
+	results, err := h.userResultRepository.GetAllByUserProgramIdAndExerciseId(ctx, userProgram.Id, exercise.Id)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	records := rep_scheme.FilterResults(userProgram.Program, results)
 
 	if len(records) == 0 {
 		msg := messages.NoRecordsForClientProgramMessage(user.GetPrivateName(), userProgram.Name())
@@ -183,26 +224,108 @@ func (h *clientResultHandler) exerciseSelected(ctx context.Context, b *tg_bot.Bo
 
 func (h *clientResultHandler) exerciseRepsSelected(ctx context.Context, b *tg_bot.Bot) {
 	chatId := utils_context.GetChatIdFromContext(ctx)
+	params := utils_context.GetParamsFromContext(ctx)
+
+	h.wizardService.Start(ctx, b, chatId, constants.ClientResultWeightState, *params)
+}
+
+func (h *clientResultHandler) exerciseRepsComplete(ctx context.Context, b *tg_bot.Bot, data types.WizardData) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+	trainer := utils_context.GetCurrentUserFromContext(ctx)
+	user := utils_context.GetUserFromContext(ctx)
+	record := utils_context.GetUserResultFromContext(ctx)
+	weight := data.Float(constants.ResultWeightConversationKey)
+
+	if _, err := h.userResultService.SaveResult(ctx, b, *user, *record, weight, constants.TrainerResultSource, trainer.Id); err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	msg := messages.ClientProgramResultModifiedMessage(user.GetPrivateName(), record.Name(), record.Reps)
+	kb := inline_keyboards.ClientProgramSelectedOk(user.Id, record.UserProgramId)
+	h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+}
+
+func (h *clientResultHandler) history(ctx context.Context, b *tg_bot.Bot) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+	trainer := utils_context.GetCurrentUserFromContext(ctx)
 	user := utils_context.GetUserFromContext(ctx)
+	userProgram := utils_context.GetUserProgramFromContext(ctx)
 	record := utils_context.GetUserResultFromContext(ctx)
+	limit := utils_context.GetLimitFromContext(ctx)
+	offset := utils_context.GetOffsetFromContext(ctx)
 
-	userMsg := messages.EnterClientResultMessage(user.GetPrivateName(), record.Name())
+	if userProgram.UserId != user.Id || record.UserProgramId != userProgram.Id {
+		h.logger.WithContext(ctx).Error(fmt.Sprintf("UserResult %d not assigned for user %d", record.Id, user.Id))
+		msg := messages.ClientProgramNotAssignedMessage(user.GetPrivateName(), userProgram.Name())
+		kb := inline_keyboards.ClientSelectedOk(user.Id)
+		h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+		return
+	}
 
-	userMsgId := h.senderService.SendSafe(ctx, b, chatId, userMsg)
+	entries, err := h.userResultHistoryRepository.GetByUserResultId(ctx, record.Id, limit, offset)
 
-	weight, err := h.getWeight(ctx, b)
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+	entriesCount, err := h.userResultHistoryRepository.CountByUserResultId(ctx, record.Id)
 
 	if err != nil {
-		h.senderService.Delete(context.Background(), b, chatId, userMsgId)
+		h.senderService.SendError(ctx, b, chatId, err)
 		return
 	}
 
-	h.userResultRepository.UpdateById(ctx, record.Id, models.UserResult{
-		Weight: weight,
-	})
+	kb := inline_keyboards.ClientResultHistory(user.Id, *record, len(entries), entriesCount, limit, offset)
 
-	msg := messages.ClientProgramResultModifiedMessage(user.GetPrivateName(), record.Name(), record.Reps)
-	kb := inline_keyboards.ClientProgramSelectedOk(user.Id, record.UserProgramId)
-	h.senderService.Delete(ctx, b, chatId, userMsgId)
+	if len(entries) == 0 {
+		msg := messages.NoClientResultHistoryMessage(user.GetPrivateName(), record.Name(), record.Reps)
+		h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+		return
+	}
+
+	msg := messages.ClientResultHistoryMessage(user.GetPrivateName(), record.Name(), record.Reps, entries, offset, trainer.GetWeightUnit())
 	h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
 }
+
+func (h *clientResultHandler) chart(ctx context.Context, b *tg_bot.Bot) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+	trainer := utils_context.GetCurrentUserFromContext(ctx)
+	user := utils_context.GetUserFromContext(ctx)
+	userProgram := utils_context.GetUserProgramFromContext(ctx)
+	exercise := utils_context.GetExerciseFromContext(ctx)
+
+	if userProgram.UserId != user.Id {
+		h.logger.WithContext(ctx).Error(fmt.Sprintf("UserProgram %d not assigned for user %d", userProgram.Id, user.Id))
+		msg := messages.ClientProgramNotAssignedMessage(user.GetPrivateName(), userProgram.Name())
+		kb := inline_keyboards.ClientSelectedOk(user.Id)
+		h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+		return
+	}
+
+	entries, err := h.userResultHistoryRepository.GetAllByUserIdAndExerciseId(ctx, user.Id, exercise.Id)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+	series := chart.ResultSeries(entries, rep_scheme.ForExercise(userProgram.Program, *exercise), trainer.GetWeightUnit())
+
+	kb := inline_keyboards.ClientResultChartOk(user.Id, userProgram.Id, exercise.Id)
+
+	if len(series) == 0 {
+		msg := messages.NoClientResultChartMessage(user.GetPrivateName(), exercise.Name)
+		h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+		return
+	}
+
+	photo, err := chart.RenderLineChart(series)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	msg := messages.ClientResultChartMessage(user.GetPrivateName(), exercise.Name, trainer.GetWeightUnit())
+	h.senderService.SendPhotoWithKb(ctx, b, chatId, photo, msg, kb)
+}
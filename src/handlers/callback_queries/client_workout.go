@@ -0,0 +1,113 @@
+package callback_queries
+
+import (
+	"context"
+	"fmt"
+	tg_bot "github.com/go-telegram/bot"
+	tg_models "github.com/go-telegram/bot/models"
+	"go.uber.org/dig"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/internal/logger"
+	"rezvin-pro-bot/src/repositories"
+	"rezvin-pro-bot/src/services"
+	utils_context "rezvin-pro-bot/src/utils/context"
+	"rezvin-pro-bot/src/utils/inline_keyboards"
+	"rezvin-pro-bot/src/utils/messages"
+	"strings"
+)
+
+type IClientWorkoutHandler interface {
+	Handle(ctx context.Context, b *tg_bot.Bot, update *tg_models.Update)
+}
+
+type clientWorkoutHandlerDependencies struct {
+	dig.In
+
+	Logger        logger.ILogger          `name:"Logger"`
+	SenderService services.ISenderService `name:"SenderService"`
+
+	WorkoutSessionRepository repositories.IWorkoutSessionRepository `name:"WorkoutSessionRepository"`
+}
+
+type clientWorkoutHandler struct {
+	logger                   logger.ILogger
+	senderService            services.ISenderService
+	workoutSessionRepository repositories.IWorkoutSessionRepository
+}
+
+func NewClientWorkoutHandler(deps clientWorkoutHandlerDependencies) *clientWorkoutHandler {
+	return &clientWorkoutHandler{
+		logger:                   deps.Logger,
+		senderService:            deps.SenderService,
+		workoutSessionRepository: deps.WorkoutSessionRepository,
+	}
+}
+
+func (h *clientWorkoutHandler) Handle(ctx context.Context, b *tg_bot.Bot, update *tg_models.Update) {
+	callBackQueryData := update.CallbackQuery.Data
+
+	if strings.HasPrefix(callBackQueryData, constants.ClientWorkoutList) {
+		h.list(ctx, b)
+		return
+	}
+
+	if strings.HasPrefix(callBackQueryData, constants.ClientWorkoutSelected) {
+		h.selected(ctx, b)
+		return
+	}
+
+	h.logger.WithContext(ctx).Warn(fmt.Sprintf("Unknown client workout callback query data: %s", callBackQueryData))
+}
+
+func (h *clientWorkoutHandler) list(ctx context.Context, b *tg_bot.Bot) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+	user := utils_context.GetUserFromContext(ctx)
+	limit := utils_context.GetLimitFromContext(ctx)
+	offset := utils_context.GetOffsetFromContext(ctx)
+
+	sessions, err := h.workoutSessionRepository.GetFinishedByUserId(ctx, user.Id, limit, offset)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	if len(sessions) == 0 {
+		msg := messages.NoClientWorkoutSessionsMessage(user.GetPrivateName())
+		kb := inline_keyboards.ClientSelectedOk(user.Id)
+		h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+		return
+	}
+
+	sessionsCount, err := h.workoutSessionRepository.CountFinishedByUserId(ctx, user.Id)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	msg := messages.SelectClientWorkoutSessionMessage(user.GetPrivateName())
+	kb := inline_keyboards.ClientWorkoutList(user.Id, sessions, sessionsCount, limit, offset)
+
+	h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+}
+
+func (h *clientWorkoutHandler) selected(ctx context.Context, b *tg_bot.Bot) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+	trainer := utils_context.GetCurrentUserFromContext(ctx)
+	user := utils_context.GetUserFromContext(ctx)
+	session := utils_context.GetWorkoutSessionFromContext(ctx)
+
+	if session.UserId != user.Id || !session.IsFinished() {
+		h.logger.WithContext(ctx).Error(fmt.Sprintf("Workout session %d is not a finished session of user %d", session.Id, user.Id))
+		msg := messages.WorkoutSessionNotFoundMessage(session.Id)
+		kb := inline_keyboards.ClientWorkoutSelectedOk(user.Id)
+		h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+		return
+	}
+
+	msg := messages.ClientWorkoutSessionMessage(user.GetPrivateName(), *session, trainer.GetWeightUnit())
+	kb := inline_keyboards.ClientWorkoutSelectedOk(user.Id)
+
+	h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+}
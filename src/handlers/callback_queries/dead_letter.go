@@ -0,0 +1,84 @@
+package callback_queries
+
+import (
+	"context"
+	"fmt"
+	tg_bot "github.com/go-telegram/bot"
+	tg_models "github.com/go-telegram/bot/models"
+	"go.uber.org/dig"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/internal/logger"
+	"rezvin-pro-bot/src/repositories"
+	"rezvin-pro-bot/src/services"
+	utils_context "rezvin-pro-bot/src/utils/context"
+	"rezvin-pro-bot/src/utils/inline_keyboards"
+	"rezvin-pro-bot/src/utils/messages"
+	"strings"
+)
+
+type IDeadLetterHandler interface {
+	Handle(ctx context.Context, b *tg_bot.Bot, update *tg_models.Update)
+}
+
+type deadLetterHandlerDependencies struct {
+	dig.In
+
+	Logger               logger.ILogger                     `name:"Logger"`
+	SenderService        services.ISenderService            `name:"SenderService"`
+	DeadLetterRepository repositories.IDeadLetterRepository `name:"DeadLetterRepository"`
+}
+
+type deadLetterHandler struct {
+	logger               logger.ILogger
+	senderService        services.ISenderService
+	deadLetterRepository repositories.IDeadLetterRepository
+}
+
+func NewDeadLetterHandler(deps deadLetterHandlerDependencies) *deadLetterHandler {
+	return &deadLetterHandler{
+		logger:               deps.Logger,
+		senderService:        deps.SenderService,
+		deadLetterRepository: deps.DeadLetterRepository,
+	}
+}
+
+func (h *deadLetterHandler) Handle(ctx context.Context, b *tg_bot.Bot, update *tg_models.Update) {
+	callbackQueryData := update.CallbackQuery.Data
+
+	if strings.HasPrefix(callbackQueryData, constants.DeadLetterList) {
+		h.list(ctx, b)
+		return
+	}
+
+	h.logger.WithContext(ctx).Warn(fmt.Sprintf("Unknown dead letter callback query data: %s", callbackQueryData))
+}
+
+func (h *deadLetterHandler) list(ctx context.Context, b *tg_bot.Bot) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+	limit := utils_context.GetLimitFromContext(ctx)
+	offset := utils_context.GetOffsetFromContext(ctx)
+
+	deadLetters, err := h.deadLetterRepository.GetAll(ctx, limit, offset)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	if len(deadLetters) == 0 {
+		h.senderService.SendWithKb(ctx, b, chatId, messages.NoDeadLettersMessage(), inline_keyboards.MainOk())
+		return
+	}
+
+	deadLettersCount, err := h.deadLetterRepository.CountAll(ctx)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	msg := messages.DeadLettersMessage(deadLetters, offset)
+	kb := inline_keyboards.DeadLetterList(len(deadLetters), deadLettersCount, limit, offset)
+
+	h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+}
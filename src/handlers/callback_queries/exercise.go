@@ -12,9 +12,12 @@ import (
 	"rezvin-pro-bot/src/models"
 	"rezvin-pro-bot/src/repositories"
 	"rezvin-pro-bot/src/services"
+	"rezvin-pro-bot/src/types"
 	"rezvin-pro-bot/src/utils/context"
 	"rezvin-pro-bot/src/utils/inline_keyboards"
 	"rezvin-pro-bot/src/utils/messages"
+	"rezvin-pro-bot/src/utils/rep_scheme"
+	"rezvin-pro-bot/src/utils/validate"
 	"strings"
 )
 
@@ -27,31 +30,60 @@ type exerciseHandlerDependencies struct {
 
 	Logger              logger.ILogger                `name:"Logger"`
 	ConversationService services.IConversationService `name:"ConversationService"`
+	WizardService       services.IWizardService       `name:"WizardService"`
 	SenderService       services.ISenderService       `name:"SenderService"`
 
 	UserProgramRepository repositories.IUserProgramRepository `name:"UserProgramRepository"`
 	ExerciseRepository    repositories.IExerciseRepository    `name:"ExerciseRepository"`
 	UserResultRepository  repositories.IUserResultRepository  `name:"UserResultRepository"`
+	UserResultService     services.IUserResultService         `name:"UserResultService"`
 }
 
 type exerciseHandler struct {
 	logger                logger.ILogger
 	conversationService   services.IConversationService
+	wizardService         services.IWizardService
 	senderService         services.ISenderService
 	exerciseRepository    repositories.IExerciseRepository
 	userProgramRepository repositories.IUserProgramRepository
 	userResultRepository  repositories.IUserResultRepository
+	userResultService     services.IUserResultService
 }
 
 func NewExerciseHandler(deps exerciseHandlerDependencies) *exerciseHandler {
-	return &exerciseHandler{
+	h := &exerciseHandler{
 		logger:                deps.Logger,
 		conversationService:   deps.ConversationService,
+		wizardService:         deps.WizardService,
 		senderService:         deps.SenderService,
 		userProgramRepository: deps.UserProgramRepository,
 		exerciseRepository:    deps.ExerciseRepository,
 		userResultRepository:  deps.UserResultRepository,
+		userResultService:     deps.UserResultService,
 	}
+
+	h.wizardService.Register(types.Wizard{
+		State: constants.ExerciseAddNameState,
+		Steps: []types.WizardStep{
+			{
+				Key: constants.ExerciseNameConversationKey,
+				Prompt: func(_ context.Context, _ types.WizardData) string {
+					return messages.EnterExerciseNameMessage()
+				},
+				Validate: h.validateExerciseName,
+			},
+		},
+		Summary: func(ctx context.Context, data types.WizardData) string {
+			program := utils_context.GetProgramFromContext(ctx)
+
+			return messages.ExerciseAddSummaryMessage(data.String(constants.ExerciseNameConversationKey), program.Name)
+		},
+		Complete: h.addComplete,
+	})
+
+	h.conversationService.RegisterStep(constants.ExerciseRepSchemeState, h.repSchemeAnswer)
+
+	return h
 }
 
 func (h *exerciseHandler) Handle(ctx context.Context, b *tg_bot.Bot, update *tg_models.Update) {
@@ -67,6 +99,16 @@ func (h *exerciseHandler) Handle(ctx context.Context, b *tg_bot.Bot, update *tg_
 		return
 	}
 
+	if strings.HasPrefix(callbackDataQuery, constants.ExerciseRepSchemes) {
+		h.repSchemeList(ctx, b)
+		return
+	}
+
+	if strings.HasPrefix(callbackDataQuery, constants.ExerciseRepScheme) {
+		h.repScheme(ctx, b)
+		return
+	}
+
 	if strings.HasPrefix(callbackDataQuery, constants.ExerciseDeleteItem) {
 		h.deleteItem(ctx, b)
 		return
@@ -77,31 +119,24 @@ func (h *exerciseHandler) Handle(ctx context.Context, b *tg_bot.Bot, update *tg_
 		return
 	}
 
-	h.logger.Warn(fmt.Sprintf("Unknown exercise callback query data: %s", callbackDataQuery))
+	h.logger.WithContext(ctx).Warn(fmt.Sprintf("Unknown exercise callback query data: %s", callbackDataQuery))
 }
 
-func (h *exerciseHandler) getExerciseName(ctx context.Context, b *tg_bot.Bot, programId uint) (string, error) {
-	chatId := utils_context.GetChatIdFromContext(ctx)
-
-	conversation := h.conversationService.CreateConversation(chatId)
-	defer h.conversationService.DeleteConversation(chatId)
-
-	exerciseName := conversation.WaitAnswer()
-
-	if ctx.Err() != nil {
-		return "", errors.New("context canceled")
-	}
+func (h *exerciseHandler) validateExerciseName(ctx context.Context, exerciseName string) (string, error) {
+	program := utils_context.GetProgramFromContext(ctx)
 
 	if strings.TrimSpace(exerciseName) == "" {
-		h.senderService.Send(ctx, b, chatId, messages.EmptyMessage())
-		return h.getExerciseName(ctx, b, programId)
+		return "", errors.New(messages.EmptyMessage())
 	}
 
-	existingExercise := h.exerciseRepository.GetByNameAndProgramId(ctx, exerciseName, programId)
+	_, err := h.exerciseRepository.GetByNameAndProgramId(ctx, exerciseName, program.Id)
 
-	if existingExercise != nil {
-		h.senderService.Send(ctx, b, chatId, messages.ExerciseNameAlreadyExistsMessage(exerciseName))
-		return h.getExerciseName(ctx, b, programId)
+	if err == nil {
+		return "", errors.New(messages.ExerciseNameAlreadyExistsMessage(exerciseName))
+	}
+
+	if !errors.Is(err, repositories.ErrNotFound) {
+		return "", err
 	}
 
 	return exerciseName, nil
@@ -109,42 +144,42 @@ func (h *exerciseHandler) getExerciseName(ctx context.Context, b *tg_bot.Bot, pr
 
 func (h *exerciseHandler) add(ctx context.Context, b *tg_bot.Bot) {
 	chatId := utils_context.GetChatIdFromContext(ctx)
-	program := utils_context.GetProgramFromContext(ctx)
-
-	exerciseMsgId := h.senderService.SendSafe(ctx, b, chatId, messages.EnterExerciseNameMessage())
+	params := utils_context.GetParamsFromContext(ctx)
 
-	exerciseName, err := h.getExerciseName(ctx, b, program.Id)
+	h.wizardService.Start(ctx, b, chatId, constants.ExerciseAddNameState, *params)
+}
 
-	if err != nil {
-		h.senderService.Delete(context.Background(), b, chatId, exerciseMsgId)
-		return
-	}
+func (h *exerciseHandler) addComplete(ctx context.Context, b *tg_bot.Bot, data types.WizardData) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+	program := utils_context.GetProgramFromContext(ctx)
+	exerciseName := data.String(constants.ExerciseNameConversationKey)
 
-	exerciseId := h.exerciseRepository.Create(ctx, models.Exercise{
+	exercise := models.Exercise{
 		Name:      exerciseName,
 		ProgramId: program.Id,
-	})
+	}
 
-	userPrograms := h.userProgramRepository.GetAllByProgramId(ctx, program.Id)
+	exerciseId, err := h.exerciseRepository.Create(ctx, exercise)
 
-	records := make([]models.UserResult, 0, 4*len(userPrograms))
+	if errors.Is(err, repositories.ErrConflict) {
+		h.senderService.Send(ctx, b, chatId, messages.ExerciseNameAlreadyExistsMessage(exerciseName))
+		return
+	}
 
-	for _, userProgram := range userPrograms {
-		for _, rep := range constants.RepsList {
-			records = append(records, models.UserResult{
-				UserProgramId: userProgram.Id,
-				ExerciseId:    exerciseId,
-				Weight:        0,
-				Reps:          uint(rep),
-			})
-		}
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
 	}
 
-	h.userResultRepository.CreateMany(ctx, records)
+	exercise.Id = exerciseId
+
+	if err = h.userResultService.SyncProgramResults(ctx, *program, []models.Exercise{exercise}); err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	msg := messages.ExerciseSuccessfullyAddedMessage(exerciseName, program.Name)
 	kb := inline_keyboards.ExerciseOk(program.Id)
-	h.senderService.Delete(ctx, b, chatId, exerciseMsgId)
 	h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
 }
 
@@ -171,7 +206,12 @@ func (h *exerciseHandler) delete(ctx context.Context, b *tg_bot.Bot) {
 	limit := utils_context.GetLimitFromContext(ctx)
 	offset := utils_context.GetOffsetFromContext(ctx)
 
-	exercises := h.exerciseRepository.GetByProgramId(ctx, program.Id, limit, offset)
+	exercises, err := h.exerciseRepository.GetByProgramId(ctx, program.Id, limit, offset)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	if len(exercises) == 0 {
 		msg := messages.NoExercisesMessage(program.Name)
@@ -181,7 +221,12 @@ func (h *exerciseHandler) delete(ctx context.Context, b *tg_bot.Bot) {
 		return
 	}
 
-	exercisesCount := h.exerciseRepository.CountByProgramId(ctx, program.Id)
+	exercisesCount, err := h.exerciseRepository.CountByProgramId(ctx, program.Id)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	msg := messages.ExerciseDeleteMessage(program.Name)
 	kb := inline_keyboards.ExerciseDeleteList(program.Id, exercises, exercisesCount, limit, offset)
@@ -201,9 +246,15 @@ func (h *exerciseHandler) deleteItem(ctx context.Context, b *tg_bot.Bot) {
 		return
 	}
 
-	h.exerciseRepository.DeleteById(ctx, exercise.Id)
+	if err := h.exerciseRepository.DeleteById(ctx, exercise.Id); err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
-	h.userResultRepository.DeleteByExerciseId(ctx, exercise.Id)
+	if err := h.userResultRepository.DeleteByExerciseId(ctx, exercise.Id); err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	msg := messages.ExerciseSuccessfullyDeletedMessage(exercise.Name, program.Name)
 
@@ -211,3 +262,92 @@ func (h *exerciseHandler) deleteItem(ctx context.Context, b *tg_bot.Bot) {
 
 	h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
 }
+
+func (h *exerciseHandler) repSchemeList(ctx context.Context, b *tg_bot.Bot) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+	program := utils_context.GetProgramFromContext(ctx)
+	limit := utils_context.GetLimitFromContext(ctx)
+	offset := utils_context.GetOffsetFromContext(ctx)
+
+	exercises, err := h.exerciseRepository.GetByProgramId(ctx, program.Id, limit, offset)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	if len(exercises) == 0 {
+		msg := messages.NoExercisesMessage(program.Name)
+		kb := inline_keyboards.ExerciseOk(program.Id)
+
+		h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+		return
+	}
+
+	exercisesCount, err := h.exerciseRepository.CountByProgramId(ctx, program.Id)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	msg := messages.ExerciseRepSchemeSelectMessage(program.Name)
+	kb := inline_keyboards.ExerciseRepSchemeList(*program, exercises, exercisesCount, limit, offset)
+
+	h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+}
+
+func (h *exerciseHandler) repScheme(ctx context.Context, b *tg_bot.Bot) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+	params := utils_context.GetParamsFromContext(ctx)
+	program := utils_context.GetProgramFromContext(ctx)
+	exercise := utils_context.GetExerciseFromContext(ctx)
+
+	if exercise.ProgramId != program.Id {
+		msg := messages.ExerciseNotFoundMessage(exercise.Id)
+		kb := inline_keyboards.ExerciseOk(program.Id)
+		h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+		return
+	}
+
+	currentRepScheme := rep_scheme.Format(rep_scheme.ForExercise(*program, *exercise))
+
+	h.conversationService.Start(ctx, b, chatId, constants.ExerciseRepSchemeState, *params, messages.EnterExerciseRepSchemeMessage(exercise.Name, currentRepScheme))
+}
+
+func (h *exerciseHandler) repSchemeAnswer(ctx context.Context, b *tg_bot.Bot, conversation *models.Conversation, answer string) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+	program := utils_context.GetProgramFromContext(ctx)
+	exercise := utils_context.GetExerciseFromContext(ctx)
+
+	repScheme := ""
+
+	if strings.TrimSpace(answer) != "-" {
+		validRepScheme, err := validate_data.ValidateRepSchemeAnswer(answer)
+
+		if err != nil {
+			h.senderService.Send(ctx, b, chatId, err.Error())
+			return
+		}
+
+		repScheme = validRepScheme
+	}
+
+	if err := h.exerciseRepository.UpdateRepSchemeById(ctx, exercise.Id, repScheme); err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	exercise.RepScheme = repScheme
+
+	if err := h.userResultService.SyncProgramResults(ctx, *program, []models.Exercise{*exercise}); err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	msg := messages.ExerciseRepSchemeChangedMessage(exercise.Name, rep_scheme.Format(rep_scheme.ForExercise(*program, *exercise)))
+	kb := inline_keyboards.ExerciseOk(program.Id)
+
+	h.conversationService.Finish(ctx, b, conversation)
+	h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+}
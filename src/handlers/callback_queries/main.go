@@ -2,6 +2,7 @@ package callback_queries
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	tg_bot "github.com/go-telegram/bot"
 	tg_models "github.com/go-telegram/bot/models"
@@ -57,7 +58,7 @@ func (h *mainHandler) Handle(ctx context.Context, b *tg_bot.Bot, update *tg_mode
 		return
 	}
 
-	h.logger.Warn(fmt.Sprintf("Unknown main callback query data: %s", callBackQueryData))
+	h.logger.WithContext(ctx).Warn(fmt.Sprintf("Unknown main callback query data: %s", callBackQueryData))
 }
 
 func (h *mainHandler) backToMain(ctx context.Context, b *tg_bot.Bot, update *tg_models.Update) {
@@ -67,9 +68,9 @@ func (h *mainHandler) backToMain(ctx context.Context, b *tg_bot.Bot, update *tg_
 	firstName := bot_utils.GetFirstName(update)
 	lastName := bot_utils.GetLastName(update)
 
-	user := h.userRepository.GetById(ctx, userId)
+	user, err := h.userRepository.GetById(ctx, userId)
 
-	if user == nil {
+	if errors.Is(err, repositories.ErrNotFound) {
 		name := fmt.Sprintf("%s %s", firstName, lastName)
 
 		kb := inline_keyboards.UserRegister()
@@ -77,6 +78,11 @@ func (h *mainHandler) backToMain(ctx context.Context, b *tg_bot.Bot, update *tg_
 		return
 	}
 
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
 	if user.IsAdmin {
 		kb := inline_keyboards.AdminMain()
 		h.senderService.SendWithKb(ctx, b, chatId, messages.AdminMainMessage(), kb)
@@ -12,9 +12,11 @@ import (
 	"rezvin-pro-bot/src/models"
 	"rezvin-pro-bot/src/repositories"
 	"rezvin-pro-bot/src/services"
+	"rezvin-pro-bot/src/types"
 	"rezvin-pro-bot/src/utils/context"
 	"rezvin-pro-bot/src/utils/inline_keyboards"
 	"rezvin-pro-bot/src/utils/messages"
+	"rezvin-pro-bot/src/utils/validate"
 	"strings"
 )
 
@@ -27,6 +29,7 @@ type measureHandlerDependencies struct {
 
 	Logger              logger.ILogger                `name:"Logger"`
 	ConversationService services.IConversationService `name:"ConversationService"`
+	WizardService       services.IWizardService       `name:"WizardService"`
 	SenderService       services.ISenderService       `name:"SenderService"`
 
 	MeasureRepository repositories.IMeasureRepository `name:"MeasureRepository"`
@@ -35,17 +38,51 @@ type measureHandlerDependencies struct {
 type measureHandler struct {
 	logger              logger.ILogger
 	conversationService services.IConversationService
+	wizardService       services.IWizardService
 	senderService       services.ISenderService
 	measureRepository   repositories.IMeasureRepository
 }
 
 func NewMeasureHandler(deps measureHandlerDependencies) *measureHandler {
-	return &measureHandler{
+	h := &measureHandler{
 		logger:              deps.Logger,
 		senderService:       deps.SenderService,
 		conversationService: deps.ConversationService,
+		wizardService:       deps.WizardService,
 		measureRepository:   deps.MeasureRepository,
 	}
+
+	h.wizardService.Register(types.Wizard{
+		State: constants.MeasureAddState,
+		Steps: []types.WizardStep{
+			{
+				Key: constants.MeasureNameConversationKey,
+				Prompt: func(_ context.Context, _ types.WizardData) string {
+					return messages.EnterMeasureNameMessage()
+				},
+				Validate: h.validateMeasureName,
+			},
+			{
+				Key: constants.MeasureUnitsConversationKey,
+				Prompt: func(_ context.Context, data types.WizardData) string {
+					return messages.EnterMeasureUnitsMessage(data.String(constants.MeasureNameConversationKey))
+				},
+				Validate: types.TextValidator(validate_data.ValidateStringAnswer),
+			},
+		},
+		Summary: func(_ context.Context, data types.WizardData) string {
+			return messages.MeasureAddSummaryMessage(
+				data.String(constants.MeasureNameConversationKey),
+				data.String(constants.MeasureUnitsConversationKey),
+			)
+		},
+		Complete: h.addComplete,
+	})
+
+	h.conversationService.RegisterStep(constants.MeasureRenameState, h.renameName)
+	h.conversationService.RegisterStep(constants.MeasureChangeUnitsState, h.changeUnitsAnswer)
+
+	return h
 }
 
 func (h *measureHandler) Handle(ctx context.Context, b *tg_bot.Bot, update *tg_models.Update) {
@@ -86,7 +123,7 @@ func (h *measureHandler) Handle(ctx context.Context, b *tg_bot.Bot, update *tg_m
 		return
 	}
 
-	h.logger.Warn(fmt.Sprintf("Unknown measure callback query data: %s", callbackDataQuery))
+	h.logger.WithContext(ctx).Warn(fmt.Sprintf("Unknown measure callback query data: %s", callbackDataQuery))
 }
 
 func (h *measureHandler) menu(ctx context.Context, b *tg_bot.Bot) {
@@ -99,36 +136,55 @@ func (h *measureHandler) menu(ctx context.Context, b *tg_bot.Bot) {
 
 func (h *measureHandler) add(ctx context.Context, b *tg_bot.Bot) {
 	chatId := utils_context.GetChatIdFromContext(ctx)
+	params := utils_context.GetParamsFromContext(ctx)
 
-	measureNameMsgId := h.senderService.SendSafe(ctx, b, chatId, messages.EnterMeasureNameMessage())
+	h.wizardService.Start(ctx, b, chatId, constants.MeasureAddState, *params)
+}
 
-	measureName, err := h.getMeasureName(ctx, b)
+func (h *measureHandler) validateMeasureName(ctx context.Context, answer string) (string, error) {
+	measureName, err := validate_data.ValidateStringAnswer(answer)
 
 	if err != nil {
-		h.senderService.Delete(context.Background(), b, chatId, measureNameMsgId)
-		return
+		return "", err
 	}
 
-	h.senderService.Delete(ctx, b, chatId, measureNameMsgId)
-	unitsMsgId := h.senderService.SendSafe(ctx, b, chatId, messages.EnterMeasureUnitsMessage(measureName))
+	_, err = h.measureRepository.GetByName(ctx, measureName)
 
-	units, err := h.getMeasureUnits(ctx, b)
+	if err == nil {
+		return "", errors.New(messages.MeasureNameAlreadyExistsMessage(measureName))
+	}
 
-	if err != nil {
-		h.senderService.Delete(context.Background(), b, chatId, unitsMsgId)
-		return
+	if !errors.Is(err, repositories.ErrNotFound) {
+		return "", err
 	}
 
-	programId := h.measureRepository.Create(ctx, models.Measure{
+	return measureName, nil
+}
+
+func (h *measureHandler) addComplete(ctx context.Context, b *tg_bot.Bot, data types.WizardData) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+	measureName := data.String(constants.MeasureNameConversationKey)
+	units := data.String(constants.MeasureUnitsConversationKey)
+
+	measureId, err := h.measureRepository.Create(ctx, models.Measure{
 		Name:  measureName,
 		Units: units,
 	})
 
+	if errors.Is(err, repositories.ErrConflict) {
+		h.senderService.Send(ctx, b, chatId, messages.MeasureNameAlreadyExistsMessage(measureName))
+		return
+	}
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
 	msg := messages.MeasureSuccessfullyAddedMessage(measureName, units)
 
-	kb := inline_keyboards.MeasureOk(programId)
+	kb := inline_keyboards.MeasureOk(measureId)
 
-	h.senderService.Delete(ctx, b, chatId, unitsMsgId)
 	h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
 }
 
@@ -137,7 +193,12 @@ func (h *measureHandler) list(ctx context.Context, b *tg_bot.Bot) {
 	limit := utils_context.GetLimitFromContext(ctx)
 	offset := utils_context.GetOffsetFromContext(ctx)
 
-	measures := h.measureRepository.GetAll(ctx, limit, offset)
+	measures, err := h.measureRepository.GetAll(ctx, limit, offset)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	if len(measures) == 0 {
 		msg := messages.MeasuresNotFoundMessage()
@@ -146,7 +207,12 @@ func (h *measureHandler) list(ctx context.Context, b *tg_bot.Bot) {
 		return
 	}
 
-	measuresCount := h.measureRepository.CountAll(ctx)
+	measuresCount, err := h.measureRepository.CountAll(ctx)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	kb := inline_keyboards.MeasureList(measures, measuresCount, limit, offset)
 
@@ -167,7 +233,10 @@ func (h *measureHandler) delete(ctx context.Context, b *tg_bot.Bot) {
 	chatId := utils_context.GetChatIdFromContext(ctx)
 	measure := utils_context.GetMeasureFromContext(ctx)
 
-	h.measureRepository.DeleteById(ctx, measure.Id)
+	if err := h.measureRepository.DeleteById(ctx, measure.Id); err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	msg := messages.MeasureDeletedMessage(measure.Name)
 	kb := inline_keyboards.MeasureDeleteOk()
@@ -177,95 +246,102 @@ func (h *measureHandler) delete(ctx context.Context, b *tg_bot.Bot) {
 
 func (h *measureHandler) rename(ctx context.Context, b *tg_bot.Bot) {
 	chatId := utils_context.GetChatIdFromContext(ctx)
-	measure := utils_context.GetMeasureFromContext(ctx)
+	params := utils_context.GetParamsFromContext(ctx)
 
-	measureNameMsgId := h.senderService.SendSafe(ctx, b, chatId, messages.EnterMeasureNameMessage())
+	h.conversationService.Start(ctx, b, chatId, constants.MeasureRenameState, *params, messages.EnterMeasureNameMessage())
+}
 
-	measureName, err := h.getMeasureName(ctx, b)
+func (h *measureHandler) renameName(ctx context.Context, b *tg_bot.Bot, conversation *models.Conversation, measureName string) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+	measure := utils_context.GetMeasureFromContext(ctx)
 
-	if err != nil {
-		h.senderService.Delete(context.Background(), b, chatId, measureNameMsgId)
+	if !h.isValidMeasureName(ctx, b, measureName) {
 		return
 	}
 
-	h.measureRepository.UpdateById(ctx, measure.Id, models.Measure{
+	err := h.measureRepository.UpdateById(ctx, measure.Id, models.Measure{
 		Name: measureName,
 	})
 
+	if errors.Is(err, repositories.ErrConflict) {
+		h.senderService.Send(ctx, b, chatId, messages.MeasureNameAlreadyExistsMessage(measureName))
+		return
+	}
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
 	msg := messages.MeasureRenamed(measure.Name, measureName)
 	kb := inline_keyboards.MeasureOk(measure.Id)
 
-	h.senderService.Delete(ctx, b, chatId, measureNameMsgId)
+	h.conversationService.Finish(ctx, b, conversation)
 	h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
 }
 
 func (h *measureHandler) changeUnits(ctx context.Context, b *tg_bot.Bot) {
 	chatId := utils_context.GetChatIdFromContext(ctx)
+	params := utils_context.GetParamsFromContext(ctx)
 	measure := utils_context.GetMeasureFromContext(ctx)
 
-	unitsMsgId := h.senderService.SendSafe(ctx, b, chatId, messages.EnterMeasureUnitsMessage(measure.Name))
+	h.conversationService.Start(ctx, b, chatId, constants.MeasureChangeUnitsState, *params, messages.EnterMeasureUnitsMessage(measure.Name))
+}
 
-	units, err := h.getMeasureUnits(ctx, b)
+func (h *measureHandler) changeUnitsAnswer(ctx context.Context, b *tg_bot.Bot, conversation *models.Conversation, units string) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+	measure := utils_context.GetMeasureFromContext(ctx)
 
-	if err != nil {
-		h.senderService.Delete(context.Background(), b, chatId, unitsMsgId)
+	if !h.isValidMeasureUnits(ctx, b, units) {
 		return
 	}
 
-	h.measureRepository.UpdateById(ctx, measure.Id, models.Measure{
+	err := h.measureRepository.UpdateById(ctx, measure.Id, models.Measure{
 		Units: units,
 	})
 
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
 	msg := messages.MeasureUnitsChanged(measure.Name, measure.Units, units)
 	kb := inline_keyboards.MeasureOk(measure.Id)
 
-	h.senderService.Delete(ctx, b, chatId, unitsMsgId)
+	h.conversationService.Finish(ctx, b, conversation)
 	h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
 }
 
-func (h *measureHandler) getMeasureName(ctx context.Context, b *tg_bot.Bot) (string, error) {
+func (h *measureHandler) isValidMeasureName(ctx context.Context, b *tg_bot.Bot, measureName string) bool {
 	chatId := utils_context.GetChatIdFromContext(ctx)
 
-	conversation := h.conversationService.CreateConversation(chatId)
-	defer h.conversationService.DeleteConversation(chatId)
-
-	measureName := conversation.WaitAnswer()
-
-	if ctx.Err() != nil {
-		return "", errors.New("context canceled")
-	}
-
 	if strings.TrimSpace(measureName) == "" {
 		h.senderService.Send(ctx, b, chatId, messages.EmptyMessage())
-		return h.getMeasureName(ctx, b)
+		return false
 	}
 
-	existingMeasure := h.measureRepository.GetByName(ctx, measureName)
+	_, err := h.measureRepository.GetByName(ctx, measureName)
 
-	if existingMeasure != nil {
+	if err == nil {
 		h.senderService.Send(ctx, b, chatId, messages.MeasureNameAlreadyExistsMessage(measureName))
-		return h.getMeasureName(ctx, b)
+		return false
 	}
 
-	return measureName, nil
+	if !errors.Is(err, repositories.ErrNotFound) {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return false
+	}
+
+	return true
 }
 
-func (h *measureHandler) getMeasureUnits(ctx context.Context, b *tg_bot.Bot) (string, error) {
+func (h *measureHandler) isValidMeasureUnits(ctx context.Context, b *tg_bot.Bot, units string) bool {
 	chatId := utils_context.GetChatIdFromContext(ctx)
 
-	conversation := h.conversationService.CreateConversation(chatId)
-	defer h.conversationService.DeleteConversation(chatId)
-
-	units := conversation.WaitAnswer()
-
-	if ctx.Err() != nil {
-		return "", errors.New("context canceled")
-	}
-
 	if strings.TrimSpace(units) == "" {
 		h.senderService.Send(ctx, b, chatId, messages.EmptyMessage())
-		return h.getMeasureName(ctx, b)
+		return false
 	}
 
-	return units, nil
+	return true
 }
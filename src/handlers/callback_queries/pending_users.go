@@ -66,7 +66,7 @@ func (h *pendingUsersHandler) Handle(ctx context.Context, b *tg_bot.Bot, update
 		return
 	}
 
-	h.logger.Warn(fmt.Sprintf("Unknown pending users callback query data: %s", callbackQueryData))
+	h.logger.WithContext(ctx).Warn(fmt.Sprintf("Unknown pending users callback query data: %s", callbackQueryData))
 }
 
 func (h *pendingUsersHandler) list(ctx context.Context, b *tg_bot.Bot) {
@@ -74,7 +74,12 @@ func (h *pendingUsersHandler) list(ctx context.Context, b *tg_bot.Bot) {
 	limit := utils_context.GetLimitFromContext(ctx)
 	offset := utils_context.GetOffsetFromContext(ctx)
 
-	users := h.userRepository.GetPendingUsers(ctx, limit, offset)
+	users, err := h.userRepository.GetPendingUsers(ctx, limit, offset)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	if len(users) == 0 {
 		msg := messages.NoPendingUsersMessage()
@@ -83,7 +88,12 @@ func (h *pendingUsersHandler) list(ctx context.Context, b *tg_bot.Bot) {
 		return
 	}
 
-	usersCount := h.userRepository.CountPendingUsers(ctx)
+	usersCount, err := h.userRepository.CountPendingUsers(ctx)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	kb := inline_keyboards.PendingUsersList(users, usersCount, limit, offset)
 
@@ -104,11 +114,16 @@ func (h *pendingUsersHandler) approve(ctx context.Context, b *tg_bot.Bot) {
 	chatId := utils_context.GetChatIdFromContext(ctx)
 	user := utils_context.GetUserFromContext(ctx)
 
-	h.userRepository.UpdateById(ctx, user.Id, models.User{
+	err := h.userRepository.UpdateById(ctx, user.Id, models.User{
 		IsApproved: true,
 		IsDeclined: false,
 	})
 
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
 	h.senderService.Send(ctx, b, user.ChatId, messages.UserApprovedMessage(user.GetPublicName()))
 
 	adminMsg := messages.UserApprovedForAdminMessage(user.GetPrivateName())
@@ -120,11 +135,16 @@ func (h *pendingUsersHandler) decline(ctx context.Context, b *tg_bot.Bot) {
 	chatId := utils_context.GetChatIdFromContext(ctx)
 	user := utils_context.GetUserFromContext(ctx)
 
-	h.userRepository.UpdateById(ctx, user.Id, models.User{
+	err := h.userRepository.UpdateById(ctx, user.Id, models.User{
 		IsDeclined: true,
 		IsApproved: false,
 	})
 
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
 	h.senderService.Send(ctx, b, user.ChatId, messages.UserDeclinedMessage(user.GetPublicName()))
 
 	adminMsg := messages.UserDeclinedForAdminMessage(user.GetPrivateName())
@@ -12,9 +12,12 @@ import (
 	"rezvin-pro-bot/src/models"
 	"rezvin-pro-bot/src/repositories"
 	"rezvin-pro-bot/src/services"
+	"rezvin-pro-bot/src/types"
 	"rezvin-pro-bot/src/utils/context"
 	"rezvin-pro-bot/src/utils/inline_keyboards"
 	"rezvin-pro-bot/src/utils/messages"
+	"rezvin-pro-bot/src/utils/rep_scheme"
+	"rezvin-pro-bot/src/utils/validate"
 	"strings"
 )
 
@@ -27,30 +30,88 @@ type programHandlerDependencies struct {
 
 	Logger              logger.ILogger                `name:"Logger"`
 	ConversationService services.IConversationService `name:"ConversationService"`
+	WizardService       services.IWizardService       `name:"WizardService"`
 	SenderService       services.ISenderService       `name:"SenderService"`
 
 	ProgramRepository repositories.IProgramRepository `name:"ProgramRepository"`
+	UserResultService services.IUserResultService     `name:"UserResultService"`
 }
 
 type programHandler struct {
 	logger              logger.ILogger
 	conversationService services.IConversationService
+	wizardService       services.IWizardService
 	senderService       services.ISenderService
 	programRepository   repositories.IProgramRepository
+	userResultService   services.IUserResultService
 }
 
 func NewProgramHandler(deps programHandlerDependencies) *programHandler {
-	return &programHandler{
+	h := &programHandler{
 		logger:              deps.Logger,
 		senderService:       deps.SenderService,
 		conversationService: deps.ConversationService,
+		wizardService:       deps.WizardService,
 		programRepository:   deps.ProgramRepository,
+		userResultService:   deps.UserResultService,
 	}
+
+	h.wizardService.Register(types.Wizard{
+		State: constants.ProgramAddNameState,
+		Steps: []types.WizardStep{
+			{
+				Key: constants.ProgramNameConversationKey,
+				Prompt: func(_ context.Context, _ types.WizardData) string {
+					return messages.EnterProgramNameMessage()
+				},
+				Validate: h.validateProgramName,
+			},
+		},
+		Summary: func(_ context.Context, data types.WizardData) string {
+			return messages.ProgramAddSummaryMessage(data.String(constants.ProgramNameConversationKey))
+		},
+		Complete: h.addComplete,
+	})
+
+	h.conversationService.RegisterStep(constants.ProgramRenameState, h.renameName)
+	h.conversationService.RegisterStep(constants.ProgramRepSchemeState, h.repSchemeAnswer)
+
+	return h
 }
 
 func (h *programHandler) Handle(ctx context.Context, b *tg_bot.Bot, update *tg_models.Update) {
 	callbackDataQuery := update.CallbackQuery.Data
 
+	if strings.HasPrefix(callbackDataQuery, constants.ProgramFormulaEpley) {
+		h.setFormula(ctx, b, constants.EpleyFormula)
+		return
+	}
+
+	if strings.HasPrefix(callbackDataQuery, constants.ProgramFormulaBrzycki) {
+		h.setFormula(ctx, b, constants.BrzyckiFormula)
+		return
+	}
+
+	if strings.HasPrefix(callbackDataQuery, constants.ProgramFormulaLombardi) {
+		h.setFormula(ctx, b, constants.LombardiFormula)
+		return
+	}
+
+	if strings.HasPrefix(callbackDataQuery, constants.ProgramFormulaBest) {
+		h.setFormula(ctx, b, constants.BestFormula)
+		return
+	}
+
+	if strings.HasPrefix(callbackDataQuery, constants.ProgramFormula) {
+		h.formula(ctx, b)
+		return
+	}
+
+	if strings.HasPrefix(callbackDataQuery, constants.ProgramRepScheme) {
+		h.repScheme(ctx, b)
+		return
+	}
+
 	if strings.HasPrefix(callbackDataQuery, constants.ProgramSelected) {
 		h.selected(ctx, b)
 		return
@@ -81,7 +142,7 @@ func (h *programHandler) Handle(ctx context.Context, b *tg_bot.Bot, update *tg_m
 		return
 	}
 
-	h.logger.Warn(fmt.Sprintf("Unknown program callback query data: %s", callbackDataQuery))
+	h.logger.WithContext(ctx).Warn(fmt.Sprintf("Unknown program callback query data: %s", callbackDataQuery))
 }
 
 func (h *programHandler) menu(ctx context.Context, b *tg_bot.Bot) {
@@ -92,28 +153,37 @@ func (h *programHandler) menu(ctx context.Context, b *tg_bot.Bot) {
 	h.senderService.SendWithKb(ctx, b, chatId, msg, inline_keyboards.ProgramMenu())
 }
 
-func (h *programHandler) getProgramName(ctx context.Context, b *tg_bot.Bot) (string, error) {
+func (h *programHandler) isValidProgramName(ctx context.Context, b *tg_bot.Bot, programName string) bool {
 	chatId := utils_context.GetChatIdFromContext(ctx)
 
-	conversation := h.conversationService.CreateConversation(chatId)
-	defer h.conversationService.DeleteConversation(chatId)
+	_, err := h.validateProgramName(ctx, programName)
 
-	programName := conversation.WaitAnswer()
+	if repositories.IsRepositoryError(err) {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return false
+	}
 
-	if ctx.Err() != nil {
-		return "", errors.New("context canceled")
+	if err != nil {
+		h.senderService.Send(ctx, b, chatId, err.Error())
+		return false
 	}
 
+	return true
+}
+
+func (h *programHandler) validateProgramName(ctx context.Context, programName string) (string, error) {
 	if strings.TrimSpace(programName) == "" {
-		h.senderService.Send(ctx, b, chatId, messages.EmptyMessage())
-		return h.getProgramName(ctx, b)
+		return "", errors.New(messages.EmptyMessage())
 	}
 
-	existingProgram := h.programRepository.GetByName(ctx, programName)
+	_, err := h.programRepository.GetByName(ctx, programName)
 
-	if existingProgram != nil {
-		h.senderService.Send(ctx, b, chatId, messages.ProgramNameAlreadyExistsMessage(programName))
-		return h.getProgramName(ctx, b)
+	if err == nil {
+		return "", errors.New(messages.ProgramNameAlreadyExistsMessage(programName))
+	}
+
+	if !errors.Is(err, repositories.ErrNotFound) {
+		return "", err
 	}
 
 	return programName, nil
@@ -121,25 +191,33 @@ func (h *programHandler) getProgramName(ctx context.Context, b *tg_bot.Bot) (str
 
 func (h *programHandler) add(ctx context.Context, b *tg_bot.Bot) {
 	chatId := utils_context.GetChatIdFromContext(ctx)
+	params := utils_context.GetParamsFromContext(ctx)
 
-	programMsgId := h.senderService.SendSafe(ctx, b, chatId, messages.EnterProgramNameMessage())
+	h.wizardService.Start(ctx, b, chatId, constants.ProgramAddNameState, *params)
+}
 
-	programName, err := h.getProgramName(ctx, b)
+func (h *programHandler) addComplete(ctx context.Context, b *tg_bot.Bot, data types.WizardData) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+	programName := data.String(constants.ProgramNameConversationKey)
 
-	if err != nil {
-		h.senderService.Delete(context.Background(), b, chatId, programMsgId)
+	programId, err := h.programRepository.Create(ctx, models.Program{
+		Name: programName,
+	})
+
+	if errors.Is(err, repositories.ErrConflict) {
+		h.senderService.Send(ctx, b, chatId, messages.ProgramNameAlreadyExistsMessage(programName))
 		return
 	}
 
-	programId := h.programRepository.Create(ctx, models.Program{
-		Name: programName,
-	})
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	msg := messages.ProgramSuccessfullyAddedMessage(programName)
 
 	kb := inline_keyboards.ProgramOk(programId)
 
-	h.senderService.Delete(ctx, b, chatId, programMsgId)
 	h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
 }
 
@@ -148,7 +226,12 @@ func (h *programHandler) list(ctx context.Context, b *tg_bot.Bot) {
 	limit := utils_context.GetLimitFromContext(ctx)
 	offset := utils_context.GetOffsetFromContext(ctx)
 
-	programs := h.programRepository.GetAll(ctx, limit, offset)
+	programs, err := h.programRepository.GetAll(ctx, limit, offset)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	if len(programs) == 0 {
 		msg := messages.NoProgramsMessage()
@@ -157,7 +240,12 @@ func (h *programHandler) list(ctx context.Context, b *tg_bot.Bot) {
 		return
 	}
 
-	programsCount := h.programRepository.CountAll(ctx)
+	programsCount, err := h.programRepository.CountAll(ctx)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	kb := inline_keyboards.ProgramList(programs, programsCount, limit, offset)
 
@@ -176,26 +264,38 @@ func (h *programHandler) selected(ctx context.Context, b *tg_bot.Bot) {
 
 func (h *programHandler) rename(ctx context.Context, b *tg_bot.Bot) {
 	chatId := utils_context.GetChatIdFromContext(ctx)
-	program := utils_context.GetProgramFromContext(ctx)
+	params := utils_context.GetParamsFromContext(ctx)
 
-	programMsgId := h.senderService.SendSafe(ctx, b, chatId, messages.EnterProgramNameMessage())
+	h.conversationService.Start(ctx, b, chatId, constants.ProgramRenameState, *params, messages.EnterProgramNameMessage())
+}
 
-	programName, err := h.getProgramName(ctx, b)
+func (h *programHandler) renameName(ctx context.Context, b *tg_bot.Bot, conversation *models.Conversation, programName string) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+	program := utils_context.GetProgramFromContext(ctx)
 
-	if err != nil {
-		h.senderService.Delete(context.Background(), b, chatId, programMsgId)
+	if !h.isValidProgramName(ctx, b, programName) {
 		return
 	}
 
-	h.programRepository.UpdateById(ctx, program.Id, models.Program{
+	err := h.programRepository.UpdateById(ctx, program.Id, models.Program{
 		Name: programName,
 	})
 
+	if errors.Is(err, repositories.ErrConflict) {
+		h.senderService.Send(ctx, b, chatId, messages.ProgramNameAlreadyExistsMessage(programName))
+		return
+	}
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
 	msg := messages.ProgramSuccessfullyRenamedMessage(program.Name, programName)
 
 	kb := inline_keyboards.ProgramOk(program.Id)
 
-	h.senderService.Delete(ctx, b, chatId, programMsgId)
+	h.conversationService.Finish(ctx, b, conversation)
 	h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
 }
 
@@ -203,10 +303,86 @@ func (h *programHandler) delete(ctx context.Context, b *tg_bot.Bot) {
 	chatId := utils_context.GetChatIdFromContext(ctx)
 	program := utils_context.GetProgramFromContext(ctx)
 
-	h.programRepository.DeleteById(ctx, program.Id)
+	if err := h.programRepository.DeleteById(ctx, program.Id); err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	msg := messages.ProgramSuccessfullyDeletedMessage(program.Name)
 	kb := inline_keyboards.ProgramDeleteOk()
 
 	h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
 }
+
+func (h *programHandler) formula(ctx context.Context, b *tg_bot.Bot) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+	program := utils_context.GetProgramFromContext(ctx)
+
+	msg := messages.SelectProgramFormulaMessage(program.Name, program.GetOneRepMaxFormula())
+	kb := inline_keyboards.ProgramFormulaMenu(*program)
+
+	h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+}
+
+func (h *programHandler) setFormula(ctx context.Context, b *tg_bot.Bot, formula constants.OneRepMaxFormula) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+	program := utils_context.GetProgramFromContext(ctx)
+
+	err := h.programRepository.UpdateById(ctx, program.Id, models.Program{
+		OneRepMaxFormula: formula,
+	})
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	msg := messages.ProgramFormulaChangedMessage(program.Name, formula)
+	kb := inline_keyboards.ProgramOk(program.Id)
+
+	h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+}
+
+func (h *programHandler) repScheme(ctx context.Context, b *tg_bot.Bot) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+	params := utils_context.GetParamsFromContext(ctx)
+	program := utils_context.GetProgramFromContext(ctx)
+
+	currentRepScheme := rep_scheme.Format(rep_scheme.ForProgram(*program))
+
+	h.conversationService.Start(ctx, b, chatId, constants.ProgramRepSchemeState, *params, messages.EnterProgramRepSchemeMessage(program.Name, currentRepScheme))
+}
+
+func (h *programHandler) repSchemeAnswer(ctx context.Context, b *tg_bot.Bot, conversation *models.Conversation, answer string) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+	program := utils_context.GetProgramFromContext(ctx)
+
+	repScheme, err := validate_data.ValidateRepSchemeAnswer(answer)
+
+	if err != nil {
+		h.senderService.Send(ctx, b, chatId, err.Error())
+		return
+	}
+
+	err = h.programRepository.UpdateById(ctx, program.Id, models.Program{
+		RepScheme: repScheme,
+	})
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	program.RepScheme = repScheme
+
+	if err := h.userResultService.SyncProgramResults(ctx, *program, program.Exercises); err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	msg := messages.ProgramRepSchemeChangedMessage(program.Name, repScheme)
+	kb := inline_keyboards.ProgramOk(program.Id)
+
+	h.conversationService.Finish(ctx, b, conversation)
+	h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+}
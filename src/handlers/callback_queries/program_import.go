@@ -0,0 +1,138 @@
+package callback_queries
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	tg_bot "github.com/go-telegram/bot"
+	tg_models "github.com/go-telegram/bot/models"
+	"go.uber.org/dig"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/internal/logger"
+	"rezvin-pro-bot/src/services"
+	bot_utils "rezvin-pro-bot/src/utils/bot"
+	utils_context "rezvin-pro-bot/src/utils/context"
+	"rezvin-pro-bot/src/utils/importer"
+	"rezvin-pro-bot/src/utils/inline_keyboards"
+	"rezvin-pro-bot/src/utils/messages"
+	"strings"
+)
+
+type IProgramImportHandler interface {
+	Handle(ctx context.Context, b *tg_bot.Bot, update *tg_models.Update)
+	HandleDocument(ctx context.Context, b *tg_bot.Bot, update *tg_models.Update)
+}
+
+type programImportHandlerDependencies struct {
+	dig.In
+
+	Logger               logger.ILogger                 `name:"Logger"`
+	SenderService        services.ISenderService        `name:"SenderService"`
+	ProgramImportService services.IProgramImportService `name:"ProgramImportService"`
+}
+
+type programImportHandler struct {
+	logger               logger.ILogger
+	senderService        services.ISenderService
+	programImportService services.IProgramImportService
+}
+
+func NewProgramImportHandler(deps programImportHandlerDependencies) *programImportHandler {
+	return &programImportHandler{
+		logger:               deps.Logger,
+		senderService:        deps.SenderService,
+		programImportService: deps.ProgramImportService,
+	}
+}
+
+func (h *programImportHandler) Handle(ctx context.Context, b *tg_bot.Bot, update *tg_models.Update) {
+	callBackQueryData := update.CallbackQuery.Data
+
+	if strings.HasPrefix(callBackQueryData, constants.ImportStart) {
+		h.start(ctx, b)
+		return
+	}
+
+	if strings.HasPrefix(callBackQueryData, constants.ImportConfirm) {
+		h.confirm(ctx, b)
+		return
+	}
+
+	if strings.HasPrefix(callBackQueryData, constants.ImportCancel) {
+		h.cancel(ctx, b)
+		return
+	}
+
+	h.logger.WithContext(ctx).Warn(fmt.Sprintf("Unknown import callback query data: %s", callBackQueryData))
+}
+
+func (h *programImportHandler) HandleDocument(ctx context.Context, b *tg_bot.Bot, update *tg_models.Update) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+	document := update.Message.Document
+
+	if document.FileSize > constants.MaxImportFileSize {
+		h.senderService.SendWithKb(ctx, b, chatId, messages.ImportFileTooLargeMessage(), inline_keyboards.ImportOk())
+		return
+	}
+
+	data, err := bot_utils.DownloadFile(ctx, b, document.FileID)
+
+	if err != nil {
+		h.logger.WithContext(ctx).Error(fmt.Sprintf("Failed to download import file %s: %v", document.FileName, err))
+		h.senderService.SendWithKb(ctx, b, chatId, messages.ErrorMessage(), inline_keyboards.ImportOk())
+		return
+	}
+
+	plan, confirmCallbackData, err := h.programImportService.Prepare(ctx, chatId, document.FileName, data)
+
+	var documentErr *importer.DocumentError
+
+	if errors.As(err, &documentErr) {
+		h.senderService.SendWithKb(ctx, b, chatId, messages.ImportErrorMessage(documentErr), inline_keyboards.ImportOk())
+		return
+	}
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	if plan.IsEmpty() {
+		h.senderService.SendWithKb(ctx, b, chatId, messages.ImportNothingToChangeMessage(), inline_keyboards.MainOk())
+		return
+	}
+
+	h.senderService.SendWithKb(ctx, b, chatId, messages.ImportPreviewMessage(*plan), inline_keyboards.ImportConfirm(confirmCallbackData))
+}
+
+func (h *programImportHandler) start(ctx context.Context, b *tg_bot.Bot) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+
+	h.senderService.SendWithKb(ctx, b, chatId, messages.ImportInstructionsMessage(), inline_keyboards.ImportStart())
+}
+
+func (h *programImportHandler) confirm(ctx context.Context, b *tg_bot.Bot) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+
+	data := utils_context.GetCallbackStateDataFromContext(ctx)
+
+	plan, err := h.programImportService.Apply(ctx, data[constants.ImportPlanDataKey])
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	if plan == nil {
+		h.senderService.SendWithKb(ctx, b, chatId, messages.ImportNotFoundMessage(), inline_keyboards.ImportOk())
+		return
+	}
+
+	h.senderService.SendWithKb(ctx, b, chatId, messages.ImportAppliedMessage(*plan), inline_keyboards.MainOk())
+}
+
+func (h *programImportHandler) cancel(ctx context.Context, b *tg_bot.Bot) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+
+	h.senderService.SendWithKb(ctx, b, chatId, messages.ImportCancelledMessage(), inline_keyboards.MainOk())
+}
@@ -0,0 +1,114 @@
+package callback_queries_test
+
+import (
+	"context"
+	"go.uber.org/dig"
+	"rezvin-pro-bot/src/internal/test_harness"
+	"rezvin-pro-bot/src/models"
+	"rezvin-pro-bot/src/repositories"
+	"testing"
+)
+
+type programTestDependencies struct {
+	dig.In
+
+	ProgramRepository repositories.IProgramRepository `name:"ProgramRepository"`
+	UserRepository    repositories.IUserRepository    `name:"UserRepository"`
+}
+
+func TestAdminCreatesProgram(t *testing.T) {
+	h := test_harness.NewMemory(t)
+
+	admin := test_harness.NewUser(1001, "Тренер", "")
+
+	h.CreateAdmin(admin)
+
+	h.Send(admin, "/start")
+	h.Click(admin, h.ExpectButton(admin, "Програми"), "Програми")
+	h.Click(admin, h.ExpectButton(admin, "Створити програму"), "Створити програму")
+	h.Expect(admin, "Введи назву програми")
+	h.Send(admin, "Сіла")
+	h.Expect(admin, "Перевір нову програму")
+	h.Click(admin, h.ExpectButton(admin, "Назад"), "Назад")
+	h.Expect(admin, "Поточне значення")
+	h.Send(admin, "Сила")
+	h.Expect(admin, "Перевір нову програму")
+	h.Click(admin, h.ExpectButton(admin, "Підтвердити"), "Підтвердити")
+	h.Expect(admin, "успішно додана")
+
+	h.Send(admin, "/start")
+	h.Click(admin, h.ExpectButton(admin, "Програми"), "Програми")
+	h.Click(admin, h.ExpectButton(admin, "Створити програму"), "Створити програму")
+	h.Expect(admin, "Введи назву програми")
+	h.Send(admin, "Сила")
+	h.Expect(admin, "вже існує")
+	h.Send(admin, "/cancel")
+	h.Expect(admin, "Дію скасовано")
+
+	h.Send(admin, "/start")
+	h.Click(admin, h.ExpectButton(admin, "Програми"), "Програми")
+	h.Click(admin, h.ExpectButton(admin, "Створити програму"), "Створити програму")
+	h.Click(admin, h.ExpectButton(admin, "Скасувати"), "Скасувати")
+	h.Expect(admin, "Дію скасовано")
+	h.Send(admin, "/cancel")
+	h.Expect(admin, "Немає активної дії")
+
+	h.Invoke(func(deps programTestDependencies) {
+		programs, err := deps.ProgramRepository.GetAll(context.Background(), 10, 0)
+
+		if err != nil {
+			t.Fatalf("failed to load programs: %s", err)
+		}
+
+		if len(programs) != 1 || programs[0].Name != "Сила" {
+			t.Fatalf("expected a single program named Сила, got %+v", programs)
+		}
+	})
+}
+
+func TestDemotedAdminCannotResumeProgramCreation(t *testing.T) {
+	h := test_harness.NewMemory(t)
+
+	admin := test_harness.NewUser(1002, "Тренер", "")
+
+	h.CreateAdmin(admin)
+
+	h.Send(admin, "/start")
+	h.Click(admin, h.ExpectButton(admin, "Програми"), "Програми")
+	h.Click(admin, h.ExpectButton(admin, "Створити програму"), "Створити програму")
+	h.Expect(admin, "Введи назву програми")
+
+	h.Invoke(func(deps programTestDependencies) {
+		ctx := context.Background()
+
+		if err := deps.UserRepository.DeleteById(ctx, admin.ID); err != nil {
+			t.Fatalf("failed to delete admin: %s", err)
+		}
+
+		_, err := deps.UserRepository.Create(ctx, models.User{
+			Id:         admin.ID,
+			ChatId:     admin.ID,
+			FirstName:  admin.FirstName,
+			IsApproved: true,
+		})
+
+		if err != nil {
+			t.Fatalf("failed to recreate the user without admin rights: %s", err)
+		}
+	})
+
+	h.Send(admin, "Сила")
+	h.Expect(admin, "тільки адміністраторам")
+
+	h.Invoke(func(deps programTestDependencies) {
+		count, err := deps.ProgramRepository.CountAll(context.Background())
+
+		if err != nil {
+			t.Fatalf("failed to count programs: %s", err)
+		}
+
+		if count != 0 {
+			t.Fatalf("expected no programs, got %d", count)
+		}
+	})
+}
@@ -2,6 +2,7 @@ package callback_queries
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	tg_bot "github.com/go-telegram/bot"
 	tg_models "github.com/go-telegram/bot/models"
@@ -53,7 +54,7 @@ func (h *registerHandler) Handle(ctx context.Context, b *tg_bot.Bot, update *tg_
 		return
 	}
 
-	h.logger.Warn(fmt.Sprintf("Unknown register callback query data: %s", callbackQueryData))
+	h.logger.WithContext(ctx).Warn(fmt.Sprintf("Unknown register callback query data: %s", callbackQueryData))
 }
 
 func (h *registerHandler) registerUser(ctx context.Context, b *tg_bot.Bot, update *tg_models.Update) {
@@ -63,9 +64,14 @@ func (h *registerHandler) registerUser(ctx context.Context, b *tg_bot.Bot, updat
 	firstName := bot_utils.GetFirstName(update)
 	lastName := bot_utils.GetLastName(update)
 
-	user := h.userRepository.GetById(ctx, userId)
+	user, err := h.userRepository.GetById(ctx, userId)
 
-	if user != nil {
+	if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	if err == nil {
 		if user.IsApproved {
 			h.senderService.Send(ctx, b, chatId, messages.AlreadyApprovedRegister())
 		} else {
@@ -74,7 +80,7 @@ func (h *registerHandler) registerUser(ctx context.Context, b *tg_bot.Bot, updat
 		return
 	}
 
-	h.userRepository.Create(ctx, models.User{
+	_, err = h.userRepository.Create(ctx, models.User{
 		Id:         userId,
 		ChatId:     chatId,
 		Username:   bot_utils.GetUsername(update),
@@ -85,15 +91,31 @@ func (h *registerHandler) registerUser(ctx context.Context, b *tg_bot.Bot, updat
 		IsDeclined: false,
 	})
 
+	if errors.Is(err, repositories.ErrConflict) {
+		h.senderService.Send(ctx, b, chatId, messages.AlreadyRegistered())
+		return
+	}
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
 	h.senderService.Send(ctx, b, chatId, messages.SuccessRegister())
 
-	admins := h.userRepository.GetAdminUsers(ctx)
+	admins, err := h.userRepository.GetAdminUsers(ctx)
+
+	// The user is already registered, only the trainers miss the notification.
+	if err != nil {
+		h.logger.WithContext(ctx).WithError(err).Error(fmt.Sprintf("Failed to notify admins about registration of user %d", userId))
+		return
+	}
 
 	name := fmt.Sprintf("%s %s", firstName, lastName)
 
 	msg := messages.NewRegister(name)
 	kb := inline_keyboards.MainOk()
 	for _, admin := range admins {
-		h.senderService.SendWithKb(ctx, b, admin.ChatId, msg, kb)
+		h.senderService.NotifyWithKb(ctx, b, admin.ChatId, msg, kb)
 	}
 }
@@ -0,0 +1,95 @@
+package callback_queries
+
+import (
+	"context"
+	"fmt"
+	tg_bot "github.com/go-telegram/bot"
+	tg_models "github.com/go-telegram/bot/models"
+	"go.uber.org/dig"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/internal/logger"
+	"rezvin-pro-bot/src/models"
+	"rezvin-pro-bot/src/repositories"
+	"rezvin-pro-bot/src/services"
+	"rezvin-pro-bot/src/utils/context"
+	"rezvin-pro-bot/src/utils/inline_keyboards"
+	"rezvin-pro-bot/src/utils/messages"
+	"strings"
+)
+
+type ISettingsHandler interface {
+	Handle(ctx context.Context, b *tg_bot.Bot, update *tg_models.Update)
+}
+
+type settingsHandlerDependencies struct {
+	dig.In
+
+	Logger        logger.ILogger          `name:"Logger"`
+	SenderService services.ISenderService `name:"SenderService"`
+
+	UserRepository repositories.IUserRepository `name:"UserRepository"`
+}
+
+type settingsHandler struct {
+	logger         logger.ILogger
+	senderService  services.ISenderService
+	userRepository repositories.IUserRepository
+}
+
+func NewSettingsHandler(deps settingsHandlerDependencies) *settingsHandler {
+	return &settingsHandler{
+		logger:         deps.Logger,
+		senderService:  deps.SenderService,
+		userRepository: deps.UserRepository,
+	}
+}
+
+func (h *settingsHandler) Handle(ctx context.Context, b *tg_bot.Bot, update *tg_models.Update) {
+	callBackQueryData := update.CallbackQuery.Data
+
+	if strings.HasPrefix(callBackQueryData, constants.SettingsMenu) {
+		h.menu(ctx, b)
+		return
+	}
+
+	if strings.HasPrefix(callBackQueryData, constants.SettingsWeightUnitKg) {
+		h.setWeightUnit(ctx, b, constants.KilogramUnit)
+		return
+	}
+
+	if strings.HasPrefix(callBackQueryData, constants.SettingsWeightUnitLb) {
+		h.setWeightUnit(ctx, b, constants.PoundUnit)
+		return
+	}
+
+	h.logger.WithContext(ctx).Warn(fmt.Sprintf("Unknown settings callback query data: %s", callBackQueryData))
+}
+
+func (h *settingsHandler) menu(ctx context.Context, b *tg_bot.Bot) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+	user := utils_context.GetCurrentUserFromContext(ctx)
+
+	msg := messages.SettingsMenuMessage(user.GetWeightUnit())
+	kb := inline_keyboards.SettingsMenu(user.GetWeightUnit())
+
+	h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+}
+
+func (h *settingsHandler) setWeightUnit(ctx context.Context, b *tg_bot.Bot, unit constants.WeightUnit) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+	user := utils_context.GetCurrentUserFromContext(ctx)
+
+	err := h.userRepository.UpdateById(ctx, user.Id, models.User{
+		WeightUnit: unit,
+	})
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	msg := messages.WeightUnitChangedMessage(unit)
+	kb := inline_keyboards.SettingsOk()
+
+	h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+}
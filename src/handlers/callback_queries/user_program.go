@@ -55,7 +55,7 @@ func (h *userProgramHandler) Handle(ctx context.Context, b *tg_bot.Bot, update *
 		return
 	}
 
-	h.logger.Warn(fmt.Sprintf("Unknown user program callback query: %s", callBackQueryData))
+	h.logger.WithContext(ctx).Warn(fmt.Sprintf("Unknown user program callback query: %s", callBackQueryData))
 }
 
 func (h *userProgramHandler) list(ctx context.Context, b *tg_bot.Bot) {
@@ -64,7 +64,12 @@ func (h *userProgramHandler) list(ctx context.Context, b *tg_bot.Bot) {
 	limit := utils_context.GetLimitFromContext(ctx)
 	offset := utils_context.GetOffsetFromContext(ctx)
 
-	programs := h.userProgramRepository.GetByUserId(ctx, user.Id, limit, offset)
+	programs, err := h.userProgramRepository.GetByUserId(ctx, user.Id, limit, offset)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	if len(programs) == 0 {
 		msg := messages.NoUserProgramsMessage()
@@ -73,7 +78,12 @@ func (h *userProgramHandler) list(ctx context.Context, b *tg_bot.Bot) {
 		return
 	}
 
-	programsCount := h.userProgramRepository.CountAllByUserId(ctx, user.Id)
+	programsCount, err := h.userProgramRepository.CountAllByUserId(ctx, user.Id)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	msg := messages.SelectUserProgramMessage()
 
@@ -88,7 +98,7 @@ func (h *userProgramHandler) selected(ctx context.Context, b *tg_bot.Bot) {
 	userProgram := utils_context.GetUserProgramFromContext(ctx)
 
 	if userProgram.UserId != user.Id {
-		h.logger.Error(fmt.Sprintf("Program %d is not assigned for user %d", userProgram.Id, user.Id))
+		h.logger.WithContext(ctx).Error(fmt.Sprintf("Program %d is not assigned for user %d", userProgram.Id, user.Id))
 		msg := messages.UserProgramNotAssignedMessage(userProgram.Name())
 		kb := inline_keyboards.UserProgramListOk()
 		h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
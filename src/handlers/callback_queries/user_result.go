@@ -2,20 +2,20 @@ package callback_queries
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	tg_bot "github.com/go-telegram/bot"
 	tg_models "github.com/go-telegram/bot/models"
 	"go.uber.org/dig"
 	"rezvin-pro-bot/src/constants"
 	"rezvin-pro-bot/src/internal/logger"
-	"rezvin-pro-bot/src/models"
 	"rezvin-pro-bot/src/repositories"
 	"rezvin-pro-bot/src/services"
+	"rezvin-pro-bot/src/types"
+	"rezvin-pro-bot/src/utils/chart"
 	utils_context "rezvin-pro-bot/src/utils/context"
 	"rezvin-pro-bot/src/utils/inline_keyboards"
 	"rezvin-pro-bot/src/utils/messages"
-	"rezvin-pro-bot/src/utils/validate"
+	"rezvin-pro-bot/src/utils/rep_scheme"
 	"strings"
 )
 
@@ -26,29 +26,55 @@ type IUserResultHandler interface {
 type userResultHandlerDependencies struct {
 	dig.In
 
-	Logger               logger.ILogger                     `name:"Logger"`
-	ConversationService  services.IConversationService      `name:"ConversationService"`
-	SenderService        services.ISenderService            `name:"SenderService"`
-	ExerciseRepository   repositories.IExerciseRepository   `name:"ExerciseRepository"`
-	UserResultRepository repositories.IUserResultRepository `name:"UserResultRepository"`
+	Logger                      logger.ILogger                            `name:"Logger"`
+	WizardService               services.IWizardService                   `name:"WizardService"`
+	SenderService               services.ISenderService                   `name:"SenderService"`
+	ExerciseRepository          repositories.IExerciseRepository          `name:"ExerciseRepository"`
+	UserResultRepository        repositories.IUserResultRepository        `name:"UserResultRepository"`
+	UserResultHistoryRepository repositories.IUserResultHistoryRepository `name:"UserResultHistoryRepository"`
+	UserResultService           services.IUserResultService               `name:"UserResultService"`
 }
 
 type userResultHandler struct {
-	logger               logger.ILogger
-	conversationService  services.IConversationService
-	senderService        services.ISenderService
-	exerciseRepository   repositories.IExerciseRepository
-	userResultRepository repositories.IUserResultRepository
+	logger                      logger.ILogger
+	wizardService               services.IWizardService
+	senderService               services.ISenderService
+	exerciseRepository          repositories.IExerciseRepository
+	userResultRepository        repositories.IUserResultRepository
+	userResultHistoryRepository repositories.IUserResultHistoryRepository
+	userResultService           services.IUserResultService
 }
 
 func NewUserResultHandler(deps userResultHandlerDependencies) *userResultHandler {
-	return &userResultHandler{
-		logger:               deps.Logger,
-		senderService:        deps.SenderService,
-		conversationService:  deps.ConversationService,
-		exerciseRepository:   deps.ExerciseRepository,
-		userResultRepository: deps.UserResultRepository,
+	h := &userResultHandler{
+		logger:                      deps.Logger,
+		senderService:               deps.SenderService,
+		wizardService:               deps.WizardService,
+		exerciseRepository:          deps.ExerciseRepository,
+		userResultRepository:        deps.UserResultRepository,
+		userResultHistoryRepository: deps.UserResultHistoryRepository,
+		userResultService:           deps.UserResultService,
 	}
+
+	h.wizardService.Register(types.Wizard{
+		State: constants.UserResultWeightState,
+		Steps: []types.WizardStep{
+			resultWeightStep(func(ctx context.Context) string {
+				user := utils_context.GetCurrentUserFromContext(ctx)
+				record := utils_context.GetUserResultFromContext(ctx)
+
+				return messages.EnterUserResultMessage(record.Name(), user.GetWeightUnit())
+			}),
+		},
+		Summary: func(ctx context.Context, data types.WizardData) string {
+			record := utils_context.GetUserResultFromContext(ctx)
+
+			return messages.UserResultSummaryMessage(record.Name(), record.Reps, resultWeightText(ctx, data))
+		},
+		Complete: h.exerciseRepsComplete,
+	})
+
+	return h
 }
 
 func (h *userResultHandler) Handle(ctx context.Context, b *tg_bot.Bot, update *tg_models.Update) {
@@ -74,7 +100,22 @@ func (h *userResultHandler) Handle(ctx context.Context, b *tg_bot.Bot, update *t
 		return
 	}
 
-	h.logger.Warn(fmt.Sprintf("Unknown user result callback query: %s", callBackQueryData))
+	if strings.HasPrefix(callBackQueryData, constants.UserResultHistory) {
+		h.history(ctx, b)
+		return
+	}
+
+	if strings.HasPrefix(callBackQueryData, constants.UserResultRecords) {
+		h.records(ctx, b)
+		return
+	}
+
+	if strings.HasPrefix(callBackQueryData, constants.UserResultChart) {
+		h.chart(ctx, b)
+		return
+	}
+
+	h.logger.WithContext(ctx).Warn(fmt.Sprintf("Unknown user result callback query: %s", callBackQueryData))
 }
 
 func (h *userResultHandler) list(ctx context.Context, b *tg_bot.Bot) {
@@ -83,14 +124,21 @@ func (h *userResultHandler) list(ctx context.Context, b *tg_bot.Bot) {
 	userProgram := utils_context.GetUserProgramFromContext(ctx)
 
 	if userProgram.UserId != user.Id {
-		h.logger.Error(fmt.Sprintf("Program %d is not assigned for user %d", userProgram.Id, user.Id))
+		h.logger.WithContext(ctx).Error(fmt.Sprintf("Program %d is not assigned for user %d", userProgram.Id, user.Id))
 		msg := messages.UserProgramNotAssignedMessage(userProgram.Name())
 		kb := inline_keyboards.UserProgramListOk()
 		h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
 		return
 	}
 
-	records := h.userResultRepository.GetAllByUserProgramId(ctx, userProgram.Id)
+	results, err := h.userResultRepository.GetAllByUserProgramId(ctx, userProgram.Id)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	records := rep_scheme.FilterResults(userProgram.Program, results)
 
 	kb := inline_keyboards.UserProgramMenuOk(userProgram.Id)
 
@@ -100,7 +148,7 @@ func (h *userResultHandler) list(ctx context.Context, b *tg_bot.Bot) {
 		return
 	}
 
-	msg := messages.UserProgramResultsMessage(userProgram.Name(), records)
+	msg := messages.UserProgramResultsMessage(userProgram.Name(), userProgram.Program.GetOneRepMaxFormula(), records, user.GetWeightUnit())
 	h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
 }
 
@@ -112,14 +160,19 @@ func (h *userResultHandler) exerciseList(ctx context.Context, b *tg_bot.Bot) {
 	offset := utils_context.GetOffsetFromContext(ctx)
 
 	if userProgram.UserId != user.Id {
-		h.logger.Error(fmt.Sprintf("Program %d is not assigned for user %d", userProgram.Id, user.Id))
+		h.logger.WithContext(ctx).Error(fmt.Sprintf("Program %d is not assigned for user %d", userProgram.Id, user.Id))
 		msg := messages.UserProgramNotAssignedMessage(userProgram.Name())
 		kb := inline_keyboards.UserProgramListOk()
 		h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
 		return
 	}
 
-	exercises := h.exerciseRepository.GetByProgramId(ctx, userProgram.ProgramId, limit, offset)
+	exercises, err := h.exerciseRepository.GetByProgramId(ctx, userProgram.ProgramId, limit, offset)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	if len(exercises) == 0 {
 		msg := messages.NoExercisesMessage(userProgram.Name())
@@ -128,7 +181,12 @@ func (h *userResultHandler) exerciseList(ctx context.Context, b *tg_bot.Bot) {
 		return
 	}
 
-	exercisesCount := h.exerciseRepository.CountByProgramId(ctx, userProgram.ProgramId)
+	exercisesCount, err := h.exerciseRepository.CountByProgramId(ctx, userProgram.ProgramId)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
 	msg := messages.UserProgramResultsSelectExerciseMessage(userProgram.Name())
 
@@ -137,34 +195,19 @@ func (h *userResultHandler) exerciseList(ctx context.Context, b *tg_bot.Bot) {
 	h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
 }
 
-func (h *userResultHandler) getWeight(ctx context.Context, b *tg_bot.Bot) (int, error) {
+func (h *userResultHandler) exerciseSelected(ctx context.Context, b *tg_bot.Bot) {
 	chatId := utils_context.GetChatIdFromContext(ctx)
+	userProgram := utils_context.GetUserProgramFromContext(ctx)
+	exercise := utils_context.GetExerciseFromContext(ctx)
 
-	conversation := h.conversationService.CreateConversation(chatId)
-	defer h.conversationService.DeleteConversation(chatId)
-
-	answer := conversation.WaitAnswer()
-
-	if ctx.Err() != nil {
-		return 0, errors.New("context canceled")
-	}
-
-	weight, err := validate_data.ValidateWeightAnswer(answer)
+	results, err := h.userResultRepository.GetAllByUserProgramIdAndExerciseId(ctx, userProgram.Id, exercise.Id)
 
 	if err != nil {
-		h.senderService.Send(ctx, b, chatId, err.Error())
-		return h.getWeight(ctx, b)
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
 	}
 
-	return weight, nil
-}
-
-func (h *userResultHandler) exerciseSelected(ctx context.Context, b *tg_bot.Bot) {
-	chatId := utils_context.GetChatIdFromContext(ctx)
-	userProgram := utils_context.GetUserProgramFromContext(ctx)
-	exercise := utils_context.GetExerciseFromContext(ctx)
-
-	records := h.userResultRepository.GetAllByUserProgramIdAndExerciseId(ctx, userProgram.Id, exercise.Id)
+	records := rep_scheme.FilterResults(userProgram.Program, results)
 
 	if len(records) == 0 {
 		msg := messages.NoRecordsForUserProgramMessage(userProgram.Name())
@@ -181,25 +224,125 @@ func (h *userResultHandler) exerciseSelected(ctx context.Context, b *tg_bot.Bot)
 
 func (h *userResultHandler) exerciseRepsSelected(ctx context.Context, b *tg_bot.Bot) {
 	chatId := utils_context.GetChatIdFromContext(ctx)
+	params := utils_context.GetParamsFromContext(ctx)
+
+	h.wizardService.Start(ctx, b, chatId, constants.UserResultWeightState, *params)
+}
+
+func (h *userResultHandler) exerciseRepsComplete(ctx context.Context, b *tg_bot.Bot, data types.WizardData) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+	user := utils_context.GetCurrentUserFromContext(ctx)
 	record := utils_context.GetUserResultFromContext(ctx)
+	weight := data.Float(constants.ResultWeightConversationKey)
 
-	userMsg := messages.EnterUserResultMessage(record.Name())
+	if _, err := h.userResultService.SaveResult(ctx, b, *user, *record, weight, constants.ClientResultSource, user.Id); err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
-	userMsgId := h.senderService.SendSafe(ctx, b, chatId, userMsg)
+	msg := messages.UserProgramResultModifiedMessage(record.Name(), record.Reps)
+	kb := inline_keyboards.UserProgramMenuOk(record.UserProgramId)
+	h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+}
 
-	weight, err := h.getWeight(ctx, b)
+func (h *userResultHandler) history(ctx context.Context, b *tg_bot.Bot) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+	user := utils_context.GetCurrentUserFromContext(ctx)
+	userProgram := utils_context.GetUserProgramFromContext(ctx)
+	record := utils_context.GetUserResultFromContext(ctx)
+	limit := utils_context.GetLimitFromContext(ctx)
+	offset := utils_context.GetOffsetFromContext(ctx)
+
+	if userProgram.UserId != user.Id || record.UserProgramId != userProgram.Id {
+		h.logger.WithContext(ctx).Error(fmt.Sprintf("Result %d is not assigned for user %d", record.Id, user.Id))
+		msg := messages.UserProgramNotAssignedMessage(userProgram.Name())
+		kb := inline_keyboards.UserProgramListOk()
+		h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+		return
+	}
+
+	entries, err := h.userResultHistoryRepository.GetByUserResultId(ctx, record.Id, limit, offset)
 
 	if err != nil {
-		h.senderService.Delete(context.Background(), b, chatId, userMsgId)
+		h.senderService.SendError(ctx, b, chatId, err)
 		return
 	}
+	entriesCount, err := h.userResultHistoryRepository.CountByUserResultId(ctx, record.Id)
 
-	h.userResultRepository.UpdateById(ctx, record.Id, models.UserResult{
-		Weight: weight,
-	})
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
 
-	msg := messages.UserProgramResultModifiedMessage(record.Name(), record.Reps)
-	kb := inline_keyboards.UserProgramMenuOk(record.UserProgramId)
-	h.senderService.Delete(ctx, b, chatId, userMsgId)
+	kb := inline_keyboards.UserResultHistory(*record, len(entries), entriesCount, limit, offset)
+
+	if len(entries) == 0 {
+		msg := messages.NoUserResultHistoryMessage(record.Name(), record.Reps)
+		h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+		return
+	}
+
+	msg := messages.UserResultHistoryMessage(record.Name(), record.Reps, entries, offset, user.GetWeightUnit())
 	h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
 }
+
+func (h *userResultHandler) records(ctx context.Context, b *tg_bot.Bot) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+	user := utils_context.GetCurrentUserFromContext(ctx)
+
+	records, err := h.userResultHistoryRepository.GetRecordsByUserId(ctx, user.Id)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	kb := inline_keyboards.UserMenuOk()
+
+	if len(records) == 0 {
+		h.senderService.SendWithKb(ctx, b, chatId, messages.NoUserRecordsMessage(), kb)
+		return
+	}
+
+	h.senderService.SendWithKb(ctx, b, chatId, messages.UserRecordsMessage(records, user.GetWeightUnit()), kb)
+}
+
+func (h *userResultHandler) chart(ctx context.Context, b *tg_bot.Bot) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+	user := utils_context.GetCurrentUserFromContext(ctx)
+	userProgram := utils_context.GetUserProgramFromContext(ctx)
+	exercise := utils_context.GetExerciseFromContext(ctx)
+
+	if userProgram.UserId != user.Id {
+		h.logger.WithContext(ctx).Error(fmt.Sprintf("Program %d is not assigned for user %d", userProgram.Id, user.Id))
+		msg := messages.UserProgramNotAssignedMessage(userProgram.Name())
+		kb := inline_keyboards.UserProgramListOk()
+		h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+		return
+	}
+
+	entries, err := h.userResultHistoryRepository.GetAllByUserIdAndExerciseId(ctx, user.Id, exercise.Id)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+	series := chart.ResultSeries(entries, rep_scheme.ForExercise(userProgram.Program, *exercise), user.GetWeightUnit())
+
+	kb := inline_keyboards.UserResultChartOk(userProgram.Id, exercise.Id)
+
+	if len(series) == 0 {
+		msg := messages.NoUserResultChartMessage(exercise.Name)
+		h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+		return
+	}
+
+	photo, err := chart.RenderLineChart(series)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	h.senderService.SendPhotoWithKb(ctx, b, chatId, photo, messages.UserResultChartMessage(exercise.Name, user.GetWeightUnit()), kb)
+}
@@ -0,0 +1,500 @@
+package callback_queries
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	tg_bot "github.com/go-telegram/bot"
+	tg_models "github.com/go-telegram/bot/models"
+	"go.uber.org/dig"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/internal/logger"
+	"rezvin-pro-bot/src/models"
+	"rezvin-pro-bot/src/repositories"
+	"rezvin-pro-bot/src/services"
+	utils_context "rezvin-pro-bot/src/utils/context"
+	"rezvin-pro-bot/src/utils/inline_keyboards"
+	"rezvin-pro-bot/src/utils/messages"
+	"rezvin-pro-bot/src/utils/validate"
+	"strings"
+	"time"
+)
+
+type IUserWorkoutHandler interface {
+	Handle(ctx context.Context, b *tg_bot.Bot, update *tg_models.Update)
+}
+
+type userWorkoutHandlerDependencies struct {
+	dig.In
+
+	Logger              logger.ILogger                `name:"Logger"`
+	ConversationService services.IConversationService `name:"ConversationService"`
+	SenderService       services.ISenderService       `name:"SenderService"`
+	RestTimerService    services.IRestTimerService    `name:"RestTimerService"`
+
+	ExerciseRepository       repositories.IExerciseRepository       `name:"ExerciseRepository"`
+	WorkoutSessionRepository repositories.IWorkoutSessionRepository `name:"WorkoutSessionRepository"`
+	WorkoutSetRepository     repositories.IWorkoutSetRepository     `name:"WorkoutSetRepository"`
+}
+
+type userWorkoutHandler struct {
+	logger                   logger.ILogger
+	conversationService      services.IConversationService
+	senderService            services.ISenderService
+	restTimerService         services.IRestTimerService
+	exerciseRepository       repositories.IExerciseRepository
+	workoutSessionRepository repositories.IWorkoutSessionRepository
+	workoutSetRepository     repositories.IWorkoutSetRepository
+}
+
+func NewUserWorkoutHandler(deps userWorkoutHandlerDependencies) *userWorkoutHandler {
+	h := &userWorkoutHandler{
+		logger:                   deps.Logger,
+		conversationService:      deps.ConversationService,
+		senderService:            deps.SenderService,
+		restTimerService:         deps.RestTimerService,
+		exerciseRepository:       deps.ExerciseRepository,
+		workoutSessionRepository: deps.WorkoutSessionRepository,
+		workoutSetRepository:     deps.WorkoutSetRepository,
+	}
+
+	h.conversationService.RegisterStep(constants.UserWorkoutSetState, h.addSetAnswer)
+
+	return h
+}
+
+func (h *userWorkoutHandler) Handle(ctx context.Context, b *tg_bot.Bot, update *tg_models.Update) {
+	callBackQueryData := update.CallbackQuery.Data
+
+	if strings.HasPrefix(callBackQueryData, constants.UserWorkoutStart) {
+		h.start(ctx, b)
+		return
+	}
+
+	if strings.HasPrefix(callBackQueryData, constants.UserWorkoutAddSet) {
+		h.addSet(ctx, b)
+		return
+	}
+
+	if strings.HasPrefix(callBackQueryData, constants.UserWorkoutNext) {
+		h.next(ctx, b)
+		return
+	}
+
+	if strings.HasPrefix(callBackQueryData, constants.UserWorkoutFinish) {
+		h.finish(ctx, b)
+		return
+	}
+
+	if strings.HasPrefix(callBackQueryData, constants.UserWorkoutCancel) {
+		h.cancel(ctx, b)
+		return
+	}
+
+	if strings.HasPrefix(callBackQueryData, constants.UserWorkoutResume) {
+		h.resume(ctx, b)
+		return
+	}
+
+	if strings.HasPrefix(callBackQueryData, constants.UserWorkoutRest) {
+		h.rest(ctx, b)
+		return
+	}
+
+	if strings.HasPrefix(callBackQueryData, constants.UserWorkoutRestCancel) {
+		h.restCancel(ctx, b)
+		return
+	}
+
+	h.logger.WithContext(ctx).Warn(fmt.Sprintf("Unknown user workout callback query: %s", callBackQueryData))
+}
+
+func (h *userWorkoutHandler) start(ctx context.Context, b *tg_bot.Bot) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+	user := utils_context.GetCurrentUserFromContext(ctx)
+	userProgram := utils_context.GetUserProgramFromContext(ctx)
+
+	if userProgram.UserId != user.Id {
+		h.logger.WithContext(ctx).Error(fmt.Sprintf("Program %d is not assigned for user %d", userProgram.Id, user.Id))
+		msg := messages.UserProgramNotAssignedMessage(userProgram.Name())
+		kb := inline_keyboards.UserProgramListOk()
+		h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+		return
+	}
+
+	session, err := h.workoutSessionRepository.GetActiveByUserId(ctx, user.Id)
+
+	if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	if err == nil && session.UserProgramId == userProgram.Id {
+		h.sendExercise(ctx, b, *session)
+		return
+	}
+
+	if err == nil {
+		msg := messages.WorkoutSessionActiveMessage(session.Name())
+		kb := inline_keyboards.UserWorkoutActive(*session, userProgram.Id)
+		h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+		return
+	}
+
+	exercises, err := h.exerciseRepository.GetAllByProgramId(ctx, userProgram.ProgramId)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	if len(exercises) == 0 {
+		msg := messages.WorkoutNoExercisesMessage(userProgram.Name())
+		kb := inline_keyboards.UserProgramMenuOk(userProgram.Id)
+		h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+		return
+	}
+
+	sessionId, err := h.workoutSessionRepository.Create(ctx, models.WorkoutSession{
+		UserId:            user.Id,
+		UserProgramId:     userProgram.Id,
+		CurrentExerciseId: exercises[0].Id,
+	})
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	session, err = h.workoutSessionRepository.GetById(ctx, sessionId)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	h.sendExercise(ctx, b, *session)
+}
+
+func (h *userWorkoutHandler) addSet(ctx context.Context, b *tg_bot.Bot) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+	params := utils_context.GetParamsFromContext(ctx)
+	user := utils_context.GetCurrentUserFromContext(ctx)
+
+	session, ok := h.getActiveSession(ctx, b)
+
+	if !ok {
+		return
+	}
+
+	exercise, err := h.exerciseRepository.GetById(ctx, session.CurrentExerciseId)
+
+	if errors.Is(err, repositories.ErrNotFound) {
+		h.sendExercise(ctx, b, *session)
+		return
+	}
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	if _, err := h.restTimerService.Cancel(ctx, chatId); err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	sets, err := h.workoutSetRepository.GetAllByWorkoutSessionIdAndExerciseId(ctx, session.Id, exercise.Id)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	msg := messages.EnterWorkoutSetMessage(exercise.Name, len(sets)+1, user.GetWeightUnit())
+
+	h.conversationService.Start(ctx, b, chatId, constants.UserWorkoutSetState, *params, msg)
+}
+
+func (h *userWorkoutHandler) addSetAnswer(ctx context.Context, b *tg_bot.Bot, conversation *models.Conversation, answer string) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+	user := utils_context.GetCurrentUserFromContext(ctx)
+
+	session, ok := h.getActiveSession(ctx, b)
+
+	if !ok {
+		h.conversationService.Finish(ctx, b, conversation)
+		return
+	}
+
+	exercise, err := h.exerciseRepository.GetById(ctx, session.CurrentExerciseId)
+
+	if errors.Is(err, repositories.ErrNotFound) {
+		h.conversationService.Finish(ctx, b, conversation)
+		h.sendExercise(ctx, b, *session)
+		return
+	}
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	reps, weight, err := validate_data.ValidateSetAnswer(answer, user.GetWeightUnit())
+
+	if err != nil {
+		h.senderService.Send(ctx, b, chatId, err.Error())
+		return
+	}
+
+	sets, err := h.workoutSetRepository.GetAllByWorkoutSessionIdAndExerciseId(ctx, session.Id, exercise.Id)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	_, err = h.workoutSetRepository.Create(ctx, models.WorkoutSet{
+		WorkoutSessionId: session.Id,
+		ExerciseId:       exercise.Id,
+		SetNumber:        uint(len(sets) + 1),
+		Reps:             reps,
+		Weight:           weight,
+	})
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	h.conversationService.Finish(ctx, b, conversation)
+	h.sendExercise(ctx, b, *session)
+}
+
+func (h *userWorkoutHandler) next(ctx context.Context, b *tg_bot.Bot) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+
+	session, ok := h.getActiveSession(ctx, b)
+
+	if !ok {
+		return
+	}
+
+	exercises, err := h.exerciseRepository.GetAllByProgramId(ctx, session.UserProgram.ProgramId)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	index := findExerciseIndex(exercises, session.CurrentExerciseId)
+
+	if index+1 >= len(exercises) {
+		h.finish(ctx, b)
+		return
+	}
+
+	session.CurrentExerciseId = exercises[index+1].Id
+
+	err = h.workoutSessionRepository.UpdateById(ctx, session.Id, models.WorkoutSession{
+		CurrentExerciseId: session.CurrentExerciseId,
+	})
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	h.sendExercise(ctx, b, *session)
+}
+
+func (h *userWorkoutHandler) finish(ctx context.Context, b *tg_bot.Bot) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+	user := utils_context.GetCurrentUserFromContext(ctx)
+
+	session, ok := h.getActiveSession(ctx, b)
+
+	if !ok {
+		return
+	}
+
+	if _, err := h.restTimerService.Cancel(ctx, chatId); err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	finishedAt := time.Now()
+
+	err := h.workoutSessionRepository.UpdateById(ctx, session.Id, models.WorkoutSession{
+		FinishedAt:      &finishedAt,
+		DurationSeconds: int64(finishedAt.Sub(session.StartedAt).Seconds()),
+	})
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	finished, err := h.workoutSessionRepository.GetById(ctx, session.Id)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	msg := messages.WorkoutSessionFinishedMessage(*finished, user.GetWeightUnit())
+	kb := inline_keyboards.UserProgramMenuOk(session.UserProgramId)
+
+	h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+}
+
+func (h *userWorkoutHandler) cancel(ctx context.Context, b *tg_bot.Bot) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+
+	session, ok := h.getActiveSession(ctx, b)
+
+	if !ok {
+		return
+	}
+
+	if _, err := h.restTimerService.Cancel(ctx, chatId); err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	if err := h.workoutSessionRepository.DeleteById(ctx, session.Id); err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	msg := messages.WorkoutSessionCancelledMessage(session.Name())
+	kb := inline_keyboards.UserProgramMenuOk(session.UserProgramId)
+
+	h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+}
+
+func (h *userWorkoutHandler) resume(ctx context.Context, b *tg_bot.Bot) {
+	session, ok := h.getActiveSession(ctx, b)
+
+	if !ok {
+		return
+	}
+
+	h.sendExercise(ctx, b, *session)
+}
+
+func (h *userWorkoutHandler) rest(ctx context.Context, b *tg_bot.Bot) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+	seconds := utils_context.GetRestSecondsFromContext(ctx)
+
+	session, ok := h.getActiveSession(ctx, b)
+
+	if !ok {
+		return
+	}
+
+	if err := h.restTimerService.Start(ctx, b, chatId, session.Id, seconds); err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	msg := messages.RestTimerStartedMessage(uint(seconds))
+	kb := inline_keyboards.UserWorkoutRestStarted(session.Id)
+
+	h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+}
+
+func (h *userWorkoutHandler) restCancel(ctx context.Context, b *tg_bot.Bot) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+
+	session, ok := h.getActiveSession(ctx, b)
+
+	if !ok {
+		return
+	}
+
+	if _, err := h.restTimerService.Cancel(ctx, chatId); err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	h.sendExercise(ctx, b, *session)
+}
+
+func (h *userWorkoutHandler) getActiveSession(ctx context.Context, b *tg_bot.Bot) (*models.WorkoutSession, bool) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+	user := utils_context.GetCurrentUserFromContext(ctx)
+	session := utils_context.GetWorkoutSessionFromContext(ctx)
+
+	if session.UserId != user.Id {
+		h.logger.WithContext(ctx).Error(fmt.Sprintf("Workout session %d does not belong to user %d", session.Id, user.Id))
+		msg := messages.WorkoutSessionNotFoundMessage(session.Id)
+		kb := inline_keyboards.UserMenuOk()
+		h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+		return nil, false
+	}
+
+	if session.IsFinished() {
+		msg := messages.WorkoutSessionNotActiveMessage()
+		kb := inline_keyboards.UserProgramMenuOk(session.UserProgramId)
+		h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+		return nil, false
+	}
+
+	return session, true
+}
+
+func (h *userWorkoutHandler) sendExercise(ctx context.Context, b *tg_bot.Bot, session models.WorkoutSession) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+	user := utils_context.GetCurrentUserFromContext(ctx)
+
+	exercises, err := h.exerciseRepository.GetAllByProgramId(ctx, session.UserProgram.ProgramId)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	if len(exercises) == 0 {
+		msg := messages.WorkoutNoExercisesMessage(session.Name())
+		kb := inline_keyboards.UserProgramMenuOk(session.UserProgramId)
+		h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+		return
+	}
+
+	index := findExerciseIndex(exercises, session.CurrentExerciseId)
+	exercise := exercises[index]
+
+	if exercise.Id != session.CurrentExerciseId {
+		err = h.workoutSessionRepository.UpdateById(ctx, session.Id, models.WorkoutSession{
+			CurrentExerciseId: exercise.Id,
+		})
+
+		if err != nil {
+			h.senderService.SendError(ctx, b, chatId, err)
+			return
+		}
+	}
+
+	sets, err := h.workoutSetRepository.GetAllByWorkoutSessionIdAndExerciseId(ctx, session.Id, exercise.Id)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	msg := messages.WorkoutExerciseMessage(session.Name(), exercise.Name, index+1, len(exercises), sets, user.GetWeightUnit())
+	kb := inline_keyboards.UserWorkoutExercise(session, len(sets) > 0, index == len(exercises)-1)
+
+	h.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+}
+
+func findExerciseIndex(exercises []models.Exercise, exerciseId uint) int {
+	for i, exercise := range exercises {
+		if exercise.Id == exerciseId {
+			return i
+		}
+	}
+
+	return 0
+}
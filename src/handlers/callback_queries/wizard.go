@@ -0,0 +1,139 @@
+package callback_queries
+
+import (
+	"context"
+	"fmt"
+	tg_bot "github.com/go-telegram/bot"
+	tg_models "github.com/go-telegram/bot/models"
+	"go.uber.org/dig"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/internal/logger"
+	"rezvin-pro-bot/src/services"
+	"rezvin-pro-bot/src/types"
+	utils_context "rezvin-pro-bot/src/utils/context"
+	"rezvin-pro-bot/src/utils/inline_keyboards"
+	"rezvin-pro-bot/src/utils/messages"
+	"rezvin-pro-bot/src/utils/units"
+	"rezvin-pro-bot/src/utils/validate"
+	"strconv"
+	"strings"
+)
+
+type IWizardHandler interface {
+	Handle(ctx context.Context, b *tg_bot.Bot, update *tg_models.Update)
+}
+
+type wizardHandlerDependencies struct {
+	dig.In
+
+	Logger        logger.ILogger          `name:"Logger"`
+	SenderService services.ISenderService `name:"SenderService"`
+	WizardService services.IWizardService `name:"WizardService"`
+}
+
+type wizardHandler struct {
+	logger        logger.ILogger
+	senderService services.ISenderService
+	wizardService services.IWizardService
+}
+
+func NewWizardHandler(deps wizardHandlerDependencies) *wizardHandler {
+	return &wizardHandler{
+		logger:        deps.Logger,
+		senderService: deps.SenderService,
+		wizardService: deps.WizardService,
+	}
+}
+
+func (h *wizardHandler) Handle(ctx context.Context, b *tg_bot.Bot, update *tg_models.Update) {
+	callbackDataQuery := update.CallbackQuery.Data
+
+	if strings.HasPrefix(callbackDataQuery, constants.WizardBack) {
+		h.back(ctx, b)
+		return
+	}
+
+	if strings.HasPrefix(callbackDataQuery, constants.WizardCancel) {
+		h.cancel(ctx, b)
+		return
+	}
+
+	if strings.HasPrefix(callbackDataQuery, constants.WizardConfirm) {
+		h.confirm(ctx, b)
+		return
+	}
+
+	h.logger.WithContext(ctx).Warn(fmt.Sprintf("Unknown wizard callback query data: %s", callbackDataQuery))
+}
+
+func (h *wizardHandler) back(ctx context.Context, b *tg_bot.Bot) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+
+	ok, err := h.wizardService.Back(ctx, b, chatId)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	if !ok {
+		h.notFound(ctx, b)
+	}
+}
+
+func (h *wizardHandler) cancel(ctx context.Context, b *tg_bot.Bot) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+
+	h.wizardService.Cancel(ctx, b, chatId)
+}
+
+func (h *wizardHandler) confirm(ctx context.Context, b *tg_bot.Bot) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+
+	ok, err := h.wizardService.Confirm(ctx, b, chatId)
+
+	if err != nil {
+		h.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	if !ok {
+		h.notFound(ctx, b)
+	}
+}
+
+// resultWeightStep asks for a weight in the unit of the current user and keeps it in kilograms.
+func resultWeightStep(prompt func(ctx context.Context) string) types.WizardStep {
+	return types.WizardStep{
+		Key: constants.ResultWeightConversationKey,
+		Prompt: func(ctx context.Context, _ types.WizardData) string {
+			return prompt(ctx)
+		},
+		Validate: func(ctx context.Context, answer string) (string, error) {
+			user := utils_context.GetCurrentUserFromContext(ctx)
+
+			weight, err := validate_data.ValidateWeightAnswer(answer, user.GetWeightUnit())
+
+			if err != nil {
+				return "", err
+			}
+
+			return strconv.FormatFloat(weight, 'f', -1, 64), nil
+		},
+		Format: func(ctx context.Context, value string) string {
+			return resultWeightText(ctx, types.WizardData{constants.ResultWeightConversationKey: value})
+		},
+	}
+}
+
+func resultWeightText(ctx context.Context, data types.WizardData) string {
+	user := utils_context.GetCurrentUserFromContext(ctx)
+
+	return units.FormatWeight(data.Float(constants.ResultWeightConversationKey), user.GetWeightUnit())
+}
+
+func (h *wizardHandler) notFound(ctx context.Context, b *tg_bot.Bot) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+
+	h.senderService.SendWithKb(ctx, b, chatId, messages.WizardNotFoundMessage(), inline_keyboards.StartOk())
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	tg_bot "github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
@@ -16,24 +17,28 @@ import (
 
 type ICommandHandler interface {
 	Start(ctx context.Context, b *tg_bot.Bot, update *models.Update)
+	Cancel(ctx context.Context, b *tg_bot.Bot, update *models.Update)
 }
 
 type commandHandlerDependencies struct {
 	dig.In
 
 	SenderService services.ISenderService `name:"SenderService"`
+	WizardService services.IWizardService `name:"WizardService"`
 
 	UserRepository repositories.IUserRepository `name:"UserRepository"`
 }
 
 type commandHandler struct {
 	senderService  services.ISenderService
+	wizardService  services.IWizardService
 	userRepository repositories.IUserRepository
 }
 
 func NewCommandHandler(deps commandHandlerDependencies) *commandHandler {
 	return &commandHandler{
 		senderService:  deps.SenderService,
+		wizardService:  deps.WizardService,
 		userRepository: deps.UserRepository,
 	}
 }
@@ -44,16 +49,21 @@ func (c *commandHandler) Start(ctx context.Context, b *tg_bot.Bot, update *model
 	firstName := bot_utils.GetFirstName(update)
 	lastName := bot_utils.GetLastName(update)
 
-	user := c.userRepository.GetById(ctx, userId)
+	user, err := c.userRepository.GetById(ctx, userId)
 
 	name := fmt.Sprintf("%s %s", firstName, lastName)
 
-	if user == nil {
+	if errors.Is(err, repositories.ErrNotFound) {
 		kb := inline_keyboards.UserRegister()
 		c.senderService.SendWithKb(ctx, b, chatId, messages.NeedRegister(name), kb)
 		return
 	}
 
+	if err != nil {
+		c.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
 	if user.IsAdmin {
 		kb := inline_keyboards.AdminMain()
 		c.senderService.SendWithKb(ctx, b, chatId, messages.AdminMainMessage(), kb)
@@ -70,3 +80,9 @@ func (c *commandHandler) Start(ctx context.Context, b *tg_bot.Bot, update *model
 		}
 	}
 }
+
+func (c *commandHandler) Cancel(ctx context.Context, b *tg_bot.Bot, _ *models.Update) {
+	chatId := utils_context.GetChatIdFromContext(ctx)
+
+	c.wizardService.Cancel(ctx, b, chatId)
+}
@@ -36,10 +36,7 @@ func NewDefaultHandler(deps defaultHandlerDependencies) *defaultHandler {
 func (h *defaultHandler) Handle(ctx context.Context, b *tg_bot.Bot, update *models.Update) {
 	chatId := utils_context.GetChatIdFromContext(ctx)
 
-	if h.conversationService.IsConversationExists(chatId) {
-		conversation := h.conversationService.GetConversation(chatId)
-
-		conversation.Answer(update.Message.Text)
+	if update.Message != nil && h.conversationService.Resume(ctx, b, chatId, update.Message.Text) {
 		return
 	}
 
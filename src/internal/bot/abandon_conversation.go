@@ -7,11 +7,12 @@ import (
 	bot_utils "rezvin-pro-bot/src/utils/bot"
 )
 
-func (bot *bot) skipIfConversationExistsMiddleware(next tg_bot.HandlerFunc) tg_bot.HandlerFunc {
+func (bot *bot) abandonConversationMiddleware(next tg_bot.HandlerFunc) tg_bot.HandlerFunc {
 	return func(ctx context.Context, b *tg_bot.Bot, update *tg_models.Update) {
 		chatId := bot_utils.GetChatID(update)
 
-		if bot.conversationService.IsConversationExists(chatId) {
+		if _, err := bot.conversationService.Abandon(ctx, b, chatId); err != nil {
+			bot.senderService.SendError(ctx, b, chatId, err)
 			return
 		}
 
@@ -0,0 +1,57 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rezvin-pro-bot/src/constants"
+	"time"
+)
+
+// startAdmin serves metrics and probes over plain HTTP on a port separate from the TLS webhook.
+func (bot *bot) startAdmin() {
+	port := bot.config.AdminPort()
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", bot.metrics.Handler())
+	mux.HandleFunc("/healthz", bot.healthz)
+	mux.HandleFunc("/readyz", bot.readyz)
+
+	bot.adminServer = http.Server{
+		Addr:         port,
+		ReadTimeout:  30 * time.Second,
+		WriteTimeout: 30 * time.Second,
+		IdleTimeout:  30 * time.Second,
+		Handler:      mux,
+	}
+
+	go func() {
+		bot.logger.Log(fmt.Sprintf("Starting admin server on port %s", port))
+		if err := bot.adminServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			bot.logger.Error(fmt.Sprintf("Failed to start admin server: %s", err))
+		}
+	}()
+}
+
+func (bot *bot) healthz(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": constants.HealthStatusOk})
+}
+
+func (bot *bot) readyz(w http.ResponseWriter, r *http.Request) {
+	report := bot.healthService.Readiness(r.Context(), bot.bot)
+
+	status := http.StatusOK
+
+	if !report.IsOk() {
+		status = http.StatusServiceUnavailable
+	}
+
+	writeJSON(w, status, report)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -17,7 +17,7 @@ func (bot *bot) answerCallbackQueryMiddleware(next tg_bot.HandlerFunc) tg_bot.Ha
 		if !answerResult {
 			chatId := utils_context.GetChatIdFromContext(ctx)
 
-			bot.logger.Error(fmt.Sprintf("Failed to answer callback query: %s", update.CallbackQuery.ID))
+			bot.logger.WithContext(ctx).Error(fmt.Sprintf("Failed to answer callback query: %s", update.CallbackQuery.ID))
 
 			msg := messages.ErrorMessage()
 			kb := inline_keyboards.StartOk()
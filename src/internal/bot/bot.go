@@ -9,6 +9,7 @@ import (
 	"rezvin-pro-bot/src/handlers"
 	"rezvin-pro-bot/src/handlers/callback_queries"
 	"rezvin-pro-bot/src/internal/logger"
+	"rezvin-pro-bot/src/internal/metrics"
 	"rezvin-pro-bot/src/repositories"
 	"rezvin-pro-bot/src/services"
 	"rezvin-pro-bot/src/utils"
@@ -22,12 +23,17 @@ type IBot interface {
 type botDependencies struct {
 	dig.In
 
-	Logger logger.ILogger `name:"Logger"`
-	Config config.IConfig `name:"Config"`
+	Logger  logger.ILogger   `name:"Logger"`
+	Config  config.IConfig   `name:"Config"`
+	Metrics metrics.IMetrics `name:"Metrics"`
 
-	SenderService       services.ISenderService       `name:"SenderService"`
-	LockService         services.ILockService         `name:"LockService"`
-	ConversationService services.IConversationService `name:"ConversationService"`
+	SenderService        services.ISenderService        `name:"SenderService"`
+	RestTimerService     services.IRestTimerService     `name:"RestTimerService"`
+	LockService          services.ILockService          `name:"LockService"`
+	ConversationService  services.IConversationService  `name:"ConversationService"`
+	CallbackStateService services.ICallbackStateService `name:"CallbackStateService"`
+	HealthService        services.IHealthService        `name:"HealthService"`
+	AlertService         services.IAlertService         `name:"AlertService"`
 
 	DefaultHandler       handlers.IDefaultHandler               `name:"DefaultHandler"`
 	CommandsHandler      handlers.ICommandHandler               `name:"CommandHandler"`
@@ -45,26 +51,40 @@ type botDependencies struct {
 	UserProgramHandler   callback_queries.IUserProgramHandler   `name:"UserProgramHandler"`
 	UserMeasureHandler   callback_queries.IUserMeasureHandler   `name:"UserMeasureHandler"`
 	MainHandler          callback_queries.IMainHandler          `name:"MainHandler"`
-
-	UserRepository        repositories.IUserRepository        `name:"UserRepository"`
-	ProgramRepository     repositories.IProgramRepository     `name:"ProgramRepository"`
-	UserProgramRepository repositories.IUserProgramRepository `name:"UserProgramRepository"`
-	UserMeasureRepository repositories.IUserMeasureRepository `name:"UserMeasureRepository"`
-	UserResultRepository  repositories.IUserResultRepository  `name:"UserResultRepository"`
-	ExerciseRepository    repositories.IExerciseRepository    `name:"ExerciseRepository"`
-	MeasureRepository     repositories.IMeasureRepository     `name:"MeasureRepository"`
+	SettingsHandler      callback_queries.ISettingsHandler      `name:"SettingsHandler"`
+	UserWorkoutHandler   callback_queries.IUserWorkoutHandler   `name:"UserWorkoutHandler"`
+	ClientWorkoutHandler callback_queries.IClientWorkoutHandler `name:"ClientWorkoutHandler"`
+	ProgramImportHandler callback_queries.IProgramImportHandler `name:"ProgramImportHandler"`
+	WizardHandler        callback_queries.IWizardHandler        `name:"WizardHandler"`
+	DeadLetterHandler    callback_queries.IDeadLetterHandler    `name:"DeadLetterHandler"`
+
+	UserRepository           repositories.IUserRepository           `name:"UserRepository"`
+	ProgramRepository        repositories.IProgramRepository        `name:"ProgramRepository"`
+	UserProgramRepository    repositories.IUserProgramRepository    `name:"UserProgramRepository"`
+	UserMeasureRepository    repositories.IUserMeasureRepository    `name:"UserMeasureRepository"`
+	UserResultRepository     repositories.IUserResultRepository     `name:"UserResultRepository"`
+	ExerciseRepository       repositories.IExerciseRepository       `name:"ExerciseRepository"`
+	MeasureRepository        repositories.IMeasureRepository        `name:"MeasureRepository"`
+	WorkoutSessionRepository repositories.IWorkoutSessionRepository `name:"WorkoutSessionRepository"`
 }
 
 type bot struct {
-	logger logger.ILogger
-	config config.IConfig `name:"Config"`
-
-	bot    *tg_bot.Bot
-	server http.Server
-
-	senderService       services.ISenderService
-	lockService         services.ILockService
-	conversationService services.IConversationService
+	logger  logger.ILogger
+	config  config.IConfig `name:"Config"`
+	metrics metrics.IMetrics
+
+	bot              *tg_bot.Bot
+	server           http.Server
+	adminServer      http.Server
+	callbackPrefixes []string
+
+	senderService        services.ISenderService
+	restTimerService     services.IRestTimerService
+	lockService          services.ILockService
+	conversationService  services.IConversationService
+	callbackStateService services.ICallbackStateService
+	healthService        services.IHealthService
+	alertService         services.IAlertService
 
 	commandsHandler      handlers.ICommandHandler
 	defaultHandler       handlers.IDefaultHandler
@@ -82,24 +102,36 @@ type bot struct {
 	userProgramHandler   callback_queries.IUserProgramHandler
 	userMeasureHandler   callback_queries.IUserMeasureHandler
 	mainHandler          callback_queries.IMainHandler
-
-	userRepository        repositories.IUserRepository
-	programRepository     repositories.IProgramRepository
-	userProgramRepository repositories.IUserProgramRepository
-	userResultRepository  repositories.IUserResultRepository
-	userMeasureRepository repositories.IUserMeasureRepository
-	exerciseRepository    repositories.IExerciseRepository
-	measureRepository     repositories.IMeasureRepository
+	settingsHandler      callback_queries.ISettingsHandler
+	userWorkoutHandler   callback_queries.IUserWorkoutHandler
+	clientWorkoutHandler callback_queries.IClientWorkoutHandler
+	programImportHandler callback_queries.IProgramImportHandler
+	wizardHandler        callback_queries.IWizardHandler
+	deadLetterHandler    callback_queries.IDeadLetterHandler
+
+	userRepository           repositories.IUserRepository
+	programRepository        repositories.IProgramRepository
+	userProgramRepository    repositories.IUserProgramRepository
+	userResultRepository     repositories.IUserResultRepository
+	userMeasureRepository    repositories.IUserMeasureRepository
+	exerciseRepository       repositories.IExerciseRepository
+	measureRepository        repositories.IMeasureRepository
+	workoutSessionRepository repositories.IWorkoutSessionRepository
 }
 
 func NewBot(deps botDependencies) *bot {
 	b := &bot{
-		logger: deps.Logger,
-		config: deps.Config,
-
-		senderService:       deps.SenderService,
-		lockService:         deps.LockService,
-		conversationService: deps.ConversationService,
+		logger:  deps.Logger,
+		config:  deps.Config,
+		metrics: deps.Metrics,
+
+		senderService:        deps.SenderService,
+		restTimerService:     deps.RestTimerService,
+		lockService:          deps.LockService,
+		conversationService:  deps.ConversationService,
+		callbackStateService: deps.CallbackStateService,
+		healthService:        deps.HealthService,
+		alertService:         deps.AlertService,
 
 		commandsHandler:      deps.CommandsHandler,
 		defaultHandler:       deps.DefaultHandler,
@@ -113,26 +145,37 @@ func NewBot(deps botDependencies) *bot {
 		userProgramHandler:   deps.UserProgramHandler,
 		userMeasureHandler:   deps.UserMeasureHandler,
 		mainHandler:          deps.MainHandler,
+		settingsHandler:      deps.SettingsHandler,
+		userWorkoutHandler:   deps.UserWorkoutHandler,
+		clientWorkoutHandler: deps.ClientWorkoutHandler,
+		programImportHandler: deps.ProgramImportHandler,
+		wizardHandler:        deps.WizardHandler,
+		deadLetterHandler:    deps.DeadLetterHandler,
 		clientHandler:        deps.ClientHandler,
 		clientProgramHandler: deps.ClientProgramHandler,
 		clientResultHandler:  deps.ClientResultHandler,
 		clientMeasureHandler: deps.ClientMeasureHandler,
 
-		userRepository:        deps.UserRepository,
-		programRepository:     deps.ProgramRepository,
-		userProgramRepository: deps.UserProgramRepository,
-		userResultRepository:  deps.UserResultRepository,
-		userMeasureRepository: deps.UserMeasureRepository,
-		exerciseRepository:    deps.ExerciseRepository,
-		measureRepository:     deps.MeasureRepository,
+		userRepository:           deps.UserRepository,
+		programRepository:        deps.ProgramRepository,
+		userProgramRepository:    deps.UserProgramRepository,
+		userResultRepository:     deps.UserResultRepository,
+		userMeasureRepository:    deps.UserMeasureRepository,
+		exerciseRepository:       deps.ExerciseRepository,
+		measureRepository:        deps.MeasureRepository,
+		workoutSessionRepository: deps.WorkoutSessionRepository,
 	}
 
 	opts := []tg_bot.Option{
 		tg_bot.WithWebhookSecretToken(b.config.WebhookSecretToken()),
-		tg_bot.WithDefaultHandler(b.defaultHandler.Handle),
+		tg_bot.WithDefaultHandler(b.resumeConversationMiddleware(b.defaultHandler.Handle)),
 		tg_bot.WithMiddlewares(b.defaultMiddlewares()...),
 	}
 
+	if b.config.BotApiUrl() != "" {
+		opts = append(opts, tg_bot.WithServerURL(b.config.BotApiUrl()))
+	}
+
 	tgBot, err := tg_bot.New(b.config.BotToken(), opts...)
 
 	utils.PanicIfError(err)
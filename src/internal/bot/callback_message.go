@@ -0,0 +1,19 @@
+package bot
+
+import (
+	"context"
+	tg_bot "github.com/go-telegram/bot"
+	tg_models "github.com/go-telegram/bot/models"
+	utils_context "rezvin-pro-bot/src/utils/context"
+)
+
+func (bot *bot) callbackMessageMiddleware(next tg_bot.HandlerFunc) tg_bot.HandlerFunc {
+	return func(ctx context.Context, b *tg_bot.Bot, update *tg_models.Update) {
+		if update.CallbackQuery == nil || update.CallbackQuery.Message.Message == nil {
+			next(ctx, b, update)
+			return
+		}
+
+		next(utils_context.GetContextWithCallbackMessage(ctx, update.CallbackQuery.Message.Message), b, update)
+	}
+}
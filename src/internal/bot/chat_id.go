@@ -2,9 +2,13 @@ package bot
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	tg_bot "github.com/go-telegram/bot"
 	tg_models "github.com/go-telegram/bot/models"
 	"rezvin-pro-bot/src/models"
+	"rezvin-pro-bot/src/repositories"
+	"rezvin-pro-bot/src/utils"
 	bot_utils "rezvin-pro-bot/src/utils/bot"
 	utils_context "rezvin-pro-bot/src/utils/context"
 )
@@ -14,15 +18,20 @@ func (bot *bot) chatIdMiddleware(next tg_bot.HandlerFunc) tg_bot.HandlerFunc {
 		chatId := bot_utils.GetChatID(update)
 		userId := bot_utils.GetUserID(update)
 
-		user := bot.userRepository.GetById(ctx, userId)
+		user, err := bot.userRepository.GetById(ctx, userId)
 
-		if user != nil && user.ChatId != chatId {
-			user.ChatId = chatId
-			bot.userRepository.UpdateById(ctx, userId, models.User{
+		if err == nil && user.ChatId != chatId {
+			err = bot.userRepository.UpdateById(ctx, userId, models.User{
 				ChatId: chatId,
 			})
 		}
 
+		// Keeping the chat id in sync is incidental to the update, the handlers
+		// report database failures themselves.
+		if err != nil && !errors.Is(err, repositories.ErrNotFound) && !utils.IsContextError(err) {
+			bot.logger.WithContext(ctx).WithError(err).Error(fmt.Sprintf("Failed to sync chat id for user %d", userId))
+		}
+
 		next(utils_context.GetContextWithChatId(ctx, chatId), b, update)
 	}
 }
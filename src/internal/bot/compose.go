@@ -4,7 +4,7 @@ import tg_bot "github.com/go-telegram/bot"
 
 func (bot *bot) adminMiddlewares() []tg_bot.Middleware {
 	return []tg_bot.Middleware{
-		bot.skipIfConversationExistsMiddleware,
+		bot.abandonConversationMiddleware,
 		bot.answerCallbackQueryMiddleware,
 		bot.isRegisteredMiddleware,
 		bot.isAdminMiddleware,
@@ -15,7 +15,7 @@ func (bot *bot) adminMiddlewares() []tg_bot.Middleware {
 
 func (bot *bot) userMiddlewares() []tg_bot.Middleware {
 	return []tg_bot.Middleware{
-		bot.skipIfConversationExistsMiddleware,
+		bot.abandonConversationMiddleware,
 		bot.answerCallbackQueryMiddleware,
 		bot.isRegisteredMiddleware,
 		bot.isApprovedMiddleware,
@@ -26,18 +26,42 @@ func (bot *bot) userMiddlewares() []tg_bot.Middleware {
 
 func (bot *bot) mainMiddlewares() []tg_bot.Middleware {
 	return []tg_bot.Middleware{
-		bot.skipIfConversationExistsMiddleware,
+		bot.abandonConversationMiddleware,
 		bot.answerCallbackQueryMiddleware,
 		bot.isRegisteredMiddleware,
 	}
 }
 
+func (bot *bot) documentMiddlewares() []tg_bot.Middleware {
+	return []tg_bot.Middleware{
+		bot.abandonConversationMiddleware,
+		bot.isRegisteredMiddleware,
+		bot.isAdminMiddleware,
+	}
+}
+
+func (bot *bot) wizardMiddlewares() []tg_bot.Middleware {
+	return []tg_bot.Middleware{
+		bot.answerCallbackQueryMiddleware,
+		bot.resumeConversationMiddleware,
+	}
+}
+
+func (bot *bot) commandMiddlewares() []tg_bot.Middleware {
+	return []tg_bot.Middleware{
+		bot.abandonConversationMiddleware,
+	}
+}
+
 func (bot *bot) defaultMiddlewares() []tg_bot.Middleware {
 	return []tg_bot.Middleware{
+		bot.metricsMiddleware,
 		bot.skipOtherTypesMiddleware,
+		bot.logContextMiddleware,
 		bot.timeoutMiddleware,
 		bot.panicRecoveryMiddleware,
 		bot.chatIdMiddleware,
+		bot.callbackMessageMiddleware,
 		bot.forbidParallel,
 	}
 }
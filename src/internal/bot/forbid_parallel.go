@@ -16,15 +16,11 @@ func (bot *bot) forbidParallel(next tg_bot.HandlerFunc) tg_bot.HandlerFunc {
 
 		key := fmt.Sprintf("%d:%d", chatId, userId)
 
-		isLocked := bot.lockService.TryLock(key)
+		isLocked := bot.lockService.TryLock(ctx, key)
 
 		if !isLocked {
-			if bot.conversationService.IsConversationExists(chatId) {
-				next(ctx, b, update)
-				return
-			}
-
-			bot.logger.Log(fmt.Sprintf("forbidParallel: chatId: %d, userId: %d,  msgID %d is locked", chatId, userId, msgId))
+			bot.metrics.IncDroppedUpdates()
+			bot.logger.WithContext(ctx).WithField("message_id", msgId).Log("forbidParallel: chat is locked, update dropped")
 			return
 		}
 
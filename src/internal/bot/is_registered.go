@@ -2,9 +2,11 @@ package bot
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	tg_bot "github.com/go-telegram/bot"
 	tg_models "github.com/go-telegram/bot/models"
+	"rezvin-pro-bot/src/repositories"
 	bot_utils "rezvin-pro-bot/src/utils/bot"
 	utils_context "rezvin-pro-bot/src/utils/context"
 	"rezvin-pro-bot/src/utils/inline_keyboards"
@@ -15,10 +17,11 @@ func (bot *bot) isRegisteredMiddleware(next tg_bot.HandlerFunc) tg_bot.HandlerFu
 	return func(ctx context.Context, b *tg_bot.Bot, update *tg_models.Update) {
 		userId := bot_utils.GetUserID(update)
 
-		user := bot.userRepository.GetById(ctx, userId)
+		chatId := utils_context.GetChatIdFromContext(ctx)
 
-		if user == nil {
-			chatId := utils_context.GetChatIdFromContext(ctx)
+		user, err := bot.userRepository.GetById(ctx, userId)
+
+		if errors.Is(err, repositories.ErrNotFound) {
 			firstName := bot_utils.GetFirstName(update)
 			lastName := bot_utils.GetLastName(update)
 
@@ -30,6 +33,11 @@ func (bot *bot) isRegisteredMiddleware(next tg_bot.HandlerFunc) tg_bot.HandlerFu
 			return
 		}
 
+		if err != nil {
+			bot.senderService.SendError(ctx, b, chatId, err)
+			return
+		}
+
 		next(utils_context.GetContextWithCurrentUser(ctx, user), b, update)
 	}
 }
@@ -0,0 +1,41 @@
+package bot
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	tg_bot "github.com/go-telegram/bot"
+	tg_models "github.com/go-telegram/bot/models"
+	"rezvin-pro-bot/src/constants"
+	bot_utils "rezvin-pro-bot/src/utils/bot"
+	utils_context "rezvin-pro-bot/src/utils/context"
+)
+
+const requestIdLength = 8
+
+// logContextMiddleware stores the fields that identify an update in the context,
+// so every logger.WithContext(ctx) call below it can be correlated to one request.
+func (bot *bot) logContextMiddleware(next tg_bot.HandlerFunc) tg_bot.HandlerFunc {
+	return func(ctx context.Context, b *tg_bot.Bot, update *tg_models.Update) {
+		fields := map[string]any{
+			constants.LogFieldRequestId: newRequestId(),
+			constants.LogFieldUpdateId:  update.ID,
+			constants.LogFieldChatId:    bot_utils.GetChatID(update),
+			constants.LogFieldUserId:    bot_utils.GetUserID(update),
+		}
+
+		if updateType, prefix := bot.updateLabels(update); updateType == "callback_query" {
+			fields[constants.LogFieldCallbackPrefix] = prefix
+		}
+
+		next(utils_context.GetContextWithLogFields(ctx, fields), b, update)
+	}
+}
+
+func newRequestId() string {
+	id := make([]byte, requestIdLength)
+
+	_, _ = rand.Read(id)
+
+	return hex.EncodeToString(id)
+}
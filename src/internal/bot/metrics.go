@@ -0,0 +1,48 @@
+package bot
+
+import (
+	"context"
+	tg_bot "github.com/go-telegram/bot"
+	tg_models "github.com/go-telegram/bot/models"
+	"strings"
+	"time"
+)
+
+const unknownCallbackPrefix = "unknown"
+
+func (bot *bot) metricsMiddleware(next tg_bot.HandlerFunc) tg_bot.HandlerFunc {
+	return func(ctx context.Context, b *tg_bot.Bot, update *tg_models.Update) {
+		start := time.Now()
+
+		defer func() {
+			updateType, prefix := bot.updateLabels(update)
+			bot.metrics.ObserveUpdate(updateType, prefix, time.Since(start))
+		}()
+
+		next(ctx, b, update)
+	}
+}
+
+// updateLabels keeps the prefix label bounded by only reporting prefixes that have a registered handler.
+func (bot *bot) updateLabels(update *tg_models.Update) (string, string) {
+	switch {
+	case update.Message != nil:
+		return "message", ""
+	case update.CallbackQuery != nil:
+		prefix := ""
+
+		for _, registered := range bot.callbackPrefixes {
+			if strings.HasPrefix(update.CallbackQuery.Data, registered) && len(registered) > len(prefix) {
+				prefix = registered
+			}
+		}
+
+		if prefix == "" {
+			prefix = unknownCallbackPrefix
+		}
+
+		return "callback_query", prefix
+	default:
+		return "other", ""
+	}
+}
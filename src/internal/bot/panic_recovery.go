@@ -5,6 +5,7 @@ import (
 	"fmt"
 	tg_bot "github.com/go-telegram/bot"
 	tg_models "github.com/go-telegram/bot/models"
+	"rezvin-pro-bot/src/internal/logger"
 	bot_utils "rezvin-pro-bot/src/utils/bot"
 	"rezvin-pro-bot/src/utils/messages"
 	"runtime"
@@ -24,7 +25,13 @@ func (bot *bot) panicRecoveryMiddleware(next tg_bot.HandlerFunc) tg_bot.HandlerF
 					return
 				}
 
-				bot.logger.Error(fmt.Sprintf("[PANIC RECOVER] %v %s\n", err, stack))
+				bot.metrics.IncPanics()
+				bot.logger.WithContext(ctx).WithFields(logger.Fields{
+					"panic": fmt.Sprint(err),
+					"stack": string(stack),
+				}).Error("[PANIC RECOVER]")
+
+				bot.alertService.ReportPanic(ctx, err)
 
 				b.SendMessage(ctx, &tg_bot.SendMessageParams{
 					ChatID:    chatID,
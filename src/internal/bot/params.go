@@ -2,14 +2,17 @@ package bot
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	tg_bot "github.com/go-telegram/bot"
 	tg_models "github.com/go-telegram/bot/models"
 	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/repositories"
 	bot_utils "rezvin-pro-bot/src/utils/bot"
 	utils_context "rezvin-pro-bot/src/utils/context"
 	"rezvin-pro-bot/src/utils/inline_keyboards"
 	"rezvin-pro-bot/src/utils/messages"
+	"strings"
 )
 
 func (bot *bot) parseParamsMiddleware(next tg_bot.HandlerFunc) tg_bot.HandlerFunc {
@@ -17,10 +20,42 @@ func (bot *bot) parseParamsMiddleware(next tg_bot.HandlerFunc) tg_bot.HandlerFun
 		callbackQueryData := update.CallbackQuery.Data
 		chatId := utils_context.GetChatIdFromContext(ctx)
 
-		params, err := bot_utils.ParseParamsFromQueryString(callbackQueryData)
+		if strings.Contains(callbackQueryData, constants.CallbackStateSeparator) {
+			state, err := bot.callbackStateService.Resolve(ctx, chatId, callbackQueryData)
+
+			if err != nil {
+				bot.senderService.SendError(ctx, b, chatId, err)
+				return
+			}
+
+			if state == nil {
+				bot.logger.WithContext(ctx).Warn(fmt.Sprintf("Rejected expired or unknown callback state: %s", callbackQueryData))
+				msg := messages.MenuExpiredMessage()
+				kb := inline_keyboards.StartOk()
+
+				bot.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+				return
+			}
+
+			ctx = utils_context.GetContextWithCallbackStateData(ctx, state.Data)
+
+			next(utils_context.GetContextWithParams(ctx, &state.Params), b, update)
+			return
+		}
+
+		params, err := bot_utils.DecodeCallbackData(callbackQueryData)
+
+		if errors.Is(err, bot_utils.ErrCallbackDataExpired) {
+			bot.logger.WithContext(ctx).Warn(fmt.Sprintf("Rejected expired or tampered callback data: %s", callbackQueryData))
+			msg := messages.MenuExpiredMessage()
+			kb := inline_keyboards.StartOk()
+
+			bot.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+			return
+		}
 
 		if err != nil {
-			bot.logger.Error(fmt.Sprintf("Failed to parse params: %s", callbackQueryData))
+			bot.logger.WithContext(ctx).Error(fmt.Sprintf("Failed to parse params: %s", callbackQueryData))
 			msg := messages.ParamsErrorMessage(err)
 			kb := inline_keyboards.StartOk()
 
@@ -29,7 +64,7 @@ func (bot *bot) parseParamsMiddleware(next tg_bot.HandlerFunc) tg_bot.HandlerFun
 		}
 
 		if params == nil {
-			bot.logger.Error(fmt.Sprintf("Failed to parse params: %s", update.Message.Text))
+			bot.logger.WithContext(ctx).Error(fmt.Sprintf("Failed to parse params: %s", update.Message.Text))
 			msg := messages.ErrorMessage()
 			kb := inline_keyboards.StartOk()
 
@@ -47,9 +82,9 @@ func (bot *bot) validateParamsMiddleware(next tg_bot.HandlerFunc) tg_bot.Handler
 		params := utils_context.GetParamsFromContext(ctx)
 
 		if params.UserId != 0 {
-			user := bot.userRepository.GetById(ctx, params.UserId)
+			user, err := bot.userRepository.GetById(ctx, params.UserId)
 
-			if user == nil {
+			if errors.Is(err, repositories.ErrNotFound) {
 				msg := messages.UserNotFoundMessage(params.UserId)
 				kb := inline_keyboards.StartOk()
 
@@ -57,13 +92,18 @@ func (bot *bot) validateParamsMiddleware(next tg_bot.HandlerFunc) tg_bot.Handler
 				return
 			}
 
+			if err != nil {
+				bot.senderService.SendError(ctx, b, chatId, err)
+				return
+			}
+
 			ctx = utils_context.GetContextWithUser(ctx, user)
 		}
 
 		if params.ProgramId != 0 {
-			program := bot.programRepository.GetById(ctx, params.ProgramId)
+			program, err := bot.programRepository.GetById(ctx, params.ProgramId)
 
-			if program == nil {
+			if errors.Is(err, repositories.ErrNotFound) {
 				msg := messages.ProgramNotFoundMessage(params.ProgramId)
 				kb := inline_keyboards.StartOk()
 
@@ -71,13 +111,18 @@ func (bot *bot) validateParamsMiddleware(next tg_bot.HandlerFunc) tg_bot.Handler
 				return
 			}
 
+			if err != nil {
+				bot.senderService.SendError(ctx, b, chatId, err)
+				return
+			}
+
 			ctx = utils_context.GetContextWithProgram(ctx, program)
 		}
 
 		if params.ExerciseId != 0 {
-			exercise := bot.exerciseRepository.GetById(ctx, params.ExerciseId)
+			exercise, err := bot.exerciseRepository.GetById(ctx, params.ExerciseId)
 
-			if exercise == nil {
+			if errors.Is(err, repositories.ErrNotFound) {
 				msg := messages.ExerciseNotFoundMessage(params.ExerciseId)
 				kb := inline_keyboards.StartOk()
 
@@ -85,13 +130,18 @@ func (bot *bot) validateParamsMiddleware(next tg_bot.HandlerFunc) tg_bot.Handler
 				return
 			}
 
+			if err != nil {
+				bot.senderService.SendError(ctx, b, chatId, err)
+				return
+			}
+
 			ctx = utils_context.GetContextWithExercise(ctx, exercise)
 		}
 
 		if params.UserProgramId != 0 {
-			userProgram := bot.userProgramRepository.GetById(ctx, params.UserProgramId)
+			userProgram, err := bot.userProgramRepository.GetById(ctx, params.UserProgramId)
 
-			if userProgram == nil {
+			if errors.Is(err, repositories.ErrNotFound) {
 				msg := messages.ClientProgramNotFoundMessage(params.UserProgramId)
 				kb := inline_keyboards.StartOk()
 
@@ -99,13 +149,18 @@ func (bot *bot) validateParamsMiddleware(next tg_bot.HandlerFunc) tg_bot.Handler
 				return
 			}
 
+			if err != nil {
+				bot.senderService.SendError(ctx, b, chatId, err)
+				return
+			}
+
 			ctx = utils_context.GetContextWithUserProgram(ctx, userProgram)
 		}
 
 		if params.UserResultId != 0 {
-			record := bot.userResultRepository.GetById(ctx, params.UserResultId)
+			record, err := bot.userResultRepository.GetById(ctx, params.UserResultId)
 
-			if record == nil {
+			if errors.Is(err, repositories.ErrNotFound) {
 				msg := messages.ClientResultNotFoundMessage(params.UserResultId)
 				kb := inline_keyboards.StartOk()
 
@@ -113,13 +168,18 @@ func (bot *bot) validateParamsMiddleware(next tg_bot.HandlerFunc) tg_bot.Handler
 				return
 			}
 
+			if err != nil {
+				bot.senderService.SendError(ctx, b, chatId, err)
+				return
+			}
+
 			ctx = utils_context.GetContextWithUserResult(ctx, record)
 		}
 
 		if params.MeasureId != 0 {
-			measure := bot.measureRepository.GetById(ctx, params.MeasureId)
+			measure, err := bot.measureRepository.GetById(ctx, params.MeasureId)
 
-			if measure == nil {
+			if errors.Is(err, repositories.ErrNotFound) {
 				msg := messages.MeasureNotFoundMessage(params.MeasureId)
 				kb := inline_keyboards.StartOk()
 
@@ -127,13 +187,18 @@ func (bot *bot) validateParamsMiddleware(next tg_bot.HandlerFunc) tg_bot.Handler
 				return
 			}
 
+			if err != nil {
+				bot.senderService.SendError(ctx, b, chatId, err)
+				return
+			}
+
 			ctx = utils_context.GetContextWithMeasure(ctx, measure)
 		}
 
 		if params.UserMeasureId != 0 {
-			userMeasure := bot.userMeasureRepository.GetById(ctx, params.UserMeasureId)
+			userMeasure, err := bot.userMeasureRepository.GetById(ctx, params.UserMeasureId)
 
-			if userMeasure == nil {
+			if errors.Is(err, repositories.ErrNotFound) {
 				msg := messages.ClientMeasureNotFoundMessage(params.UserMeasureId)
 				kb := inline_keyboards.StartOk()
 
@@ -141,13 +206,41 @@ func (bot *bot) validateParamsMiddleware(next tg_bot.HandlerFunc) tg_bot.Handler
 				return
 			}
 
+			if err != nil {
+				bot.senderService.SendError(ctx, b, chatId, err)
+				return
+			}
+
 			ctx = utils_context.GetContextWithUserMeasure(ctx, userMeasure)
 		}
 
+		if params.WorkoutSessionId != 0 {
+			session, err := bot.workoutSessionRepository.GetById(ctx, params.WorkoutSessionId)
+
+			if errors.Is(err, repositories.ErrNotFound) {
+				msg := messages.WorkoutSessionNotFoundMessage(params.WorkoutSessionId)
+				kb := inline_keyboards.StartOk()
+
+				bot.senderService.SendWithKb(ctx, b, chatId, msg, kb)
+				return
+			}
+
+			if err != nil {
+				bot.senderService.SendError(ctx, b, chatId, err)
+				return
+			}
+
+			ctx = utils_context.GetContextWithWorkoutSession(ctx, session)
+		}
+
 		if params.Reps != constants.Zero {
 			ctx = utils_context.GetContextWithReps(ctx, params.Reps)
 		}
 
+		if params.RestSeconds != 0 {
+			ctx = utils_context.GetContextWithRestSeconds(ctx, params.RestSeconds)
+		}
+
 		if params.Limit != 0 {
 			ctx = utils_context.GetContextWithLimit(ctx, params.Limit)
 		}
@@ -2,6 +2,7 @@ package bot
 
 import (
 	tg_bot "github.com/go-telegram/bot"
+	tg_models "github.com/go-telegram/bot/models"
 	"rezvin-pro-bot/src/constants"
 )
 
@@ -16,6 +17,8 @@ func (bot *bot) registerCommand(command string, handler tg_bot.HandlerFunc, midd
 }
 
 func (bot *bot) registerCallbackQueryByPrefix(prefix string, handler tg_bot.HandlerFunc, middlewares []tg_bot.Middleware) {
+	bot.callbackPrefixes = append(bot.callbackPrefixes, prefix)
+
 	bot.bot.RegisterHandler(
 		tg_bot.HandlerTypeCallbackQueryData,
 		prefix,
@@ -25,19 +28,32 @@ func (bot *bot) registerCallbackQueryByPrefix(prefix string, handler tg_bot.Hand
 	)
 }
 
+func (bot *bot) registerDocument(handler tg_bot.HandlerFunc, middlewares []tg_bot.Middleware) {
+	bot.bot.RegisterHandlerMatchFunc(
+		func(update *tg_models.Update) bool {
+			return update.Message != nil && update.Message.Document != nil
+		},
+		handler,
+		middlewares...,
+	)
+}
+
 func (bot *bot) registerHandlers() {
 	if bot.bot == nil {
 		panic("cannot register handlers without bot instance")
 	}
 
-	bot.registerCommand(constants.CommandStart, bot.commandsHandler.Start, bot.emptyMiddlewares())
+	bot.registerCommand(constants.CommandStart, bot.commandsHandler.Start, bot.commandMiddlewares())
+	bot.registerCommand(constants.CommandCancel, bot.commandsHandler.Cancel, bot.emptyMiddlewares())
 
 	bot.registerCallbackQueryByPrefix(constants.MainPrefix, bot.mainHandler.Handle, bot.mainMiddlewares())
+	bot.registerCallbackQueryByPrefix(constants.SettingsPrefix, bot.settingsHandler.Handle, bot.mainMiddlewares())
 
 	bot.registerCallbackQueryByPrefix(constants.RegisterPrefix, bot.registerHandler.Handle, bot.emptyMiddlewares())
 	bot.registerCallbackQueryByPrefix(constants.UserProgramPrefix, bot.userProgramHandler.Handle, bot.userMiddlewares())
 	bot.registerCallbackQueryByPrefix(constants.UserResultPrefix, bot.userResultHandler.Handle, bot.userMiddlewares())
 	bot.registerCallbackQueryByPrefix(constants.UserMeasurePrefix, bot.userMeasureHandler.Handle, bot.userMiddlewares())
+	bot.registerCallbackQueryByPrefix(constants.UserWorkoutPrefix, bot.userWorkoutHandler.Handle, bot.userMiddlewares())
 
 	bot.registerCallbackQueryByPrefix(constants.ProgramPrefix, bot.programHandler.Handle, bot.adminMiddlewares())
 	bot.registerCallbackQueryByPrefix(constants.ExercisePrefix, bot.exerciseHandler.Handle, bot.adminMiddlewares())
@@ -48,4 +64,10 @@ func (bot *bot) registerHandlers() {
 	bot.registerCallbackQueryByPrefix(constants.ClientProgramPrefix, bot.clientProgramHandler.Handle, bot.adminMiddlewares())
 	bot.registerCallbackQueryByPrefix(constants.ClientResultPrefix, bot.clientResultHandler.Handle, bot.adminMiddlewares())
 	bot.registerCallbackQueryByPrefix(constants.ClientMeasurePrefix, bot.clientMeasureHandler.Handle, bot.adminMiddlewares())
+	bot.registerCallbackQueryByPrefix(constants.ClientWorkoutPrefix, bot.clientWorkoutHandler.Handle, bot.adminMiddlewares())
+	bot.registerCallbackQueryByPrefix(constants.ImportPrefix, bot.programImportHandler.Handle, bot.adminMiddlewares())
+	bot.registerCallbackQueryByPrefix(constants.DeadLetterPrefix, bot.deadLetterHandler.Handle, bot.adminMiddlewares())
+	bot.registerCallbackQueryByPrefix(constants.WizardPrefix, bot.wizardHandler.Handle, bot.wizardMiddlewares())
+
+	bot.registerDocument(bot.programImportHandler.HandleDocument, bot.documentMiddlewares())
 }
@@ -0,0 +1,75 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	tg_bot "github.com/go-telegram/bot"
+	tg_models "github.com/go-telegram/bot/models"
+	"rezvin-pro-bot/src/constants"
+	utils_context "rezvin-pro-bot/src/utils/context"
+)
+
+func (bot *bot) resumeConversationMiddleware(next tg_bot.HandlerFunc) tg_bot.HandlerFunc {
+	return func(ctx context.Context, b *tg_bot.Bot, update *tg_models.Update) {
+		chatId := utils_context.GetChatIdFromContext(ctx)
+
+		conversation, err := bot.conversationService.GetConversation(ctx, chatId)
+
+		if err != nil {
+			bot.senderService.SendError(ctx, b, chatId, err)
+			return
+		}
+
+		if conversation == nil {
+			next(ctx, b, update)
+			return
+		}
+
+		resumed := false
+
+		guard := bot.conversationGuard(conversation.State)
+
+		handler := bot.isRegisteredMiddleware(guard(bot.validateParamsMiddleware(
+			func(ctx context.Context, b *tg_bot.Bot, update *tg_models.Update) {
+				resumed = true
+				next(ctx, b, update)
+			},
+		)))
+
+		params := conversation.Params
+
+		handler(utils_context.GetContextWithParams(ctx, &params), b, update)
+
+		if !resumed {
+			bot.conversationService.Finish(ctx, b, conversation)
+		}
+	}
+}
+
+// conversationGuard returns the access check of the callbacks that start conversations in
+// the state, so a resumed step is not run for a user who has lost that access meanwhile.
+func (bot *bot) conversationGuard(state constants.ConversationState) tg_bot.Middleware {
+	switch state {
+	case constants.UserMeasureValueState,
+		constants.UserResultWeightState,
+		constants.UserWorkoutSetState:
+		return bot.isApprovedMiddleware
+	case constants.ProgramAddNameState,
+		constants.ProgramRenameState,
+		constants.ProgramRepSchemeState,
+		constants.ExerciseAddNameState,
+		constants.ExerciseRepSchemeState,
+		constants.MeasureAddState,
+		constants.MeasureRenameState,
+		constants.MeasureChangeUnitsState,
+		constants.ClientMeasureValueState,
+		constants.ClientResultWeightState:
+		return bot.isAdminMiddleware
+	}
+
+	return func(_ tg_bot.HandlerFunc) tg_bot.HandlerFunc {
+		return func(ctx context.Context, _ *tg_bot.Bot, _ *tg_models.Update) {
+			bot.logger.WithContext(ctx).Warn(fmt.Sprintf("No access check for conversation state %s, conversation dropped", state))
+		}
+	}
+}
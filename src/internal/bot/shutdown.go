@@ -13,6 +13,12 @@ func (bot *bot) Shutdown(ctx context.Context) error {
 		return fmt.Errorf("error while shutting down server: %w", err)
 	}
 
+	err = bot.adminServer.Shutdown(ctx)
+
+	if err != nil {
+		return fmt.Errorf("error while shutting down admin server: %w", err)
+	}
+
 	bot.senderService.SendSafe(ctx, bot.bot, bot.config.AlertChatId(), fmt.Sprintf("Бот %s вимкнено\\! Схоже сталась критична помилка", globals.AdminName))
 
 	return nil
@@ -11,8 +11,17 @@ import (
 )
 
 func (bot *bot) Start(ctx context.Context) {
+	bot.alertService.Start(bot.bot)
+
 	bot.senderService.Send(ctx, bot.bot, bot.config.AlertChatId(), fmt.Sprintf("Бот %s запустився і готовий до роботи\\!", globals.AdminName))
 
+	if err := bot.restTimerService.Restore(ctx, bot.bot); err != nil {
+		bot.logger.WithError(err).Error("Failed to restore rest timers")
+		bot.alertService.Report(ctx, constants.RepositoryAlertKind, err)
+	}
+
+	bot.startAdmin()
+
 	if bot.config.AppEnv() == constants.DevelopmentEnv {
 		bot.startPolling(ctx)
 	} else {
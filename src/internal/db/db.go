@@ -10,18 +10,21 @@ import (
 	"rezvin-pro-bot/src/config"
 	"rezvin-pro-bot/src/constants"
 	internal_logger "rezvin-pro-bot/src/internal/logger"
+	"rezvin-pro-bot/src/internal/metrics"
 )
 
 type IDatabase interface {
 	Shutdown(ctx context.Context) error
 	GetInstance() *gorm.DB
+	Ping(ctx context.Context) error
 }
 
 type databaseDependencies struct {
 	dig.In
 
-	Logger internal_logger.ILogger `name:"Logger"`
-	Config config.IConfig          `name:"Config"`
+	Logger  internal_logger.ILogger `name:"Logger"`
+	Config  config.IConfig          `name:"Config"`
+	Metrics metrics.IMetrics        `name:"Metrics"`
 }
 
 type database struct {
@@ -53,6 +56,22 @@ func NewDatabase(deps databaseDependencies) *database {
 		panic(err)
 	}
 
+	err = dbInstance.Use(&metrics.GormPlugin{Metrics: deps.Metrics})
+
+	if err != nil {
+		db.logger.Error(fmt.Sprintf("Failed to register database metrics: %s", err))
+		panic(err)
+	}
+
+	sqlDB, err := dbInstance.DB()
+
+	if err != nil {
+		db.logger.Error(fmt.Sprintf("Failed to get database instance: %s", err))
+		panic(err)
+	}
+
+	sqlDB.SetMaxOpenConns(db.config.PostgresMaxOpenConns())
+
 	db.logger.Log("Connected to database")
 
 	db.instance = dbInstance
@@ -64,6 +83,16 @@ func (db *database) GetInstance() *gorm.DB {
 	return db.instance
 }
 
+func (db *database) Ping(ctx context.Context) error {
+	dbInstance, err := db.instance.DB()
+
+	if err != nil {
+		return fmt.Errorf("failed to get database instance: %s", err)
+	}
+
+	return dbInstance.PingContext(ctx)
+}
+
 func (db *database) Shutdown(_ context.Context) error {
 	dbInstance, err := db.instance.DB()
 
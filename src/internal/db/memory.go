@@ -0,0 +1,26 @@
+package db
+
+import (
+	"context"
+	"gorm.io/gorm"
+)
+
+// memoryDatabase stands in for the database in the in-memory container, where the
+// repositories keep their data in the process and there is no connection to check or close.
+type memoryDatabase struct{}
+
+func NewMemoryDatabase() *memoryDatabase {
+	return &memoryDatabase{}
+}
+
+func (db *memoryDatabase) GetInstance() *gorm.DB {
+	return nil
+}
+
+func (db *memoryDatabase) Ping(_ context.Context) error {
+	return nil
+}
+
+func (db *memoryDatabase) Shutdown(_ context.Context) error {
+	return nil
+}
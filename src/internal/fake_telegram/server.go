@@ -0,0 +1,290 @@
+package fake_telegram
+
+import (
+	"encoding/json"
+	"fmt"
+	tg_models "github.com/go-telegram/bot/models"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const Token = "123456:fake-token"
+
+const pollWait = 500 * time.Millisecond
+
+type Call struct {
+	Method string
+	Params map[string]string
+}
+
+type Server struct {
+	server *httptest.Server
+
+	mu            sync.Mutex
+	calls         []Call
+	messages      map[int64]map[int]*tg_models.Message
+	updates       []*tg_models.Update
+	lastUpdateId  int64
+	lastMessageId int
+	notify        chan struct{}
+}
+
+func NewServer() *Server {
+	s := &Server{
+		messages: make(map[int64]map[int]*tg_models.Message),
+		notify:   make(chan struct{}),
+	}
+
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+
+	return s
+}
+
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+func (s *Server) Calls() []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Call(nil), s.calls...)
+}
+
+func (s *Server) CallsFor(method string) []Call {
+	var result []Call
+
+	for _, call := range s.Calls() {
+		if call.Method == method {
+			result = append(result, call)
+		}
+	}
+
+	return result
+}
+
+// Messages returns the messages currently visible in the chat, oldest first.
+func (s *Server) Messages(chatId int64) []tg_models.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result []tg_models.Message
+
+	for id := 1; id <= s.lastMessageId; id++ {
+		if msg, ok := s.messages[chatId][id]; ok {
+			result = append(result, *msg)
+		}
+	}
+
+	return result
+}
+
+func (s *Server) InjectMessage(from tg_models.User, text string) {
+	s.inject(&tg_models.Update{
+		Message: &tg_models.Message{
+			ID:   s.nextMessageId(),
+			From: &from,
+			Chat: privateChat(from),
+			Date: int(time.Now().Unix()),
+			Text: text,
+		},
+	})
+}
+
+func (s *Server) InjectCallback(from tg_models.User, message tg_models.Message, data string) {
+	s.inject(&tg_models.Update{
+		CallbackQuery: &tg_models.CallbackQuery{
+			ID:   strconv.FormatInt(time.Now().UnixNano(), 10),
+			From: from,
+			Message: tg_models.MaybeInaccessibleMessage{
+				Type:    tg_models.MaybeInaccessibleMessageTypeMessage,
+				Message: &message,
+			},
+			Data: data,
+		},
+	})
+}
+
+func (s *Server) inject(update *tg_models.Update) {
+	s.mu.Lock()
+	s.lastUpdateId++
+	update.ID = s.lastUpdateId
+	s.updates = append(s.updates, update)
+	close(s.notify)
+	s.notify = make(chan struct{})
+	s.mu.Unlock()
+}
+
+func (s *Server) nextMessageId() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastMessageId++
+
+	return s.lastMessageId
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	method := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+
+	params := map[string]string{}
+
+	if err := r.ParseMultipartForm(32 << 20); err == nil {
+		for key, values := range r.MultipartForm.Value {
+			params[key] = values[0]
+		}
+	}
+
+	if method == "getUpdates" {
+		s.writeResult(w, s.getUpdates(r, params))
+		return
+	}
+
+	s.mu.Lock()
+	s.calls = append(s.calls, Call{Method: method, Params: params})
+	s.mu.Unlock()
+
+	switch method {
+	case "getMe":
+		s.writeResult(w, tg_models.User{ID: 123456, IsBot: true, FirstName: "Fake", Username: "fake_bot"})
+	case "sendMessage", "sendPhoto", "sendDocument":
+		s.writeResult(w, s.sendMessage(params))
+	case "editMessageText":
+		msg, ok := s.editMessageText(params)
+
+		if !ok {
+			s.writeError(w, http.StatusBadRequest, "Bad Request: message to edit not found")
+			return
+		}
+
+		s.writeResult(w, msg)
+	case "deleteMessage":
+		s.writeResult(w, s.deleteMessage(params))
+	default:
+		s.writeResult(w, true)
+	}
+}
+
+func (s *Server) getUpdates(r *http.Request, params map[string]string) []*tg_models.Update {
+	offset, _ := strconv.ParseInt(params["offset"], 10, 64)
+	deadline := time.After(pollWait)
+
+	for {
+		s.mu.Lock()
+
+		var result []*tg_models.Update
+
+		for _, update := range s.updates {
+			if update.ID >= offset {
+				result = append(result, update)
+			}
+		}
+
+		notify := s.notify
+		s.mu.Unlock()
+
+		if len(result) > 0 {
+			return result
+		}
+
+		select {
+		case <-notify:
+		case <-deadline:
+			return []*tg_models.Update{}
+		case <-r.Context().Done():
+			return []*tg_models.Update{}
+		}
+	}
+}
+
+func (s *Server) sendMessage(params map[string]string) *tg_models.Message {
+	chatId, _ := strconv.ParseInt(params["chat_id"], 10, 64)
+
+	msg := &tg_models.Message{
+		ID:      s.nextMessageId(),
+		Chat:    tg_models.Chat{ID: chatId, Type: tg_models.ChatTypePrivate},
+		Date:    int(time.Now().Unix()),
+		Text:    params["text"],
+		Caption: params["caption"],
+	}
+
+	if raw, ok := params["reply_markup"]; ok {
+		json.Unmarshal([]byte(raw), &msg.ReplyMarkup)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.messages[chatId] == nil {
+		s.messages[chatId] = make(map[int]*tg_models.Message)
+	}
+
+	s.messages[chatId][msg.ID] = msg
+
+	return msg
+}
+
+func (s *Server) editMessageText(params map[string]string) (*tg_models.Message, bool) {
+	chatId, _ := strconv.ParseInt(params["chat_id"], 10, 64)
+	messageId, _ := strconv.Atoi(params["message_id"])
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	msg, ok := s.messages[chatId][messageId]
+
+	if !ok {
+		return nil, false
+	}
+
+	msg.Text = params["text"]
+	msg.ReplyMarkup = tg_models.InlineKeyboardMarkup{}
+
+	if raw, ok := params["reply_markup"]; ok {
+		json.Unmarshal([]byte(raw), &msg.ReplyMarkup)
+	}
+
+	return msg, true
+}
+
+func (s *Server) deleteMessage(params map[string]string) bool {
+	chatId, _ := strconv.ParseInt(params["chat_id"], 10, 64)
+	messageId, _ := strconv.Atoi(params["message_id"])
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.messages[chatId][messageId]
+	delete(s.messages[chatId], messageId)
+
+	return ok
+}
+
+func (s *Server) writeResult(w http.ResponseWriter, result any) {
+	w.Header().Set("Content-Type", "application/json")
+
+	json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": result})
+}
+
+func (s *Server) writeError(w http.ResponseWriter, code int, description string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	json.NewEncoder(w).Encode(map[string]any{"ok": false, "error_code": code, "description": description})
+}
+
+func privateChat(user tg_models.User) tg_models.Chat {
+	return tg_models.Chat{ID: user.ID, Type: tg_models.ChatTypePrivate, FirstName: user.FirstName, Username: user.Username}
+}
+
+func (c Call) String() string {
+	return fmt.Sprintf("%s %v", c.Method, c.Params)
+}
@@ -1,10 +1,15 @@
 package logger
 
 import (
+	"context"
 	"github.com/sirupsen/logrus"
 	"os"
+	"rezvin-pro-bot/src/constants"
+	utils_context "rezvin-pro-bot/src/utils/context"
 )
 
+type Fields map[string]any
+
 type ILogger interface {
 	Log(message string)
 	Warn(message string)
@@ -13,49 +18,95 @@ type ILogger interface {
 	Debug(message string)
 	Fatal(message string)
 	Panic(message string)
+
+	WithField(key string, value any) ILogger
+	WithFields(fields Fields) ILogger
+	WithError(err error) ILogger
+	// WithContext attaches the request fields stored in ctx by the bot middlewares.
+	WithContext(ctx context.Context) ILogger
 }
 
 type Logger struct {
-	internalLogger *logrus.Logger
+	entry *logrus.Entry
 }
 
-func NewLogger() *Logger {
+func NewLogger(level constants.LogLevel, format constants.LogFormat) *Logger {
 	logger := logrus.New()
 
-	logger.SetFormatter(&logrus.JSONFormatter{})
+	if format == constants.TextLogFormat {
+		logger.SetFormatter(&logrus.TextFormatter{FullTimestamp: true})
+	} else {
+		logger.SetFormatter(&logrus.JSONFormatter{})
+	}
+
 	logger.SetOutput(os.Stdout)
 
-	logger.SetLevel(logrus.DebugLevel)
+	logger.SetLevel(parseLevel(level))
 
 	return &Logger{
-		internalLogger: logger,
+		entry: logrus.NewEntry(logger),
+	}
+}
+
+func parseLevel(level constants.LogLevel) logrus.Level {
+	switch level {
+	case constants.InfoLogLevel:
+		return logrus.InfoLevel
+	case constants.WarnLogLevel:
+		return logrus.WarnLevel
+	case constants.ErrorLogLevel:
+		return logrus.ErrorLevel
+	default:
+		return logrus.DebugLevel
 	}
 }
 
 func (l *Logger) Log(message string) {
-	l.internalLogger.Info(message)
+	l.entry.Info(message)
 }
 
 func (l *Logger) Warn(message string) {
-	l.internalLogger.Warn(message)
+	l.entry.Warn(message)
 }
 
 func (l *Logger) Error(message string) {
-	l.internalLogger.Error(message)
+	l.entry.Error(message)
 }
 
 func (l *Logger) Trace(message string) {
-	l.internalLogger.Trace(message)
+	l.entry.Trace(message)
 }
 
 func (l *Logger) Debug(message string) {
-	l.internalLogger.Debug(message)
+	l.entry.Debug(message)
 }
 
 func (l *Logger) Fatal(message string) {
-	l.internalLogger.Fatal(message)
+	l.entry.Fatal(message)
 }
 
 func (l *Logger) Panic(message string) {
-	l.internalLogger.Panic(message)
+	l.entry.Panic(message)
+}
+
+func (l *Logger) WithField(key string, value any) ILogger {
+	return &Logger{entry: l.entry.WithField(key, value)}
+}
+
+func (l *Logger) WithFields(fields Fields) ILogger {
+	return &Logger{entry: l.entry.WithFields(logrus.Fields(fields))}
+}
+
+func (l *Logger) WithError(err error) ILogger {
+	return &Logger{entry: l.entry.WithError(err)}
+}
+
+func (l *Logger) WithContext(ctx context.Context) ILogger {
+	fields := utils_context.GetLogFieldsFromContext(ctx)
+
+	if len(fields) == 0 {
+		return l
+	}
+
+	return l.WithFields(fields)
 }
@@ -0,0 +1,138 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	"time"
+)
+
+const namespace = "rezvin_pro_bot"
+
+type IMetrics interface {
+	Handler() http.Handler
+	ObserveUpdate(updateType, prefix string, duration time.Duration)
+	IncTimeouts()
+	IncPanics()
+	IncDroppedUpdates()
+	ObserveTelegramCall(method, outcome string)
+	ObserveQuery(operation, table string, duration time.Duration)
+	RegisterActiveConversations(count func() float64)
+}
+
+type metrics struct {
+	registry *prometheus.Registry
+
+	updates          *prometheus.CounterVec
+	handlerDuration  *prometheus.HistogramVec
+	timeouts         prometheus.Counter
+	panics           prometheus.Counter
+	droppedUpdates   prometheus.Counter
+	telegramCalls    *prometheus.CounterVec
+	queryDuration    *prometheus.HistogramVec
+	conversationsSet bool
+}
+
+// NewMetrics uses its own registry instead of the global one, so several containers
+// in one process (tests) never collide on registration.
+func NewMetrics() *metrics {
+	m := &metrics{
+		registry: prometheus.NewRegistry(),
+		updates: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "updates_total",
+			Help:      "Telegram updates received, by update type and callback prefix.",
+		}, []string{"type", "prefix"}),
+		handlerDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "handler_duration_seconds",
+			Help:      "Time spent handling an update, by update type and callback prefix.",
+			Buckets:   []float64{.01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+		}, []string{"type", "prefix"}),
+		timeouts: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "handler_timeouts_total",
+			Help:      "Updates whose handling exceeded the request timeout.",
+		}),
+		panics: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "handler_panics_total",
+			Help:      "Panics recovered while handling updates.",
+		}),
+		droppedUpdates: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "dropped_updates_total",
+			Help:      "Updates dropped because the same chat was already being handled.",
+		}),
+		telegramCalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "telegram_api_calls_total",
+			Help:      "Telegram Bot API call attempts, by method and outcome.",
+		}, []string{"method", "outcome"}),
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "db_query_duration_seconds",
+			Help:      "Duration of database queries issued through GORM, by operation and table.",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"operation", "table"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.updates,
+		m.handlerDuration,
+		m.timeouts,
+		m.panics,
+		m.droppedUpdates,
+		m.telegramCalls,
+		m.queryDuration,
+	)
+
+	return m
+}
+
+func (m *metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+func (m *metrics) ObserveUpdate(updateType, prefix string, duration time.Duration) {
+	m.updates.WithLabelValues(updateType, prefix).Inc()
+	m.handlerDuration.WithLabelValues(updateType, prefix).Observe(duration.Seconds())
+}
+
+func (m *metrics) IncTimeouts() {
+	m.timeouts.Inc()
+}
+
+func (m *metrics) IncPanics() {
+	m.panics.Inc()
+}
+
+func (m *metrics) IncDroppedUpdates() {
+	m.droppedUpdates.Inc()
+}
+
+func (m *metrics) ObserveTelegramCall(method, outcome string) {
+	m.telegramCalls.WithLabelValues(method, outcome).Inc()
+}
+
+func (m *metrics) ObserveQuery(operation, table string, duration time.Duration) {
+	m.queryDuration.WithLabelValues(operation, table).Observe(duration.Seconds())
+}
+
+// RegisterActiveConversations exposes a gauge that is computed by count on every scrape.
+func (m *metrics) RegisterActiveConversations(count func() float64) {
+	if m.conversationsSet {
+		return
+	}
+
+	m.conversationsSet = true
+
+	m.registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "active_conversations",
+		Help:      "Conversations that are waiting for the user's answer.",
+	}, count))
+}
@@ -0,0 +1,297 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"go.uber.org/dig"
+	"hash/fnv"
+	"rezvin-pro-bot/src/globals"
+	"rezvin-pro-bot/src/internal/db"
+	"rezvin-pro-bot/src/internal/logger"
+	"sort"
+	"strings"
+	"time"
+)
+
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+	// Missing marks a version recorded in schema_migrations that this build does not know about.
+	Missing bool
+}
+
+type IMigrator interface {
+	Up(ctx context.Context) ([]Migration, error)
+	Down(ctx context.Context) (*Migration, error)
+	Status(ctx context.Context) ([]MigrationStatus, error)
+}
+
+type migratorDependencies struct {
+	dig.In
+
+	Logger   logger.ILogger `name:"Logger"`
+	Database db.IDatabase   `name:"Database"`
+}
+
+type migrator struct {
+	logger   logger.ILogger
+	database db.IDatabase
+}
+
+func NewMigrator(deps migratorDependencies) *migrator {
+	return &migrator{
+		logger:   deps.Logger,
+		database: deps.Database,
+	}
+}
+
+// Up applies every pending migration in version order, each in its own transaction.
+func (m *migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+
+	err := m.withLock(ctx, func(conn *sql.Conn, schema string) error {
+		migrations, err := load()
+
+		if err != nil {
+			return err
+		}
+
+		done, err := appliedVersions(ctx, conn, schema)
+
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+
+			m.logger.Log(fmt.Sprintf("Applying migration %d_%s", migration.Version, migration.Name))
+
+			err = execInSchema(ctx, conn, schema, migration.Up,
+				"INSERT INTO schema_migrations (version, name, applied_at) VALUES ($1, $2, now())",
+				migration.Version, migration.Name,
+			)
+
+			if err != nil {
+				return fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+
+			applied = append(applied, migration)
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down reverts the most recently applied migration and returns it, or nil if nothing is applied.
+func (m *migrator) Down(ctx context.Context) (*Migration, error) {
+	var reverted *Migration
+
+	err := m.withLock(ctx, func(conn *sql.Conn, schema string) error {
+		migrations, err := load()
+
+		if err != nil {
+			return err
+		}
+
+		var version int64
+
+		err = conn.QueryRowContext(ctx, fmt.Sprintf(
+			"SELECT version FROM %s.schema_migrations ORDER BY version DESC LIMIT 1",
+			quoteIdentifier(schema),
+		)).Scan(&version)
+
+		if err == sql.ErrNoRows {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range migrations {
+			if migration.Version != version {
+				continue
+			}
+
+			m.logger.Log(fmt.Sprintf("Reverting migration %d_%s", migration.Version, migration.Name))
+
+			err = execInSchema(ctx, conn, schema, migration.Down,
+				"DELETE FROM schema_migrations WHERE version = $1",
+				migration.Version,
+			)
+
+			if err != nil {
+				return fmt.Errorf("reverting migration %d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+
+			reverted = &migration
+
+			return nil
+		}
+
+		return fmt.Errorf("applied migration %d is not known to this build", version)
+	})
+
+	return reverted, err
+}
+
+func (m *migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+
+	err := m.withLock(ctx, func(conn *sql.Conn, schema string) error {
+		migrations, err := load()
+
+		if err != nil {
+			return err
+		}
+
+		done, err := appliedVersions(ctx, conn, schema)
+
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range migrations {
+			status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+
+			if record, ok := done[migration.Version]; ok {
+				status.AppliedAt = record.AppliedAt
+				delete(done, migration.Version)
+			}
+
+			statuses = append(statuses, status)
+		}
+
+		for _, record := range done {
+			record.Missing = true
+			statuses = append(statuses, record)
+		}
+
+		sort.Slice(statuses, func(i, j int) bool {
+			return statuses[i].Version < statuses[j].Version
+		})
+
+		return nil
+	})
+
+	return statuses, err
+}
+
+// withLock runs fn on a dedicated connection holding a session-level advisory lock,
+// so that replicas starting at the same time never migrate concurrently.
+func (m *migrator) withLock(ctx context.Context, fn func(conn *sql.Conn, schema string) error) error {
+	sqlDB, err := m.database.GetInstance().DB()
+
+	if err != nil {
+		return err
+	}
+
+	conn, err := sqlDB.Conn(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	schema := globals.GetPostgresSchema()
+	lockId := advisoryLockId("schema_migrations:" + schema)
+
+	if _, err = conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockId); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockId); err != nil {
+			m.logger.Error(fmt.Sprintf("Failed to release migration lock: %s", err))
+		}
+	}()
+
+	_, err = conn.ExecContext(ctx, fmt.Sprintf(
+		`CREATE SCHEMA IF NOT EXISTS %[1]s;
+		CREATE TABLE IF NOT EXISTS %[1]s.schema_migrations (
+			version    bigint PRIMARY KEY,
+			name       text NOT NULL,
+			applied_at timestamptz NOT NULL
+		)`,
+		quoteIdentifier(schema),
+	))
+
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	return fn(conn, schema)
+}
+
+func appliedVersions(ctx context.Context, conn *sql.Conn, schema string) (map[int64]MigrationStatus, error) {
+	rows, err := conn.QueryContext(ctx, fmt.Sprintf(
+		"SELECT version, name, applied_at FROM %s.schema_migrations",
+		quoteIdentifier(schema),
+	))
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make(map[int64]MigrationStatus)
+
+	for rows.Next() {
+		var status MigrationStatus
+		var appliedAt time.Time
+
+		if err = rows.Scan(&status.Version, &status.Name, &appliedAt); err != nil {
+			return nil, err
+		}
+
+		status.AppliedAt = &appliedAt
+		result[status.Version] = status
+	}
+
+	return result, rows.Err()
+}
+
+// execInSchema runs the migration body and the bookkeeping statement in one transaction
+// with search_path pointed at the configured schema, so migration files use bare table names.
+func execInSchema(ctx context.Context, conn *sql.Conn, schema, body, bookkeeping string, args ...any) error {
+	tx, err := conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(ctx, fmt.Sprintf("SET LOCAL search_path TO %s", quoteIdentifier(schema))); err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, body); err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, bookkeeping, args...); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func quoteIdentifier(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
+
+func advisoryLockId(key string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	return int64(h.Sum64())
+}
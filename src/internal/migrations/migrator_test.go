@@ -0,0 +1,285 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gorm_logger "gorm.io/gorm/logger"
+	"os"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/globals"
+	"rezvin-pro-bot/src/internal/logger"
+	"rezvin-pro-bot/src/models"
+	"testing"
+	"time"
+)
+
+// The baseline models are copied from the last release that created the schema with AutoMigrate.
+
+type baselineUser struct {
+	Id         int64  `gorm:"primaryKey"`
+	FirstName  string `gorm:"size:100;not null"`
+	LastName   string `gorm:"size:100"`
+	Username   string `gorm:"size:100"`
+	ChatId     int64  `gorm:"not null"`
+	IsAdmin    bool   `gorm:"default:false"`
+	IsApproved bool   `gorm:"default:false"`
+	IsDeclined bool   `gorm:"default:false"`
+	CreatedAt  time.Time
+}
+
+func (baselineUser) TableName() string {
+	return fmt.Sprintf("%s.users", globals.GetPostgresSchema())
+}
+
+type baselineProgram struct {
+	Id        uint               `gorm:"primaryKey;autoIncrement"`
+	Name      string             `gorm:"size:100;not null;unique"`
+	Exercises []baselineExercise `gorm:"foreignKey:ProgramId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (baselineProgram) TableName() string {
+	return fmt.Sprintf("%s.programs", globals.GetPostgresSchema())
+}
+
+type baselineExercise struct {
+	Id        uint   `gorm:"primaryKey;autoIncrement"`
+	Name      string `gorm:"index:idx_exercise,unique;size:100;not null"`
+	ProgramId uint   `gorm:"not null;index:idx_exercise,unique;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (baselineExercise) TableName() string {
+	return fmt.Sprintf("%s.exercises", globals.GetPostgresSchema())
+}
+
+type baselineMeasure struct {
+	Id        uint   `gorm:"primaryKey;autoIncrement"`
+	Name      string `gorm:"size:100;not null;unique"`
+	Units     string `gorm:"size:100;not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (baselineMeasure) TableName() string {
+	return fmt.Sprintf("%s.measures", globals.GetPostgresSchema())
+}
+
+type baselineUserProgram struct {
+	Id        uint            `gorm:"primaryKey;autoIncrement"`
+	UserId    int64           `gorm:"index:idx_user_program,unique;not null"`
+	ProgramId uint            `gorm:"index:idx_user_program,unique;not null"`
+	User      baselineUser    `gorm:"foreignKey:UserId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Program   baselineProgram `gorm:"foreignKey:ProgramId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	CreatedAt time.Time
+}
+
+func (baselineUserProgram) TableName() string {
+	return fmt.Sprintf("%s.user_programs", globals.GetPostgresSchema())
+}
+
+type baselineUserResult struct {
+	Id            uint             `gorm:"primaryKey;autoIncrement"`
+	UserProgramId uint             `gorm:"index:idx_record,unique;not null"`
+	ExerciseId    uint             `gorm:"index:idx_record,unique;not null"`
+	Reps          uint             `gorm:"index:idx_record,unique;not null"`
+	Weight        int              `gorm:"not null"`
+	Exercise      baselineExercise `gorm:"foreignKey:ExerciseId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	LoggedAt      time.Time
+}
+
+func (baselineUserResult) TableName() string {
+	return fmt.Sprintf("%s.user_results", globals.GetPostgresSchema())
+}
+
+type baselineUserMeasure struct {
+	Id        uint            `gorm:"primaryKey;autoIncrement"`
+	UserId    int64           `gorm:"index:idx_user_measure_user_id;not null"`
+	MeasureId uint            `gorm:"index:idx_user_measure_measure_id;not null"`
+	Value     float64         `gorm:"not null"`
+	User      baselineUser    `gorm:"foreignKey:UserId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Measure   baselineMeasure `gorm:"foreignKey:MeasureId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	CreatedAt time.Time
+}
+
+func (baselineUserMeasure) TableName() string {
+	return fmt.Sprintf("%s.user_measures", globals.GetPostgresSchema())
+}
+
+type baselineLastUserMessage struct {
+	MessageId int   `gorm:"index:idx_last_user_message,unique;not null"`
+	ChatId    int64 `gorm:"primaryKey;autoIncrement=false"`
+	CreatedAt time.Time
+	UpdateAt  time.Time
+}
+
+func (baselineLastUserMessage) TableName() string {
+	return fmt.Sprintf("%s.last_user_messages", globals.GetPostgresSchema())
+}
+
+type testDatabase struct {
+	instance *gorm.DB
+}
+
+func (d *testDatabase) GetInstance() *gorm.DB {
+	return d.instance
+}
+
+func (d *testDatabase) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (d *testDatabase) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+func TestUpAdoptsBaselineSchema(t *testing.T) {
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN is not set")
+	}
+
+	conn, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: gorm_logger.Default.LogMode(gorm_logger.Silent)})
+
+	if err != nil {
+		t.Fatalf("failed to connect to test database: %s", err)
+	}
+
+	schema := fmt.Sprintf("migrations_%d", time.Now().UnixNano())
+
+	if err = conn.Exec(fmt.Sprintf(`CREATE SCHEMA "%s"`, schema)).Error; err != nil {
+		t.Fatalf("failed to create test schema: %s", err)
+	}
+
+	t.Cleanup(func() {
+		conn.Exec(fmt.Sprintf(`DROP SCHEMA "%s" CASCADE`, schema))
+
+		if sqlDb, err := conn.DB(); err == nil {
+			sqlDb.Close()
+		}
+	})
+
+	t.Setenv("POSTGRES_SCHEMA", schema)
+
+	err = conn.AutoMigrate(
+		&baselineUser{},
+		&baselineProgram{},
+		&baselineExercise{},
+		&baselineMeasure{},
+		&baselineUserProgram{},
+		&baselineUserResult{},
+		&baselineUserMeasure{},
+		&baselineLastUserMessage{},
+	)
+
+	if err != nil {
+		t.Fatalf("failed to create baseline schema: %s", err)
+	}
+
+	user := baselineUser{Id: 1, FirstName: "Client", ChatId: 1}
+	program := baselineProgram{Name: "Base"}
+
+	if err = conn.Create(&user).Error; err != nil {
+		t.Fatalf("failed to seed user: %s", err)
+	}
+
+	if err = conn.Create(&program).Error; err != nil {
+		t.Fatalf("failed to seed program: %s", err)
+	}
+
+	exercise := baselineExercise{Name: "Squat", ProgramId: program.Id}
+
+	if err = conn.Create(&exercise).Error; err != nil {
+		t.Fatalf("failed to seed exercise: %s", err)
+	}
+
+	userProgram := baselineUserProgram{UserId: user.Id, ProgramId: program.Id}
+
+	if err = conn.Omit("User", "Program").Create(&userProgram).Error; err != nil {
+		t.Fatalf("failed to seed user program: %s", err)
+	}
+
+	result := baselineUserResult{UserProgramId: userProgram.Id, ExerciseId: exercise.Id, Reps: 6, Weight: 100}
+
+	if err = conn.Omit("Exercise").Create(&result).Error; err != nil {
+		t.Fatalf("failed to seed user result: %s", err)
+	}
+
+	m := NewMigrator(migratorDependencies{
+		Logger:   logger.NewLogger(constants.ErrorLogLevel, constants.TextLogFormat),
+		Database: &testDatabase{instance: conn},
+	})
+
+	ctx := context.Background()
+
+	applied, err := m.Up(ctx)
+
+	if err != nil {
+		t.Fatalf("failed to migrate baseline schema: %s", err)
+	}
+
+	migrations, err := load()
+
+	if err != nil {
+		t.Fatalf("failed to load migrations: %s", err)
+	}
+
+	if len(applied) != len(migrations) {
+		t.Errorf("applied %d migrations, want %d", len(applied), len(migrations))
+	}
+
+	var migratedUser models.User
+
+	if err = conn.First(&migratedUser, user.Id).Error; err != nil {
+		t.Fatalf("failed to load user: %s", err)
+	}
+
+	if migratedUser.WeightUnit != constants.DefaultWeightUnit {
+		t.Errorf("got weight unit %q, want %q", migratedUser.WeightUnit, constants.DefaultWeightUnit)
+	}
+
+	var migratedProgram models.Program
+
+	if err = conn.Preload("Exercises").First(&migratedProgram, program.Id).Error; err != nil {
+		t.Fatalf("failed to load program: %s", err)
+	}
+
+	if migratedProgram.RepScheme != "6,8,10,12" || migratedProgram.OneRepMaxFormula != constants.DefaultOneRepMaxFormula {
+		t.Errorf("got rep scheme %q and formula %q, want column defaults", migratedProgram.RepScheme, migratedProgram.OneRepMaxFormula)
+	}
+
+	if len(migratedProgram.Exercises) != 1 || migratedProgram.Exercises[0].Position != 1 {
+		t.Errorf("got exercises %+v, want one exercise at position 1", migratedProgram.Exercises)
+	}
+
+	var migratedResult models.UserResult
+
+	if err = conn.First(&migratedResult, result.Id).Error; err != nil {
+		t.Fatalf("failed to load user result: %s", err)
+	}
+
+	if migratedResult.Weight != 100 {
+		t.Errorf("got weight %v, want 100", migratedResult.Weight)
+	}
+
+	if err = conn.Model(&migratedResult).Update("weight", 62.5).Error; err != nil {
+		t.Fatalf("failed to store a fractional weight: %s", err)
+	}
+
+	if err = conn.First(&migratedResult, result.Id).Error; err != nil {
+		t.Fatalf("failed to load user result: %s", err)
+	}
+
+	if migratedResult.Weight != 62.5 {
+		t.Errorf("got weight %v, want 62.5", migratedResult.Weight)
+	}
+
+	if applied, err = m.Up(ctx); err != nil || len(applied) != 0 {
+		t.Errorf("expected a second run to be a no-op, got %d migrations and %v", len(applied), err)
+	}
+}
@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS callback_states;
+DROP TABLE IF EXISTS rest_timers;
+DROP TABLE IF EXISTS dead_letters;
+DROP TABLE IF EXISTS conversations;
+DROP TABLE IF EXISTS last_user_messages;
+DROP TABLE IF EXISTS workout_sets;
+DROP TABLE IF EXISTS workout_sessions;
+DROP TABLE IF EXISTS user_measures;
+DROP TABLE IF EXISTS user_result_histories;
+DROP TABLE IF EXISTS user_results;
+DROP TABLE IF EXISTS user_programs;
+DROP TABLE IF EXISTS measures;
+DROP TABLE IF EXISTS exercises;
+DROP TABLE IF EXISTS programs;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,193 @@
+-- Baseline matching what AutoMigrate used to create. Every statement is guarded with
+-- IF NOT EXISTS so databases that were migrated by AutoMigrate adopt it as a no-op.
+
+CREATE TABLE IF NOT EXISTS users (
+    id          bigint PRIMARY KEY,
+    first_name  varchar(100) NOT NULL,
+    last_name   varchar(100),
+    username    varchar(100),
+    chat_id     bigint NOT NULL,
+    is_admin    boolean DEFAULT false,
+    is_approved boolean DEFAULT false,
+    is_declined boolean DEFAULT false,
+    weight_unit varchar(2) NOT NULL DEFAULT 'kg',
+    created_at  timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS programs (
+    id                  bigserial PRIMARY KEY,
+    name                varchar(100) NOT NULL CONSTRAINT uni_programs_name UNIQUE,
+    one_rep_max_formula varchar(20) NOT NULL DEFAULT 'best',
+    rep_scheme          varchar(100) NOT NULL DEFAULT '6,8,10,12',
+    created_at          timestamptz,
+    updated_at          timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS exercises (
+    id         bigserial PRIMARY KEY,
+    name       varchar(100) NOT NULL,
+    program_id bigint NOT NULL,
+    rep_scheme varchar(100),
+    created_at timestamptz,
+    updated_at timestamptz,
+    CONSTRAINT fk_programs_exercises FOREIGN KEY (program_id)
+        REFERENCES programs (id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_exercise ON exercises (name, program_id);
+
+CREATE TABLE IF NOT EXISTS measures (
+    id         bigserial PRIMARY KEY,
+    name       varchar(100) NOT NULL CONSTRAINT uni_measures_name UNIQUE,
+    units      varchar(100) NOT NULL,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS user_programs (
+    id         bigserial PRIMARY KEY,
+    user_id    bigint NOT NULL,
+    program_id bigint NOT NULL,
+    created_at timestamptz,
+    CONSTRAINT fk_user_programs_user FOREIGN KEY (user_id)
+        REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    CONSTRAINT fk_user_programs_program FOREIGN KEY (program_id)
+        REFERENCES programs (id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_program ON user_programs (user_id, program_id);
+
+CREATE TABLE IF NOT EXISTS user_results (
+    id              bigserial PRIMARY KEY,
+    user_program_id bigint NOT NULL,
+    exercise_id     bigint NOT NULL,
+    reps            bigint NOT NULL,
+    weight          numeric(8,2) NOT NULL,
+    logged_at       timestamptz,
+    CONSTRAINT fk_user_results_exercise FOREIGN KEY (exercise_id)
+        REFERENCES exercises (id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_record ON user_results (user_program_id, exercise_id, reps);
+
+CREATE TABLE IF NOT EXISTS user_result_histories (
+    id              bigserial PRIMARY KEY,
+    user_result_id  bigint NOT NULL,
+    user_id         bigint NOT NULL,
+    user_program_id bigint NOT NULL,
+    exercise_id     bigint NOT NULL,
+    reps            bigint NOT NULL,
+    weight          numeric(8,2) NOT NULL,
+    is_record       boolean DEFAULT false,
+    entered_by      varchar(20) NOT NULL,
+    entered_by_id   bigint NOT NULL,
+    logged_at       timestamptz,
+    CONSTRAINT fk_user_result_histories_user_result FOREIGN KEY (user_result_id)
+        REFERENCES user_results (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    CONSTRAINT fk_user_result_histories_exercise FOREIGN KEY (exercise_id)
+        REFERENCES exercises (id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_result_history_user_result_id ON user_result_histories (user_result_id);
+CREATE INDEX IF NOT EXISTS idx_user_result_history_user_id ON user_result_histories (user_id);
+
+CREATE TABLE IF NOT EXISTS user_measures (
+    id         bigserial PRIMARY KEY,
+    user_id    bigint NOT NULL,
+    measure_id bigint NOT NULL,
+    value      decimal NOT NULL,
+    created_at timestamptz,
+    CONSTRAINT fk_user_measures_user FOREIGN KEY (user_id)
+        REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    CONSTRAINT fk_user_measures_measure FOREIGN KEY (measure_id)
+        REFERENCES measures (id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_measure_user_id ON user_measures (user_id);
+CREATE INDEX IF NOT EXISTS idx_user_measure_measure_id ON user_measures (measure_id);
+
+CREATE TABLE IF NOT EXISTS workout_sessions (
+    id                  bigserial PRIMARY KEY,
+    user_id             bigint NOT NULL,
+    user_program_id     bigint NOT NULL,
+    current_exercise_id bigint,
+    duration_seconds    bigint DEFAULT 0,
+    started_at          timestamptz,
+    finished_at         timestamptz,
+    CONSTRAINT fk_workout_sessions_user FOREIGN KEY (user_id)
+        REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    CONSTRAINT fk_workout_sessions_user_program FOREIGN KEY (user_program_id)
+        REFERENCES user_programs (id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_workout_session_user_id ON workout_sessions (user_id);
+
+CREATE TABLE IF NOT EXISTS workout_sets (
+    id                 bigserial PRIMARY KEY,
+    workout_session_id bigint NOT NULL,
+    exercise_id        bigint NOT NULL,
+    set_number         bigint NOT NULL,
+    reps               bigint NOT NULL,
+    weight             numeric(8,2) NOT NULL,
+    logged_at          timestamptz,
+    CONSTRAINT fk_workout_sessions_sets FOREIGN KEY (workout_session_id)
+        REFERENCES workout_sessions (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    CONSTRAINT fk_workout_sets_exercise FOREIGN KEY (exercise_id)
+        REFERENCES exercises (id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_workout_set_session_id ON workout_sets (workout_session_id);
+
+CREATE TABLE IF NOT EXISTS last_user_messages (
+    chat_id    bigint PRIMARY KEY,
+    message_id bigint NOT NULL,
+    created_at timestamptz,
+    update_at  timestamptz
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_last_user_message ON last_user_messages (message_id);
+
+CREATE TABLE IF NOT EXISTS conversations (
+    chat_id    bigint PRIMARY KEY,
+    state      text NOT NULL,
+    params     text NOT NULL,
+    data       text,
+    step       bigint NOT NULL DEFAULT 0,
+    message_id bigint NOT NULL,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS dead_letters (
+    id         bigserial PRIMARY KEY,
+    chat_id    bigint NOT NULL,
+    method     varchar(100) NOT NULL,
+    payload    text NOT NULL,
+    error      text NOT NULL,
+    attempts   bigint NOT NULL,
+    created_at timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS idx_dead_letter_chat_id ON dead_letters (chat_id);
+
+CREATE TABLE IF NOT EXISTS rest_timers (
+    chat_id            bigint PRIMARY KEY,
+    workout_session_id bigint NOT NULL,
+    seconds            bigint NOT NULL,
+    fire_at            timestamptz NOT NULL,
+    created_at         timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS idx_rest_timer_fire_at ON rest_timers (fire_at);
+
+CREATE TABLE IF NOT EXISTS callback_states (
+    token      varchar(32) PRIMARY KEY,
+    chat_id    bigint NOT NULL,
+    prefix     text NOT NULL,
+    params     text NOT NULL,
+    data       text,
+    expires_at timestamptz NOT NULL,
+    created_at timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS idx_callback_states_expires_at ON callback_states (expires_at);
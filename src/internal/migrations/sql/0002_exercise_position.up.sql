@@ -0,0 +1,10 @@
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS position bigint NOT NULL DEFAULT 0;
+
+-- Keep the order exercises were listed in so far, which was by id.
+UPDATE exercises e
+SET position = ordered.position
+FROM (
+    SELECT id, row_number() OVER (PARTITION BY program_id ORDER BY id) AS position
+    FROM exercises
+) ordered
+WHERE e.id = ordered.id AND e.position = 0;
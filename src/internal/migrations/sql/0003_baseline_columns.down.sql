@@ -0,0 +1,2 @@
+-- The columns are part of 0001_initial_schema on fresh databases, so there is nothing to revert.
+SELECT 1;
@@ -0,0 +1,12 @@
+-- 0001 only creates missing tables, so databases that AutoMigrate created before these
+-- columns existed still lack them. Every statement is a no-op on a fresh database.
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS weight_unit varchar(2) NOT NULL DEFAULT 'kg';
+
+ALTER TABLE programs ADD COLUMN IF NOT EXISTS one_rep_max_formula varchar(20) NOT NULL DEFAULT 'best';
+ALTER TABLE programs ADD COLUMN IF NOT EXISTS rep_scheme varchar(100) NOT NULL DEFAULT '6,8,10,12';
+
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS rep_scheme varchar(100);
+
+-- Weights used to be whole kilograms.
+ALTER TABLE user_results ALTER COLUMN weight TYPE numeric(8,2);
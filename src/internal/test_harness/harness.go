@@ -0,0 +1,302 @@
+package test_harness
+
+import (
+	"context"
+	"fmt"
+	tg_models "github.com/go-telegram/bot/models"
+	"go.uber.org/dig"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"os"
+	"rezvin-pro-bot/src/di"
+	"rezvin-pro-bot/src/di/dependency"
+	"rezvin-pro-bot/src/internal/bot"
+	"rezvin-pro-bot/src/internal/db"
+	"rezvin-pro-bot/src/internal/fake_telegram"
+	"rezvin-pro-bot/src/internal/migrations"
+	"rezvin-pro-bot/src/models"
+	"rezvin-pro-bot/src/repositories"
+	"rezvin-pro-bot/src/services"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	AlertChatId   int64 = -100
+	expectTimeout       = 10 * time.Second
+	expectPoll          = 20 * time.Millisecond
+)
+
+type harnessDependencies struct {
+	dig.In
+
+	LockService          services.ILockService          `name:"LockService"`
+	RestTimerService     services.IRestTimerService     `name:"RestTimerService"`
+	CallbackStateService services.ICallbackStateService `name:"CallbackStateService"`
+	OutboundService      services.IOutboundService      `name:"OutboundService"`
+	AlertService         services.IAlertService         `name:"AlertService"`
+	Bot                  bot.IBot                       `name:"Bot"`
+}
+
+type databaseDependencies struct {
+	dig.In
+
+	Database db.IDatabase         `name:"Database"`
+	Migrator migrations.IMigrator `name:"Migrator"`
+}
+
+type Harness struct {
+	t         testing.TB
+	container *dig.Container
+	Telegram  *fake_telegram.Server
+}
+
+// New starts the bot in polling mode against a fake Bot API server and a throwaway
+// Postgres schema. The test is skipped unless TEST_POSTGRES_DSN is set; ADMIN_NAME must
+// be set as well because it is read when the globals package is initialized.
+func New(t testing.TB) *Harness {
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN is not set")
+	}
+
+	schema := fmt.Sprintf("e2e_%d", time.Now().UnixNano())
+
+	conn, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+
+	if err != nil {
+		t.Fatalf("failed to connect to test database: %s", err)
+	}
+
+	if err = conn.Exec(fmt.Sprintf(`CREATE SCHEMA "%s"`, schema)).Error; err != nil {
+		t.Fatalf("failed to create test schema: %s", err)
+	}
+
+	t.Cleanup(func() {
+		conn.Exec(fmt.Sprintf(`DROP SCHEMA "%s" CASCADE`, schema))
+
+		if sqlDb, err := conn.DB(); err == nil {
+			sqlDb.Close()
+		}
+	})
+
+	telegram := newTelegram(t)
+
+	t.Setenv("POSTGRES_DSN", dsn)
+	t.Setenv("POSTGRES_SCHEMA", schema)
+	t.Setenv("RUN_MIGRATIONS", "true")
+
+	container := di.BuildContainer()
+	container = di.AppendDependenciesToContainer(container, dependency.GetShutdownDependencies(context.Background(), &sync.WaitGroup{}))
+
+	h := &Harness{
+		t:         t,
+		container: container,
+		Telegram:  telegram,
+	}
+
+	h.Invoke(func(deps databaseDependencies) {
+		if _, err := deps.Migrator.Up(context.Background()); err != nil {
+			t.Fatalf("failed to migrate test schema: %s", err)
+		}
+
+		t.Cleanup(func() {
+			shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer shutdownCancel()
+
+			deps.Database.Shutdown(shutdownCtx)
+		})
+	})
+
+	h.start()
+
+	return h
+}
+
+// NewMemory starts the bot like New, but against in-memory repositories, so it never
+// needs a database and is never skipped.
+func NewMemory(t testing.TB) *Harness {
+	telegram := newTelegram(t)
+
+	t.Setenv("POSTGRES_DSN", "unused")
+
+	h := &Harness{
+		t:         t,
+		container: di.BuildMemoryContainer(),
+		Telegram:  telegram,
+	}
+
+	h.start()
+
+	return h
+}
+
+func newTelegram(t testing.TB) *fake_telegram.Server {
+	telegram := fake_telegram.NewServer()
+
+	t.Cleanup(telegram.Close)
+
+	t.Setenv("APP_ENV", "development")
+	t.Setenv("BOT_TOKEN", fake_telegram.Token)
+	t.Setenv("BOT_API_URL", telegram.URL())
+	t.Setenv("LOCK_BACKEND", "memory")
+	t.Setenv("ADMIN_PORT", "127.0.0.1:0")
+	t.Setenv("ALERT_CHAT_ID", strconv.FormatInt(AlertChatId, 10))
+
+	return telegram
+}
+
+func (h *Harness) start() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	h.Invoke(func(deps harnessDependencies) {
+		go deps.Bot.Start(ctx)
+
+		h.t.Cleanup(func() {
+			cancel()
+
+			shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer shutdownCancel()
+
+			deps.CallbackStateService.Shutdown(shutdownCtx)
+			deps.RestTimerService.Shutdown(shutdownCtx)
+			deps.OutboundService.Shutdown(shutdownCtx)
+			deps.AlertService.Shutdown(shutdownCtx)
+			deps.LockService.Shutdown(shutdownCtx)
+		})
+	})
+}
+
+func (h *Harness) Invoke(function any) {
+	h.t.Helper()
+
+	if err := h.container.Invoke(function); err != nil {
+		h.t.Fatalf("failed to invoke container: %s", err)
+	}
+}
+
+func NewUser(id int64, firstName, lastName string) tg_models.User {
+	return tg_models.User{
+		ID:        id,
+		FirstName: firstName,
+		LastName:  lastName,
+		Username:  fmt.Sprintf("user%d", id),
+	}
+}
+
+func (h *Harness) CreateAdmin(user tg_models.User) {
+	h.Invoke(func(deps struct {
+		dig.In
+
+		UserRepository repositories.IUserRepository `name:"UserRepository"`
+	}) {
+		_, err := deps.UserRepository.Create(context.Background(), models.User{
+			Id:         user.ID,
+			ChatId:     user.ID,
+			Username:   user.Username,
+			FirstName:  user.FirstName,
+			LastName:   user.LastName,
+			IsAdmin:    true,
+			IsApproved: true,
+		})
+
+		if err != nil {
+			h.t.Fatalf("failed to create admin %d: %s", user.ID, err)
+		}
+	})
+}
+
+func (h *Harness) Send(user tg_models.User, text string) {
+	h.t.Helper()
+
+	h.waitIdle(user)
+	h.Telegram.InjectMessage(user, text)
+}
+
+// Expect waits until the latest visible message in the user's chat contains text.
+func (h *Harness) Expect(user tg_models.User, text string) tg_models.Message {
+	h.t.Helper()
+
+	return h.waitFor(user, fmt.Sprintf("message containing %q", text), func(message tg_models.Message) bool {
+		return strings.Contains(message.Text, text) || strings.Contains(message.Caption, text)
+	})
+}
+
+// ExpectButton waits until the latest visible message in the user's chat has a button containing text.
+func (h *Harness) ExpectButton(user tg_models.User, text string) tg_models.Message {
+	h.t.Helper()
+
+	return h.waitFor(user, fmt.Sprintf("button containing %q", text), func(message tg_models.Message) bool {
+		_, ok := findButton(message, text)
+		return ok
+	})
+}
+
+func (h *Harness) Click(user tg_models.User, message tg_models.Message, button string) {
+	h.t.Helper()
+
+	btn, ok := findButton(message, button)
+
+	if !ok {
+		h.t.Fatalf("button %q not found in message %d: %v", button, message.ID, message.ReplyMarkup.InlineKeyboard)
+	}
+
+	h.waitIdle(user)
+	h.Telegram.InjectCallback(user, message, btn.CallbackData)
+}
+
+// waitIdle waits until the previous update of the user is handled, the bot drops
+// updates that arrive while the chat is still locked.
+func (h *Harness) waitIdle(user tg_models.User) {
+	h.t.Helper()
+
+	h.Invoke(func(deps harnessDependencies) {
+		ctx, cancel := context.WithTimeout(context.Background(), expectTimeout)
+		defer cancel()
+
+		key := fmt.Sprintf("%d:%d", user.ID, user.ID)
+
+		if err := deps.LockService.Lock(ctx, key); err != nil {
+			h.t.Fatalf("chat %d is still busy: %s", user.ID, err)
+		}
+
+		deps.LockService.Unlock(key)
+	})
+}
+
+func (h *Harness) waitFor(user tg_models.User, description string, match func(message tg_models.Message) bool) tg_models.Message {
+	h.t.Helper()
+
+	deadline := time.Now().Add(expectTimeout)
+
+	for time.Now().Before(deadline) {
+		chatMessages := h.Telegram.Messages(user.ID)
+
+		if len(chatMessages) > 0 && match(chatMessages[len(chatMessages)-1]) {
+			return chatMessages[len(chatMessages)-1]
+		}
+
+		time.Sleep(expectPoll)
+	}
+
+	h.t.Fatalf("expected %s in chat %d, got: %+v", description, user.ID, h.Telegram.Messages(user.ID))
+
+	return tg_models.Message{}
+}
+
+func findButton(message tg_models.Message, text string) (tg_models.InlineKeyboardButton, bool) {
+	for _, row := range message.ReplyMarkup.InlineKeyboard {
+		for _, btn := range row {
+			if strings.Contains(btn.Text, text) {
+				return btn, true
+			}
+		}
+	}
+
+	return tg_models.InlineKeyboardButton{}, false
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"rezvin-pro-bot/src/globals"
+	"rezvin-pro-bot/src/types"
+	"time"
+)
+
+type CallbackState struct {
+	Token     string            `gorm:"primaryKey;size:32" json:"token"`
+	ChatId    int64             `gorm:"not null" json:"chatId"`
+	Prefix    string            `gorm:"not null" json:"prefix"`
+	Params    types.Params      `gorm:"serializer:json;not null" json:"params"`
+	Data      map[string]string `gorm:"serializer:json" json:"data"`
+	ExpiresAt time.Time         `gorm:"index;not null" json:"expiresAt"`
+	CreatedAt time.Time         `json:"createdAt"`
+}
+
+func (c *CallbackState) TableName() string {
+	schema := globals.GetPostgresSchema()
+	return fmt.Sprintf("%s.callback_states", schema)
+}
+
+func (c *CallbackState) BeforeCreate(tx *gorm.DB) (err error) {
+	c.CreatedAt = time.Now()
+	return
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/globals"
+	"rezvin-pro-bot/src/types"
+	"time"
+)
+
+type Conversation struct {
+	ChatId    int64                       `gorm:"primaryKey;autoIncrement=false" json:"chatId"`
+	State     constants.ConversationState `gorm:"not null" json:"state"`
+	Params    types.Params                `gorm:"serializer:json;not null" json:"params"`
+	Data      map[string]string           `gorm:"serializer:json" json:"data"`
+	Step      int                         `gorm:"not null;default:0" json:"step"`
+	MessageId int                         `gorm:"not null" json:"messageId"`
+	CreatedAt time.Time                   `json:"createdAt"`
+	UpdatedAt time.Time                   `json:"updatedAt"`
+}
+
+func (c *Conversation) TableName() string {
+	schema := globals.GetPostgresSchema()
+	return fmt.Sprintf("%s.conversations", schema)
+}
+
+func (c *Conversation) BeforeCreate(tx *gorm.DB) (err error) {
+	c.CreatedAt = time.Now()
+	return
+}
+
+func (c *Conversation) GetData(key string) string {
+	if c.Data == nil {
+		return ""
+	}
+
+	return c.Data[key]
+}
+
+func (c *Conversation) SetData(key, value string) {
+	if c.Data == nil {
+		c.Data = make(map[string]string)
+	}
+
+	c.Data[key] = value
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"rezvin-pro-bot/src/globals"
+	"time"
+)
+
+type DeadLetter struct {
+	Id        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	ChatId    int64     `gorm:"index:idx_dead_letter_chat_id;not null" json:"chatId"`
+	Method    string    `gorm:"size:100;not null" json:"method"`
+	Payload   string    `gorm:"type:text;not null" json:"payload"`
+	Error     string    `gorm:"type:text;not null" json:"error"`
+	Attempts  int       `gorm:"not null" json:"attempts"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (d *DeadLetter) TableName() string {
+	schema := globals.GetPostgresSchema()
+	return fmt.Sprintf("%s.dead_letters", schema)
+}
+
+func (d *DeadLetter) BeforeCreate(tx *gorm.DB) (err error) {
+	d.CreatedAt = time.Now()
+	return
+}
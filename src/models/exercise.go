@@ -11,6 +11,8 @@ type Exercise struct {
 	Id        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
 	Name      string    `gorm:"index:idx_exercise,unique;size:100;not null" json:"name"`
 	ProgramId uint      `gorm:"not null;index:idx_exercise,unique;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"programId"`
+	RepScheme string    `gorm:"size:100" json:"repScheme"`
+	Position  uint      `gorm:"not null;default:0" json:"position"`
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
 }
@@ -3,16 +3,19 @@ package models
 import (
 	"fmt"
 	"gorm.io/gorm"
+	"rezvin-pro-bot/src/constants"
 	"rezvin-pro-bot/src/globals"
 	"time"
 )
 
 type Program struct {
-	Id        uint       `gorm:"primaryKey;autoIncrement" json:"id" `
-	Name      string     `gorm:"size:100;not null;unique" json:"name"`
-	Exercises []Exercise `gorm:"foreignKey:ProgramId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"exercises"`
-	CreatedAt time.Time  `json:"createdAt"`
-	UpdatedAt time.Time  `json:"updatedAt"`
+	Id               uint                       `gorm:"primaryKey;autoIncrement" json:"id" `
+	Name             string                     `gorm:"size:100;not null;unique" json:"name"`
+	OneRepMaxFormula constants.OneRepMaxFormula `gorm:"size:20;not null;default:best" json:"oneRepMaxFormula"`
+	RepScheme        string                     `gorm:"size:100;not null;default:6,8,10,12" json:"repScheme"`
+	Exercises        []Exercise                 `gorm:"foreignKey:ProgramId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"exercises"`
+	CreatedAt        time.Time                  `json:"createdAt"`
+	UpdatedAt        time.Time                  `json:"updatedAt"`
 }
 
 func (c *Program) TableName() string {
@@ -20,6 +23,14 @@ func (c *Program) TableName() string {
 	return fmt.Sprintf("%s.programs", schema)
 }
 
+func (c *Program) GetOneRepMaxFormula() constants.OneRepMaxFormula {
+	if c.OneRepMaxFormula == "" {
+		return constants.DefaultOneRepMaxFormula
+	}
+
+	return c.OneRepMaxFormula
+}
+
 func (c *Program) BeforeCreate(tx *gorm.DB) (err error) {
 	c.CreatedAt = time.Now()
 	c.UpdatedAt = time.Now()
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"rezvin-pro-bot/src/globals"
+	"time"
+)
+
+type RestTimer struct {
+	ChatId           int64     `gorm:"primaryKey;autoIncrement=false" json:"chatId"`
+	WorkoutSessionId uint      `gorm:"not null" json:"workoutSessionId"`
+	Seconds          uint      `gorm:"not null" json:"seconds"`
+	FireAt           time.Time `gorm:"index:idx_rest_timer_fire_at;not null" json:"fireAt"`
+	CreatedAt        time.Time `json:"createdAt"`
+}
+
+func (r *RestTimer) TableName() string {
+	schema := globals.GetPostgresSchema()
+	return fmt.Sprintf("%s.rest_timers", schema)
+}
+
+func (r *RestTimer) BeforeCreate(tx *gorm.DB) (err error) {
+	r.CreatedAt = time.Now()
+	return
+}
@@ -3,20 +3,22 @@ package models
 import (
 	"fmt"
 	"gorm.io/gorm"
+	"rezvin-pro-bot/src/constants"
 	"rezvin-pro-bot/src/globals"
 	"time"
 )
 
 type User struct {
-	Id         int64     `gorm:"primaryKey" json:"id"`
-	FirstName  string    `gorm:"size:100;not null" json:"firstName"`
-	LastName   string    `gorm:"size:100" json:"lastName"`
-	Username   string    `gorm:"size:100" json:"username"`
-	ChatId     int64     `gorm:"not null" json:"chatId"`
-	IsAdmin    bool      `gorm:"default:false" json:"isAdmin"`
-	IsApproved bool      `gorm:"default:false" json:"isApproved"`
-	IsDeclined bool      `gorm:"default:false" json:"isDeclined"`
-	CreatedAt  time.Time `json:"createdAt"`
+	Id         int64                `gorm:"primaryKey" json:"id"`
+	FirstName  string               `gorm:"size:100;not null" json:"firstName"`
+	LastName   string               `gorm:"size:100" json:"lastName"`
+	Username   string               `gorm:"size:100" json:"username"`
+	ChatId     int64                `gorm:"not null" json:"chatId"`
+	IsAdmin    bool                 `gorm:"default:false" json:"isAdmin"`
+	IsApproved bool                 `gorm:"default:false" json:"isApproved"`
+	IsDeclined bool                 `gorm:"default:false" json:"isDeclined"`
+	WeightUnit constants.WeightUnit `gorm:"size:2;not null;default:kg" json:"weightUnit"`
+	CreatedAt  time.Time            `json:"createdAt"`
 }
 
 func (u *User) TableName() string {
@@ -24,6 +26,14 @@ func (u *User) TableName() string {
 	return fmt.Sprintf("%s.users", schema)
 }
 
+func (u *User) GetWeightUnit() constants.WeightUnit {
+	if u.WeightUnit == "" {
+		return constants.DefaultWeightUnit
+	}
+
+	return u.WeightUnit
+}
+
 func (u *User) BeforeCreate(tx *gorm.DB) (err error) {
 	u.CreatedAt = time.Now()
 	return
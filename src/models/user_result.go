@@ -12,7 +12,7 @@ type UserResult struct {
 	UserProgramId uint      `gorm:"index:idx_record,unique;not null" json:"userProgramId"`
 	ExerciseId    uint      `gorm:"index:idx_record,unique;not null" json:"exerciseId"`
 	Reps          uint      `gorm:"index:idx_record,unique;not null" json:"reps"`
-	Weight        int       `gorm:"not null" json:"weight"`
+	Weight        float64   `gorm:"type:numeric(8,2);not null" json:"weight"`
 	Exercise      Exercise  `gorm:"foreignKey:ExerciseId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"exercise"`
 	LoggedAt      time.Time `json:"loggedAt"`
 }
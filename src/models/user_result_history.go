@@ -0,0 +1,43 @@
+package models
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/globals"
+	"time"
+)
+
+type UserResultHistory struct {
+	Id            uint                   `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserResultId  uint                   `gorm:"index:idx_user_result_history_user_result_id;not null" json:"userResultId"`
+	UserId        int64                  `gorm:"index:idx_user_result_history_user_id;not null" json:"userId"`
+	UserProgramId uint                   `gorm:"not null" json:"userProgramId"`
+	ExerciseId    uint                   `gorm:"not null" json:"exerciseId"`
+	Reps          uint                   `gorm:"not null" json:"reps"`
+	Weight        float64                `gorm:"type:numeric(8,2);not null" json:"weight"`
+	IsRecord      bool                   `gorm:"default:false" json:"isRecord"`
+	EnteredBy     constants.ResultSource `gorm:"size:20;not null" json:"enteredBy"`
+	EnteredById   int64                  `gorm:"not null" json:"enteredById"`
+	UserResult    UserResult             `gorm:"foreignKey:UserResultId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"userResult"`
+	Exercise      Exercise               `gorm:"foreignKey:ExerciseId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"exercise"`
+	LoggedAt      time.Time              `json:"loggedAt"`
+}
+
+func (u *UserResultHistory) Name() string {
+	return u.Exercise.Name
+}
+
+func (u *UserResultHistory) IsEnteredByTrainer() bool {
+	return u.EnteredBy == constants.TrainerResultSource
+}
+
+func (u *UserResultHistory) TableName() string {
+	schema := globals.GetPostgresSchema()
+	return fmt.Sprintf("%s.user_result_histories", schema)
+}
+
+func (u *UserResultHistory) BeforeCreate(tx *gorm.DB) (err error) {
+	u.LoggedAt = time.Now()
+	return
+}
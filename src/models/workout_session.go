@@ -0,0 +1,43 @@
+package models
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"rezvin-pro-bot/src/globals"
+	"time"
+)
+
+type WorkoutSession struct {
+	Id                uint         `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserId            int64        `gorm:"index:idx_workout_session_user_id;not null" json:"userId"`
+	UserProgramId     uint         `gorm:"not null" json:"userProgramId"`
+	CurrentExerciseId uint         `json:"currentExerciseId"`
+	DurationSeconds   int64        `gorm:"default:0" json:"durationSeconds"`
+	User              User         `gorm:"foreignKey:UserId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"user"`
+	UserProgram       UserProgram  `gorm:"foreignKey:UserProgramId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"userProgram"`
+	Sets              []WorkoutSet `gorm:"foreignKey:WorkoutSessionId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"sets"`
+	StartedAt         time.Time    `json:"startedAt"`
+	FinishedAt        *time.Time   `json:"finishedAt"`
+}
+
+func (w *WorkoutSession) Name() string {
+	return w.UserProgram.Name()
+}
+
+func (w *WorkoutSession) IsFinished() bool {
+	return w.FinishedAt != nil
+}
+
+func (w *WorkoutSession) Duration() time.Duration {
+	return time.Duration(w.DurationSeconds) * time.Second
+}
+
+func (w *WorkoutSession) TableName() string {
+	schema := globals.GetPostgresSchema()
+	return fmt.Sprintf("%s.workout_sessions", schema)
+}
+
+func (w *WorkoutSession) BeforeCreate(tx *gorm.DB) (err error) {
+	w.StartedAt = time.Now()
+	return
+}
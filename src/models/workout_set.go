@@ -0,0 +1,33 @@
+package models
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"rezvin-pro-bot/src/globals"
+	"time"
+)
+
+type WorkoutSet struct {
+	Id               uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	WorkoutSessionId uint      `gorm:"index:idx_workout_set_session_id;not null" json:"workoutSessionId"`
+	ExerciseId       uint      `gorm:"not null" json:"exerciseId"`
+	SetNumber        uint      `gorm:"not null" json:"setNumber"`
+	Reps             uint      `gorm:"not null" json:"reps"`
+	Weight           float64   `gorm:"type:numeric(8,2);not null" json:"weight"`
+	Exercise         Exercise  `gorm:"foreignKey:ExerciseId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"exercise"`
+	LoggedAt         time.Time `json:"loggedAt"`
+}
+
+func (w *WorkoutSet) Name() string {
+	return w.Exercise.Name
+}
+
+func (w *WorkoutSet) TableName() string {
+	schema := globals.GetPostgresSchema()
+	return fmt.Sprintf("%s.workout_sets", schema)
+}
+
+func (w *WorkoutSet) BeforeCreate(tx *gorm.DB) (err error) {
+	w.LoggedAt = time.Now()
+	return
+}
@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"context"
+	"go.uber.org/dig"
+	"gorm.io/gorm"
+	"rezvin-pro-bot/src/internal/db"
+	"rezvin-pro-bot/src/models"
+	"time"
+)
+
+type ICallbackStateRepository interface {
+	Create(ctx context.Context, state models.CallbackState) error
+	GetByToken(ctx context.Context, token string) (*models.CallbackState, error)
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+type callbackStateRepositoryDependencies struct {
+	dig.In
+
+	Database db.IDatabase `name:"Database"`
+}
+
+type callbackStateRepository struct {
+	db *gorm.DB
+}
+
+func NewCallbackStateRepository(deps callbackStateRepositoryDependencies) *callbackStateRepository {
+	return &callbackStateRepository{
+		db: deps.Database.GetInstance(),
+	}
+}
+
+func (r *callbackStateRepository) Create(ctx context.Context, state models.CallbackState) error {
+	return executeOnce(ctx, func() error {
+		return r.db.WithContext(ctx).Create(&state).Error
+	})
+}
+
+func (r *callbackStateRepository) GetByToken(ctx context.Context, token string) (*models.CallbackState, error) {
+	var state models.CallbackState
+
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).Where("token = ? AND expires_at > ?", token, time.Now()).First(&state).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &state, nil
+}
+
+func (r *callbackStateRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	var deleted int64
+
+	err := execute(ctx, func() error {
+		result := r.db.WithContext(ctx).Where("expires_at <= ?", time.Now()).Delete(&models.CallbackState{})
+		deleted = result.RowsAffected
+
+		return result.Error
+	})
+
+	return deleted, err
+}
@@ -0,0 +1,83 @@
+package repositories
+
+import (
+	"context"
+	"go.uber.org/dig"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"rezvin-pro-bot/src/internal/db"
+	"rezvin-pro-bot/src/models"
+)
+
+type IConversationRepository interface {
+	Save(ctx context.Context, conversation models.Conversation) error
+	GetByChatId(ctx context.Context, chatId int64) (*models.Conversation, error)
+	ExistsByChatId(ctx context.Context, chatId int64) (bool, error)
+	CountAll(ctx context.Context) (int64, error)
+	DeleteByChatId(ctx context.Context, chatId int64) error
+}
+
+type conversationRepositoryDependencies struct {
+	dig.In
+
+	Database db.IDatabase `name:"Database"`
+}
+
+type conversationRepository struct {
+	db *gorm.DB
+}
+
+func NewConversationRepository(deps conversationRepositoryDependencies) *conversationRepository {
+	return &conversationRepository{
+		db: deps.Database.GetInstance(),
+	}
+}
+
+func (r *conversationRepository) Save(ctx context.Context, conversation models.Conversation) error {
+	return execute(ctx, func() error {
+		return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "chat_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"state", "params", "data", "step", "message_id", "updated_at"}),
+		}).Create(&conversation).Error
+	})
+}
+
+func (r *conversationRepository) GetByChatId(ctx context.Context, chatId int64) (*models.Conversation, error) {
+	var conversation models.Conversation
+
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).Where("chat_id = ?", chatId).First(&conversation).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &conversation, nil
+}
+
+func (r *conversationRepository) ExistsByChatId(ctx context.Context, chatId int64) (bool, error) {
+	var count int64
+
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).Model(&models.Conversation{}).Where("chat_id = ?", chatId).Count(&count).Error
+	})
+
+	return count > 0, err
+}
+
+func (r *conversationRepository) CountAll(ctx context.Context) (int64, error) {
+	var count int64
+
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).Model(&models.Conversation{}).Count(&count).Error
+	})
+
+	return count, err
+}
+
+func (r *conversationRepository) DeleteByChatId(ctx context.Context, chatId int64) error {
+	return execute(ctx, func() error {
+		return r.db.WithContext(ctx).Where("chat_id = ?", chatId).Delete(&models.Conversation{}).Error
+	})
+}
@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"context"
+	"go.uber.org/dig"
+	"gorm.io/gorm"
+	"rezvin-pro-bot/src/internal/db"
+	"rezvin-pro-bot/src/models"
+)
+
+type IDeadLetterRepository interface {
+	Create(ctx context.Context, deadLetter models.DeadLetter) (uint, error)
+	GetAll(ctx context.Context, limit, offset int) ([]models.DeadLetter, error)
+	CountAll(ctx context.Context) (int64, error)
+}
+
+type deadLetterRepositoryDependencies struct {
+	dig.In
+
+	Database db.IDatabase `name:"Database"`
+}
+
+type deadLetterRepository struct {
+	db *gorm.DB
+}
+
+func NewDeadLetterRepository(deps deadLetterRepositoryDependencies) *deadLetterRepository {
+	return &deadLetterRepository{
+		db: deps.Database.GetInstance(),
+	}
+}
+
+func (r *deadLetterRepository) Create(ctx context.Context, deadLetter models.DeadLetter) (uint, error) {
+	err := executeOnce(ctx, func() error {
+		return r.db.WithContext(ctx).Create(&deadLetter).Error
+	})
+
+	return deadLetter.Id, err
+}
+
+func (r *deadLetterRepository) GetAll(ctx context.Context, limit, offset int) ([]models.DeadLetter, error) {
+	var deadLetters []models.DeadLetter
+
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).Order("created_at DESC").Limit(limit).Offset(offset).Find(&deadLetters).Error
+	})
+
+	return deadLetters, err
+}
+
+func (r *deadLetterRepository) CountAll(ctx context.Context) (int64, error) {
+	var count int64
+
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).Model(&models.DeadLetter{}).Count(&count).Error
+	})
+
+	return count, err
+}
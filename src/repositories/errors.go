@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"rezvin-pro-bot/src/utils"
+	"strings"
+)
+
+var (
+	ErrNotFound  = errors.New("record not found")
+	ErrConflict  = errors.New("record conflicts with existing data")
+	ErrTransient = errors.New("temporary database failure")
+)
+
+// wrapError classifies a GORM or driver error as one of the repository errors,
+// keeping the original error in the chain. Context errors are returned as is.
+func wrapError(err error) error {
+	switch {
+	case err == nil || utils.IsContextError(err):
+		return err
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return fmt.Errorf("%w: %w", ErrNotFound, err)
+	case isConflictError(err):
+		return fmt.Errorf("%w: %w", ErrConflict, err)
+	case isTransientError(err):
+		return fmt.Errorf("%w: %w", ErrTransient, err)
+	default:
+		return err
+	}
+}
+
+// IsRepositoryError tells a failed query apart from other errors, such as input
+// validation errors that are meant to be shown to the user as is.
+func IsRepositoryError(err error) bool {
+	return errors.Is(err, ErrNotFound) ||
+		errors.Is(err, ErrConflict) ||
+		errors.Is(err, ErrTransient) ||
+		utils.IsContextError(err) ||
+		utils.IsDatabaseError(err)
+}
+
+func isConflictError(err error) bool {
+	var pgErr *pgconn.PgError
+
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == "23505" || pgErr.Code == "23503"
+	}
+
+	return errors.Is(err, gorm.ErrDuplicatedKey) || errors.Is(err, gorm.ErrForeignKeyViolated)
+}
+
+// isTransientError reports failures that may succeed on a retry: lost or refused
+// connections, serialization failures, deadlocks and server restarts.
+func isTransientError(err error) bool {
+	var pgErr *pgconn.PgError
+
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "40001", "40P01", "53300", "55P03", "57P01", "57P02", "57P03":
+			return true
+		default:
+			return strings.HasPrefix(pgErr.Code, "08")
+		}
+	}
+
+	var connectErr *pgconn.ConnectError
+
+	return errors.As(err, &connectErr) || pgconn.SafeToRetry(err) || errors.Is(err, driver.ErrBadConn)
+}
@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5/pgconn"
+	"rezvin-pro-bot/src/constants"
+	"time"
+)
+
+// execute runs a read or an idempotent write and classifies its error, retrying
+// with exponential backoff while the error is transient.
+func execute(ctx context.Context, query func() error) error {
+	return retry(ctx, query, func(err error) bool {
+		return errors.Is(err, ErrTransient)
+	})
+}
+
+// executeOnce runs a write that must not be applied twice, such as an insert. A broken
+// connection may hide a committed write, so a transient error is only retried when the
+// write never reached the server or Postgres rolled it back as a serialization failure.
+func executeOnce(ctx context.Context, query func() error) error {
+	return retry(ctx, query, func(err error) bool {
+		var pgErr *pgconn.PgError
+		var connectErr *pgconn.ConnectError
+
+		if !errors.Is(err, ErrTransient) {
+			return false
+		}
+
+		if errors.As(err, &pgErr) {
+			return pgErr.Code == "40001" || pgErr.Code == "40P01"
+		}
+
+		return errors.As(err, &connectErr) || pgconn.SafeToRetry(err)
+	})
+}
+
+func retry(ctx context.Context, query func() error, retryable func(err error) bool) error {
+	var err error
+
+	for attempt := 1; attempt <= constants.RepositoryMaxAttempts; attempt++ {
+		err = wrapError(query())
+
+		if !retryable(err) || attempt == constants.RepositoryMaxAttempts {
+			return err
+		}
+
+		timer := time.NewTimer(constants.RepositoryRetryBaseDelay << (attempt - 1))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	return err
+}
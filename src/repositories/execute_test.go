@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"github.com/jackc/pgx/v5/pgconn"
+	"io"
+	"testing"
+)
+
+func TestExecuteRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		once         bool
+		wantAttempts int
+	}{
+		{"read succeeds", nil, false, 1},
+		{"read not found", errors.New("record not found"), false, 1},
+		{"read serialization failure", &pgconn.PgError{Code: "40001"}, false, 3},
+		{"read bad connection", driver.ErrBadConn, false, 3},
+		{"read connection failure", &pgconn.PgError{Code: "08006"}, false, 3},
+		{"read unexpected eof", io.ErrUnexpectedEOF, false, 1},
+		{"write succeeds", nil, true, 1},
+		{"write conflict", &pgconn.PgError{Code: "23505"}, true, 1},
+		{"write serialization failure", &pgconn.PgError{Code: "40001"}, true, 3},
+		{"write deadlock", &pgconn.PgError{Code: "40P01"}, true, 3},
+		{"write connection failure", &pgconn.PgError{Code: "08006"}, true, 1},
+		{"write admin shutdown", &pgconn.PgError{Code: "57P01"}, true, 1},
+		{"write bad connection", driver.ErrBadConn, true, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run := execute
+
+			if tt.once {
+				run = executeOnce
+			}
+
+			attempts := 0
+
+			err := run(context.Background(), func() error {
+				attempts++
+				return tt.err
+			})
+
+			if attempts != tt.wantAttempts {
+				t.Errorf("got %d attempts, want %d", attempts, tt.wantAttempts)
+			}
+
+			if !errors.Is(err, tt.err) {
+				t.Errorf("got %v, want %v in the chain", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestExecuteStopsOnCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	attempts := 0
+
+	err := execute(ctx, func() error {
+		attempts++
+		return driver.ErrBadConn
+	})
+
+	if !errors.Is(err, context.Canceled) || attempts != 1 {
+		t.Errorf("got %v after %d attempts, want %v after 1", err, attempts, context.Canceled)
+	}
+}
@@ -5,29 +5,30 @@ import (
 	"go.uber.org/dig"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
-	"rezvin-pro-bot/src/config"
 	"rezvin-pro-bot/src/internal/db"
 	"rezvin-pro-bot/src/models"
-	"rezvin-pro-bot/src/utils"
 )
 
 type IExerciseRepository interface {
-	Create(ctx context.Context, exercise models.Exercise) uint
-	CountByProgramId(ctx context.Context, programId uint) int64
-	GetByProgramId(ctx context.Context, programId uint, limit, offset int) []models.Exercise
-	GetById(ctx context.Context, id uint) *models.Exercise
-	GetByIdAndProgramId(ctx context.Context, id, programId uint) *models.Exercise
-	GetAll(ctx context.Context, limit, offset int) []models.Exercise
-	GetByNameAndProgramId(ctx context.Context, name string, programId uint) *models.Exercise
-	UpdateById(ctx context.Context, id uint, exercise models.Exercise)
-	DeleteById(ctx context.Context, id uint)
+	Create(ctx context.Context, exercise models.Exercise) (uint, error)
+	CountByProgramId(ctx context.Context, programId uint) (int64, error)
+	GetByProgramId(ctx context.Context, programId uint, limit, offset int) ([]models.Exercise, error)
+	GetById(ctx context.Context, id uint) (*models.Exercise, error)
+	GetAllByProgramId(ctx context.Context, programId uint) ([]models.Exercise, error)
+	GetByIdAndProgramId(ctx context.Context, id, programId uint) (*models.Exercise, error)
+	GetAll(ctx context.Context, limit, offset int) ([]models.Exercise, error)
+	GetByNameAndProgramId(ctx context.Context, name string, programId uint) (*models.Exercise, error)
+	UpdateById(ctx context.Context, id uint, exercise models.Exercise) error
+	UpdateRepSchemeById(ctx context.Context, id uint, repScheme string) error
+	DeleteById(ctx context.Context, id uint) error
 }
 
+const exerciseOrder = "position ASC, id ASC"
+
 type exerciseRepositoryDependencies struct {
 	dig.In
 
-	Database db.IDatabase   `name:"Database"`
-	Config   config.IConfig `name:"Config"`
+	Database db.IDatabase `name:"Database"`
 }
 
 type exerciseRepository struct {
@@ -35,114 +36,134 @@ type exerciseRepository struct {
 }
 
 func NewExerciseRepository(deps exerciseRepositoryDependencies) *exerciseRepository {
-	r := &exerciseRepository{
+	return &exerciseRepository{
 		db: deps.Database.GetInstance(),
 	}
-
-	if deps.Config.RunMigrations() {
-		err := r.db.AutoMigrate(&models.Exercise{})
-
-		utils.PanicIfError(err)
-	}
-
-	return r
 }
 
-func (r *exerciseRepository) Create(ctx context.Context, exercise models.Exercise) uint {
-	err := r.db.WithContext(ctx).Create(&exercise).Error
+func (r *exerciseRepository) Create(ctx context.Context, exercise models.Exercise) (uint, error) {
+	if exercise.Position == 0 {
+		err := execute(ctx, func() error {
+			return r.db.WithContext(ctx).
+				Model(&models.Exercise{}).
+				Select("COALESCE(MAX(position), 0) + 1").
+				Where("program_id = ?", exercise.ProgramId).
+				Scan(&exercise.Position).
+				Error
+		})
+
+		if err != nil {
+			return 0, err
+		}
+	}
 
-	utils.PanicIfNotContextError(err)
+	err := executeOnce(ctx, func() error {
+		return r.db.WithContext(ctx).Create(&exercise).Error
+	})
 
-	return exercise.Id
+	return exercise.Id, err
 }
 
-func (r *exerciseRepository) GetById(ctx context.Context, id uint) *models.Exercise {
+func (r *exerciseRepository) GetById(ctx context.Context, id uint) (*models.Exercise, error) {
 	var exercise models.Exercise
-	err := r.db.WithContext(ctx).Clauses(clause.Returning{}).Where("id = ?", id).First(&exercise).Error
 
-	if err != nil && utils.IsRecordNotFoundError(err) {
-		return nil
-	}
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).Clauses(clause.Returning{}).Where("id = ?", id).First(&exercise).Error
+	})
 
-	utils.PanicIfNotRecordNotFound(err)
+	if err != nil {
+		return nil, err
+	}
 
-	return &exercise
+	return &exercise, nil
 }
 
-func (r *exerciseRepository) GetAllByProgramId(ctx context.Context, programId uint) []models.Exercise {
+func (r *exerciseRepository) GetAllByProgramId(ctx context.Context, programId uint) ([]models.Exercise, error) {
 	var exercises []models.Exercise
 
-	err := r.db.WithContext(ctx).Where("program_id = ?", programId).Find(&exercises).Error
-
-	utils.PanicIfNotContextError(err)
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).Where("program_id = ?", programId).Order(exerciseOrder).Find(&exercises).Error
+	})
 
-	return exercises
+	return exercises, err
 }
 
-func (r *exerciseRepository) GetByProgramId(ctx context.Context, programId uint, limit, offset int) []models.Exercise {
+func (r *exerciseRepository) GetByProgramId(ctx context.Context, programId uint, limit, offset int) ([]models.Exercise, error) {
 	var exercises []models.Exercise
 
-	err := r.db.WithContext(ctx).Limit(limit).Offset(offset).Where("program_id = ?", programId).Find(&exercises).Error
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).Limit(limit).Offset(offset).Where("program_id = ?", programId).Order(exerciseOrder).Find(&exercises).Error
+	})
 
-	utils.PanicIfNotContextError(err)
-
-	return exercises
+	return exercises, err
 }
 
-func (r *exerciseRepository) CountByProgramId(ctx context.Context, programId uint) int64 {
+func (r *exerciseRepository) CountByProgramId(ctx context.Context, programId uint) (int64, error) {
 	var count int64
 
-	err := r.db.WithContext(ctx).Model(&models.Exercise{}).Where("program_id = ?", programId).Count(&count).Error
-
-	utils.PanicIfNotContextError(err)
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).Model(&models.Exercise{}).Where("program_id = ?", programId).Count(&count).Error
+	})
 
-	return count
+	return count, err
 }
 
-func (r *exerciseRepository) GetByIdAndProgramId(ctx context.Context, id, programId uint) *models.Exercise {
+func (r *exerciseRepository) GetByIdAndProgramId(ctx context.Context, id, programId uint) (*models.Exercise, error) {
 	var exercise models.Exercise
-	err := r.db.WithContext(ctx).Clauses(clause.Returning{}).Where("id = ?", id).Where("program_id = ?", programId).First(&exercise).Error
 
-	if err != nil && utils.IsRecordNotFoundError(err) {
-		return nil
-	}
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).Clauses(clause.Returning{}).Where("id = ?", id).Where("program_id = ?", programId).First(&exercise).Error
+	})
 
-	utils.PanicIfNotRecordNotFound(err)
+	if err != nil {
+		return nil, err
+	}
 
-	return &exercise
+	return &exercise, nil
 }
 
-func (r *exerciseRepository) GetAll(ctx context.Context, limit, offset int) []models.Exercise {
+func (r *exerciseRepository) GetAll(ctx context.Context, limit, offset int) ([]models.Exercise, error) {
 	var exercises []models.Exercise
 
-	err := r.db.WithContext(ctx).Limit(limit).Offset(offset).Find(&exercises).Error
-
-	utils.PanicIfNotContextError(err)
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).Limit(limit).Offset(offset).Find(&exercises).Error
+	})
 
-	return exercises
+	return exercises, err
 }
 
-func (r *exerciseRepository) GetByNameAndProgramId(ctx context.Context, name string, programId uint) *models.Exercise {
+func (r *exerciseRepository) GetByNameAndProgramId(ctx context.Context, name string, programId uint) (*models.Exercise, error) {
 	var exercise models.Exercise
-	err := r.db.WithContext(ctx).Where("name = ?", name).Where("program_id = ?", programId).First(&exercise).Error
 
-	if err != nil && utils.IsRecordNotFoundError(err) {
-		return nil
-	}
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).Where("name = ?", name).Where("program_id = ?", programId).First(&exercise).Error
+	})
 
-	utils.PanicIfNotRecordNotFound(err)
+	if err != nil {
+		return nil, err
+	}
 
-	return &exercise
+	return &exercise, nil
 }
 
-func (r *exerciseRepository) UpdateById(ctx context.Context, id uint, exercise models.Exercise) {
-	err := r.db.WithContext(ctx).Model(&models.Exercise{}).Where("id = ?", id).Updates(&exercise).Error
+func (r *exerciseRepository) UpdateById(ctx context.Context, id uint, exercise models.Exercise) error {
+	return execute(ctx, func() error {
+		return r.db.WithContext(ctx).Model(&models.Exercise{}).Where("id = ?", id).Updates(&exercise).Error
+	})
+}
 
-	utils.PanicIfNotContextError(err)
+func (r *exerciseRepository) UpdateRepSchemeById(ctx context.Context, id uint, repScheme string) error {
+	return execute(ctx, func() error {
+		return r.db.WithContext(ctx).Model(&models.Exercise{}).Where("id = ?", id).Update("rep_scheme", repScheme).Error
+	})
 }
 
-func (r *exerciseRepository) DeleteById(ctx context.Context, id uint) {
-	err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.Exercise{}).Error
+func (r *exerciseRepository) DeleteById(ctx context.Context, id uint) error {
+	return execute(ctx, func() error {
+		return r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.Exercise{}).Error
+	})
+}
 
-	utils.PanicIfNotContextError(err)
+func orderExercises(db *gorm.DB) *gorm.DB {
+	return db.Order(exerciseOrder)
 }
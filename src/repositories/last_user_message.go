@@ -4,24 +4,21 @@ import (
 	"context"
 	"go.uber.org/dig"
 	"gorm.io/gorm"
-	"rezvin-pro-bot/src/config"
 	"rezvin-pro-bot/src/internal/db"
 	"rezvin-pro-bot/src/models"
-	"rezvin-pro-bot/src/utils"
 )
 
 type ILastUserMessageRepository interface {
-	Create(ctx context.Context, exercise models.LastUserMessage) int64
-	GetByChatId(ctx context.Context, id int64) *models.LastUserMessage
-	UpdateByChatId(ctx context.Context, id int64, exercise models.LastUserMessage)
-	DeleteByChatId(ctx context.Context, id int64)
+	Create(ctx context.Context, exercise models.LastUserMessage) (int64, error)
+	GetByChatId(ctx context.Context, id int64) (*models.LastUserMessage, error)
+	UpdateByChatId(ctx context.Context, id int64, exercise models.LastUserMessage) error
+	DeleteByChatId(ctx context.Context, id int64) error
 }
 
 type lastUserMessageRepositoryDependencies struct {
 	dig.In
 
-	Database db.IDatabase   `name:"Database"`
-	Config   config.IConfig `name:"Config"`
+	Database db.IDatabase `name:"Database"`
 }
 
 type lastUserMessageRepository struct {
@@ -29,48 +26,41 @@ type lastUserMessageRepository struct {
 }
 
 func NewLastUserMessageRepository(deps lastUserMessageRepositoryDependencies) *lastUserMessageRepository {
-	r := &lastUserMessageRepository{
+	return &lastUserMessageRepository{
 		db: deps.Database.GetInstance(),
 	}
-
-	if deps.Config.RunMigrations() {
-		err := r.db.AutoMigrate(&models.LastUserMessage{})
-
-		utils.PanicIfError(err)
-	}
-
-	return r
 }
 
-func (r *lastUserMessageRepository) Create(ctx context.Context, msg models.LastUserMessage) int64 {
-	err := r.db.WithContext(ctx).Create(&msg).Error
-
-	utils.PanicIfNotContextError(err)
+func (r *lastUserMessageRepository) Create(ctx context.Context, msg models.LastUserMessage) (int64, error) {
+	err := executeOnce(ctx, func() error {
+		return r.db.WithContext(ctx).Create(&msg).Error
+	})
 
-	return msg.ChatId
+	return msg.ChatId, err
 }
 
-func (r *lastUserMessageRepository) GetByChatId(ctx context.Context, id int64) *models.LastUserMessage {
+func (r *lastUserMessageRepository) GetByChatId(ctx context.Context, id int64) (*models.LastUserMessage, error) {
 	var msg models.LastUserMessage
-	err := r.db.WithContext(ctx).Where("chat_id = ?", id).First(&msg).Error
 
-	if err != nil && utils.IsRecordNotFoundError(err) {
-		return nil
-	}
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).Where("chat_id = ?", id).First(&msg).Error
+	})
 
-	utils.PanicIfNotContextError(err)
+	if err != nil {
+		return nil, err
+	}
 
-	return &msg
+	return &msg, nil
 }
 
-func (r *lastUserMessageRepository) UpdateByChatId(ctx context.Context, id int64, msg models.LastUserMessage) {
-	err := r.db.WithContext(ctx).Where("chat_id = ?", id).Updates(&msg).Error
-
-	utils.PanicIfNotContextError(err)
+func (r *lastUserMessageRepository) UpdateByChatId(ctx context.Context, id int64, msg models.LastUserMessage) error {
+	return execute(ctx, func() error {
+		return r.db.WithContext(ctx).Where("chat_id = ?", id).Updates(&msg).Error
+	})
 }
 
-func (r *lastUserMessageRepository) DeleteByChatId(ctx context.Context, id int64) {
-	err := r.db.WithContext(ctx).Where("chat_id = ?", id).Delete(&models.LastUserMessage{}).Error
-
-	utils.PanicIfNotContextError(err)
+func (r *lastUserMessageRepository) DeleteByChatId(ctx context.Context, id int64) error {
+	return execute(ctx, func() error {
+		return r.db.WithContext(ctx).Where("chat_id = ?", id).Delete(&models.LastUserMessage{}).Error
+	})
 }
@@ -0,0 +1,73 @@
+package memory_repositories
+
+import (
+	"context"
+	"go.uber.org/dig"
+	"rezvin-pro-bot/src/models"
+	"rezvin-pro-bot/src/repositories"
+	"time"
+)
+
+type callbackStateRepositoryDependencies struct {
+	dig.In
+
+	Store *Store `name:"MemoryStore"`
+}
+
+type callbackStateRepository struct {
+	store *Store
+}
+
+func NewCallbackStateRepository(deps callbackStateRepositoryDependencies) *callbackStateRepository {
+	return &callbackStateRepository{
+		store: deps.Store,
+	}
+}
+
+func (r *callbackStateRepository) Create(ctx context.Context, state models.CallbackState) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.callbackStates[state.Token]; ok {
+		return duplicatedKey("callback_states_pkey")
+	}
+
+	state.Data = cloneData(state.Data)
+
+	beforeCreate(&state)
+
+	r.store.callbackStates[state.Token] = state
+
+	return nil
+}
+
+func (r *callbackStateRepository) GetByToken(ctx context.Context, token string) (*models.CallbackState, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	state, ok := r.store.callbackStates[token]
+
+	if !ok || !state.ExpiresAt.After(time.Now()) {
+		return nil, repositories.ErrNotFound
+	}
+
+	state.Data = cloneData(state.Data)
+
+	return &state, nil
+}
+
+func (r *callbackStateRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var deleted int64
+
+	for token, state := range r.store.callbackStates {
+		if !state.ExpiresAt.After(time.Now()) {
+			delete(r.store.callbackStates, token)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
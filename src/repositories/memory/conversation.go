@@ -0,0 +1,84 @@
+package memory_repositories
+
+import (
+	"context"
+	"go.uber.org/dig"
+	"rezvin-pro-bot/src/models"
+	"rezvin-pro-bot/src/repositories"
+	"time"
+)
+
+type conversationRepositoryDependencies struct {
+	dig.In
+
+	Store *Store `name:"MemoryStore"`
+}
+
+type conversationRepository struct {
+	store *Store
+}
+
+func NewConversationRepository(deps conversationRepositoryDependencies) *conversationRepository {
+	return &conversationRepository{
+		store: deps.Store,
+	}
+}
+
+func (r *conversationRepository) Save(ctx context.Context, conversation models.Conversation) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	conversation.Data = cloneData(conversation.Data)
+
+	if existing, ok := r.store.conversations[conversation.ChatId]; ok {
+		conversation.CreatedAt = existing.CreatedAt
+	} else {
+		beforeCreate(&conversation)
+	}
+
+	conversation.UpdatedAt = time.Now()
+
+	r.store.conversations[conversation.ChatId] = conversation
+
+	return nil
+}
+
+func (r *conversationRepository) GetByChatId(ctx context.Context, chatId int64) (*models.Conversation, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	conversation, ok := r.store.conversations[chatId]
+
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+
+	conversation.Data = cloneData(conversation.Data)
+
+	return &conversation, nil
+}
+
+func (r *conversationRepository) ExistsByChatId(ctx context.Context, chatId int64) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	_, ok := r.store.conversations[chatId]
+
+	return ok, nil
+}
+
+func (r *conversationRepository) CountAll(ctx context.Context) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return int64(len(r.store.conversations)), nil
+}
+
+func (r *conversationRepository) DeleteByChatId(ctx context.Context, chatId int64) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.conversations, chatId)
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package memory_repositories
+
+import (
+	"context"
+	"go.uber.org/dig"
+	"rezvin-pro-bot/src/models"
+)
+
+type deadLetterRepositoryDependencies struct {
+	dig.In
+
+	Store *Store `name:"MemoryStore"`
+}
+
+type deadLetterRepository struct {
+	store *Store
+}
+
+func NewDeadLetterRepository(deps deadLetterRepositoryDependencies) *deadLetterRepository {
+	return &deadLetterRepository{
+		store: deps.Store,
+	}
+}
+
+func (r *deadLetterRepository) Create(ctx context.Context, deadLetter models.DeadLetter) (uint, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	deadLetter.Id = r.store.nextId("dead_letters")
+
+	beforeCreate(&deadLetter)
+
+	r.store.deadLetters[deadLetter.Id] = deadLetter
+
+	return deadLetter.Id, nil
+}
+
+func (r *deadLetterRepository) GetAll(ctx context.Context, limit, offset int) ([]models.DeadLetter, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return paginate(filter(r.store.deadLetters, all[models.DeadLetter], func(a, b models.DeadLetter) bool {
+		return a.CreatedAt.After(b.CreatedAt) || (a.CreatedAt.Equal(b.CreatedAt) && a.Id > b.Id)
+	}), limit, offset), nil
+}
+
+func (r *deadLetterRepository) CountAll(ctx context.Context) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return int64(len(r.store.deadLetters)), nil
+}
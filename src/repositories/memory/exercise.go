@@ -0,0 +1,193 @@
+package memory_repositories
+
+import (
+	"context"
+	"go.uber.org/dig"
+	"rezvin-pro-bot/src/models"
+	"rezvin-pro-bot/src/repositories"
+)
+
+type exerciseRepositoryDependencies struct {
+	dig.In
+
+	Store *Store `name:"MemoryStore"`
+}
+
+type exerciseRepository struct {
+	store *Store
+}
+
+func NewExerciseRepository(deps exerciseRepositoryDependencies) *exerciseRepository {
+	return &exerciseRepository{
+		store: deps.Store,
+	}
+}
+
+func (r *exerciseRepository) Create(ctx context.Context, exercise models.Exercise) (uint, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return createExercise(r.store, exercise)
+}
+
+func (r *exerciseRepository) GetById(ctx context.Context, id uint) (*models.Exercise, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	exercise, ok := r.store.exercises[id]
+
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+
+	return &exercise, nil
+}
+
+func (r *exerciseRepository) GetAllByProgramId(ctx context.Context, programId uint) ([]models.Exercise, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return filter(r.store.exercises, byProgramId(programId), exerciseLess), nil
+}
+
+func (r *exerciseRepository) GetByProgramId(ctx context.Context, programId uint, limit, offset int) ([]models.Exercise, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return paginate(filter(r.store.exercises, byProgramId(programId), exerciseLess), limit, offset), nil
+}
+
+func (r *exerciseRepository) CountByProgramId(ctx context.Context, programId uint) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return count(r.store.exercises, byProgramId(programId)), nil
+}
+
+func (r *exerciseRepository) GetByIdAndProgramId(ctx context.Context, id, programId uint) (*models.Exercise, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	exercise, ok := r.store.exercises[id]
+
+	if !ok || exercise.ProgramId != programId {
+		return nil, repositories.ErrNotFound
+	}
+
+	return &exercise, nil
+}
+
+func (r *exerciseRepository) GetAll(ctx context.Context, limit, offset int) ([]models.Exercise, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return paginate(filter(r.store.exercises, all[models.Exercise], exerciseLess), limit, offset), nil
+}
+
+func (r *exerciseRepository) GetByNameAndProgramId(ctx context.Context, name string, programId uint) (*models.Exercise, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, exercise := range r.store.exercises {
+		if exercise.Name == name && exercise.ProgramId == programId {
+			return &exercise, nil
+		}
+	}
+
+	return nil, repositories.ErrNotFound
+}
+
+func (r *exerciseRepository) UpdateById(ctx context.Context, id uint, exercise models.Exercise) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.exercises[id]
+
+	if !ok {
+		return nil
+	}
+
+	applyUpdates(&existing, exercise)
+
+	if err := checkExercise(r.store, existing); err != nil {
+		return err
+	}
+
+	r.store.exercises[id] = existing
+
+	return nil
+}
+
+func (r *exerciseRepository) UpdateRepSchemeById(ctx context.Context, id uint, repScheme string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.exercises[id]
+
+	if !ok {
+		return nil
+	}
+
+	existing.RepScheme = repScheme
+
+	r.store.exercises[id] = existing
+
+	return nil
+}
+
+func (r *exerciseRepository) DeleteById(ctx context.Context, id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.deleteExercise(id)
+
+	return nil
+}
+
+func createExercise(store *Store, exercise models.Exercise) (uint, error) {
+	exercise.Id = store.nextId("exercises")
+
+	if exercise.Position == 0 {
+		for _, existing := range store.exercises {
+			if existing.ProgramId == exercise.ProgramId {
+				exercise.Position = max(exercise.Position, existing.Position)
+			}
+		}
+
+		exercise.Position++
+	}
+
+	if err := checkExercise(store, exercise); err != nil {
+		return 0, err
+	}
+
+	beforeCreate(&exercise)
+
+	store.exercises[exercise.Id] = exercise
+
+	return exercise.Id, nil
+}
+
+func checkExercise(store *Store, exercise models.Exercise) error {
+	if _, ok := store.programs[exercise.ProgramId]; !ok {
+		return foreignKeyViolated("fk_programs_exercises")
+	}
+
+	for _, existing := range store.exercises {
+		if existing.Id != exercise.Id && existing.Name == exercise.Name && existing.ProgramId == exercise.ProgramId {
+			return duplicatedKey("idx_exercise")
+		}
+	}
+
+	return nil
+}
+
+func byProgramId(programId uint) func(models.Exercise) bool {
+	return func(exercise models.Exercise) bool {
+		return exercise.ProgramId == programId
+	}
+}
+
+func exerciseLess(a, b models.Exercise) bool {
+	return a.Position < b.Position || (a.Position == b.Position && a.Id < b.Id)
+}
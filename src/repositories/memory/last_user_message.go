@@ -0,0 +1,99 @@
+package memory_repositories
+
+import (
+	"context"
+	"go.uber.org/dig"
+	"rezvin-pro-bot/src/models"
+	"rezvin-pro-bot/src/repositories"
+)
+
+type lastUserMessageRepositoryDependencies struct {
+	dig.In
+
+	Store *Store `name:"MemoryStore"`
+}
+
+type lastUserMessageRepository struct {
+	store *Store
+}
+
+func NewLastUserMessageRepository(deps lastUserMessageRepositoryDependencies) *lastUserMessageRepository {
+	return &lastUserMessageRepository{
+		store: deps.Store,
+	}
+}
+
+func (r *lastUserMessageRepository) Create(ctx context.Context, msg models.LastUserMessage) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.lastUserMessages[msg.ChatId]; ok {
+		return 0, duplicatedKey("last_user_messages_pkey")
+	}
+
+	if err := r.checkUniqueMessageId(msg); err != nil {
+		return 0, err
+	}
+
+	beforeCreate(&msg)
+
+	r.store.lastUserMessages[msg.ChatId] = msg
+
+	return msg.ChatId, nil
+}
+
+func (r *lastUserMessageRepository) GetByChatId(ctx context.Context, id int64) (*models.LastUserMessage, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	msg, ok := r.store.lastUserMessages[id]
+
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+
+	return &msg, nil
+}
+
+func (r *lastUserMessageRepository) UpdateByChatId(ctx context.Context, id int64, msg models.LastUserMessage) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.lastUserMessages[id]
+
+	if !ok {
+		return nil
+	}
+
+	// ChatId is the primary key here, so it must not be overwritten by a non-zero value in msg.
+	msg.ChatId = 0
+
+	applyUpdates(&existing, msg)
+
+	if err := r.checkUniqueMessageId(existing); err != nil {
+		return err
+	}
+
+	r.store.lastUserMessages[id] = existing
+
+	return nil
+}
+
+func (r *lastUserMessageRepository) DeleteByChatId(ctx context.Context, id int64) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.lastUserMessages, id)
+
+	return nil
+}
+
+func (r *lastUserMessageRepository) checkUniqueMessageId(msg models.LastUserMessage) error {
+	for _, existing := range r.store.lastUserMessages {
+		if existing.ChatId != msg.ChatId && existing.MessageId == msg.MessageId {
+			return duplicatedKey("idx_last_user_message")
+		}
+	}
+
+	return nil
+}
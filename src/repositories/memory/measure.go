@@ -0,0 +1,151 @@
+package memory_repositories
+
+import (
+	"context"
+	"go.uber.org/dig"
+	"rezvin-pro-bot/src/models"
+	"rezvin-pro-bot/src/repositories"
+)
+
+type measureRepositoryDependencies struct {
+	dig.In
+
+	Store *Store `name:"MemoryStore"`
+}
+
+type measureRepository struct {
+	store *Store
+}
+
+func NewMeasureRepository(deps measureRepositoryDependencies) *measureRepository {
+	return &measureRepository{
+		store: deps.Store,
+	}
+}
+
+func (r *measureRepository) CountNotAssignedToUser(ctx context.Context, userId int64) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return count(r.store.measures, r.notAssignedTo(userId)), nil
+}
+
+func (r *measureRepository) GetNotAssignedToUser(ctx context.Context, userId int64, limit, offset int) ([]models.Measure, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return paginate(filter(r.store.measures, r.notAssignedTo(userId), measureLess), limit, offset), nil
+}
+
+func (r *measureRepository) Create(ctx context.Context, measure models.Measure) (uint, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	measure.Id = r.store.nextId("measures")
+
+	if err := r.checkUniqueName(measure); err != nil {
+		return 0, err
+	}
+
+	beforeCreate(&measure)
+
+	r.store.measures[measure.Id] = measure
+
+	return measure.Id, nil
+}
+
+func (r *measureRepository) CountAll(ctx context.Context) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return int64(len(r.store.measures)), nil
+}
+
+func (r *measureRepository) GetById(ctx context.Context, id uint) (*models.Measure, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	measure, ok := r.store.measures[id]
+
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+
+	return &measure, nil
+}
+
+func (r *measureRepository) GetAll(ctx context.Context, limit, offset int) ([]models.Measure, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return paginate(filter(r.store.measures, all[models.Measure], measureLess), limit, offset), nil
+}
+
+func (r *measureRepository) GetByName(ctx context.Context, name string) (*models.Measure, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, measure := range r.store.measures {
+		if measure.Name == name {
+			return &measure, nil
+		}
+	}
+
+	return nil, repositories.ErrNotFound
+}
+
+func (r *measureRepository) UpdateById(ctx context.Context, id uint, measure models.Measure) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.measures[id]
+
+	if !ok {
+		return nil
+	}
+
+	applyUpdates(&existing, measure)
+
+	if err := r.checkUniqueName(existing); err != nil {
+		return err
+	}
+
+	r.store.measures[id] = existing
+
+	return nil
+}
+
+func (r *measureRepository) DeleteById(ctx context.Context, id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.deleteMeasure(id)
+
+	return nil
+}
+
+func (r *measureRepository) checkUniqueName(measure models.Measure) error {
+	for _, existing := range r.store.measures {
+		if existing.Id != measure.Id && existing.Name == measure.Name {
+			return duplicatedKey("uni_measures_name")
+		}
+	}
+
+	return nil
+}
+
+func (r *measureRepository) notAssignedTo(userId int64) func(models.Measure) bool {
+	return func(measure models.Measure) bool {
+		for _, userMeasure := range r.store.userMeasures {
+			if userMeasure.UserId == userId && userMeasure.MeasureId == measure.Id {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+func measureLess(a, b models.Measure) bool {
+	return a.Id < b.Id
+}
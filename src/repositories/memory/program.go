@@ -0,0 +1,249 @@
+package memory_repositories
+
+import (
+	"context"
+	"go.uber.org/dig"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/models"
+	"rezvin-pro-bot/src/repositories"
+	"rezvin-pro-bot/src/utils/importer"
+	"rezvin-pro-bot/src/utils/rep_scheme"
+)
+
+type programRepositoryDependencies struct {
+	dig.In
+
+	Store *Store `name:"MemoryStore"`
+}
+
+type programRepository struct {
+	store *Store
+}
+
+func NewProgramRepository(deps programRepositoryDependencies) *programRepository {
+	return &programRepository{
+		store: deps.Store,
+	}
+}
+
+func (r *programRepository) CountNotAssignedToUser(ctx context.Context, userId int64) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return count(r.store.programs, r.notAssignedTo(userId)), nil
+}
+
+func (r *programRepository) GetNotAssignedToUser(ctx context.Context, userId int64, limit, offset int) ([]models.Program, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return paginate(filter(r.store.programs, r.notAssignedTo(userId), programLess), limit, offset), nil
+}
+
+func (r *programRepository) Create(ctx context.Context, program models.Program) (uint, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.create(program)
+}
+
+func (r *programRepository) CountAll(ctx context.Context) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return int64(len(r.store.programs)), nil
+}
+
+func (r *programRepository) GetById(ctx context.Context, id uint) (*models.Program, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	program, ok := r.store.programWithExercises(id)
+
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+
+	return &program, nil
+}
+
+func (r *programRepository) GetAll(ctx context.Context, limit, offset int) ([]models.Program, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return paginate(filter(r.store.programs, all[models.Program], programLess), limit, offset), nil
+}
+
+func (r *programRepository) GetByName(ctx context.Context, name string) (*models.Program, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, program := range r.store.programs {
+		if program.Name == name {
+			return &program, nil
+		}
+	}
+
+	return nil, repositories.ErrNotFound
+}
+
+func (r *programRepository) UpdateById(ctx context.Context, id uint, program models.Program) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.programs[id]
+
+	if !ok {
+		return nil
+	}
+
+	applyUpdates(&existing, program)
+
+	if err := r.checkUniqueName(existing); err != nil {
+		return err
+	}
+
+	r.store.programs[id] = existing
+
+	return nil
+}
+
+func (r *programRepository) DeleteById(ctx context.Context, id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.deleteProgram(id)
+
+	return nil
+}
+
+func (r *programRepository) Import(ctx context.Context, plan importer.Plan) error {
+	return r.store.transaction(func() error {
+		for _, change := range plan.Programs {
+			programId := change.ProgramId
+
+			if change.IsNew() {
+				id, err := r.create(models.Program{Name: change.Name, RepScheme: change.RepScheme})
+
+				if err != nil {
+					return err
+				}
+
+				programId = id
+			} else if change.RepSchemeChanged() {
+				if program, ok := r.store.programs[programId]; ok {
+					program.RepScheme = change.RepScheme
+					r.store.programs[programId] = program
+				}
+			}
+
+			for _, exerciseChange := range change.Exercises {
+				if exerciseChange.IsNew() {
+					_, err := createExercise(r.store, models.Exercise{
+						Name:      exerciseChange.Name,
+						ProgramId: programId,
+						RepScheme: exerciseChange.RepScheme,
+						Position:  exerciseChange.Position,
+					})
+
+					if err != nil {
+						return err
+					}
+
+					continue
+				}
+
+				if exercise, ok := r.store.exercises[exerciseChange.ExerciseId]; ok {
+					if exerciseChange.RepSchemeChanged() {
+						exercise.RepScheme = exerciseChange.RepScheme
+					}
+
+					if exerciseChange.PositionChanged() {
+						exercise.Position = exerciseChange.Position
+					}
+
+					r.store.exercises[exercise.Id] = exercise
+				}
+			}
+
+			if err := r.syncResults(programId); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *programRepository) syncResults(programId uint) error {
+	program, ok := r.store.programWithExercises(programId)
+
+	if !ok {
+		return nil
+	}
+
+	results := &userResultRepository{store: r.store}
+
+	for _, userProgram := range r.store.userPrograms {
+		if userProgram.ProgramId != programId {
+			continue
+		}
+
+		for _, exercise := range program.Exercises {
+			if err := results.syncReps(userProgram.Id, exercise.Id, rep_scheme.ForExercise(program, exercise)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *programRepository) create(program models.Program) (uint, error) {
+	if program.OneRepMaxFormula == "" {
+		program.OneRepMaxFormula = constants.DefaultOneRepMaxFormula
+	}
+
+	if program.RepScheme == "" {
+		program.RepScheme = constants.DefaultRepScheme
+	}
+
+	program.Id = r.store.nextId("programs")
+	program.Exercises = nil
+
+	if err := r.checkUniqueName(program); err != nil {
+		return 0, err
+	}
+
+	beforeCreate(&program)
+
+	r.store.programs[program.Id] = program
+
+	return program.Id, nil
+}
+
+func (r *programRepository) checkUniqueName(program models.Program) error {
+	for _, existing := range r.store.programs {
+		if existing.Id != program.Id && existing.Name == program.Name {
+			return duplicatedKey("uni_programs_name")
+		}
+	}
+
+	return nil
+}
+
+func (r *programRepository) notAssignedTo(userId int64) func(models.Program) bool {
+	return func(program models.Program) bool {
+		for _, userProgram := range r.store.userPrograms {
+			if userProgram.UserId == userId && userProgram.ProgramId == program.Id {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+func programLess(a, b models.Program) bool {
+	return a.Id < b.Id
+}
@@ -0,0 +1,68 @@
+package memory_repositories
+
+import (
+	"context"
+	"go.uber.org/dig"
+	"rezvin-pro-bot/src/models"
+	"time"
+)
+
+type restTimerRepositoryDependencies struct {
+	dig.In
+
+	Store *Store `name:"MemoryStore"`
+}
+
+type restTimerRepository struct {
+	store *Store
+}
+
+func NewRestTimerRepository(deps restTimerRepositoryDependencies) *restTimerRepository {
+	return &restTimerRepository{
+		store: deps.Store,
+	}
+}
+
+func (r *restTimerRepository) Save(ctx context.Context, timer models.RestTimer) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	beforeCreate(&timer)
+
+	r.store.restTimers[timer.ChatId] = timer
+
+	return nil
+}
+
+func (r *restTimerRepository) GetAll(ctx context.Context) ([]models.RestTimer, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return filter(r.store.restTimers, all[models.RestTimer], func(a, b models.RestTimer) bool {
+		return a.FireAt.Before(b.FireAt) || (a.FireAt.Equal(b.FireAt) && a.ChatId < b.ChatId)
+	}), nil
+}
+
+func (r *restTimerRepository) DeleteByChatId(ctx context.Context, chatId int64) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.restTimers, chatId)
+
+	return nil
+}
+
+func (r *restTimerRepository) Claim(ctx context.Context, chatId int64, fireAt time.Time) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	timer, ok := r.store.restTimers[chatId]
+
+	if !ok || !timer.FireAt.Equal(fireAt) {
+		return false, nil
+	}
+
+	delete(r.store.restTimers, chatId)
+
+	return true, nil
+}
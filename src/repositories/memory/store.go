@@ -0,0 +1,361 @@
+package memory_repositories
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"reflect"
+	"rezvin-pro-bot/src/models"
+	"rezvin-pro-bot/src/repositories"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Store keeps every table in memory behind a single lock, so that cascades and
+// preloads can cross repository boundaries the same way they do in Postgres.
+type Store struct {
+	mu sync.Mutex
+
+	sequences map[string]uint
+
+	users               map[int64]models.User
+	programs            map[uint]models.Program
+	exercises           map[uint]models.Exercise
+	measures            map[uint]models.Measure
+	userPrograms        map[uint]models.UserProgram
+	userResults         map[uint]models.UserResult
+	userResultHistories map[uint]models.UserResultHistory
+	userMeasures        map[uint]models.UserMeasure
+	workoutSessions     map[uint]models.WorkoutSession
+	workoutSets         map[uint]models.WorkoutSet
+	deadLetters         map[uint]models.DeadLetter
+	lastUserMessages    map[int64]models.LastUserMessage
+	conversations       map[int64]models.Conversation
+	restTimers          map[int64]models.RestTimer
+	callbackStates      map[string]models.CallbackState
+}
+
+func NewStore() *Store {
+	return &Store{
+		sequences:           make(map[string]uint),
+		users:               make(map[int64]models.User),
+		programs:            make(map[uint]models.Program),
+		exercises:           make(map[uint]models.Exercise),
+		measures:            make(map[uint]models.Measure),
+		userPrograms:        make(map[uint]models.UserProgram),
+		userResults:         make(map[uint]models.UserResult),
+		userResultHistories: make(map[uint]models.UserResultHistory),
+		userMeasures:        make(map[uint]models.UserMeasure),
+		workoutSessions:     make(map[uint]models.WorkoutSession),
+		workoutSets:         make(map[uint]models.WorkoutSet),
+		deadLetters:         make(map[uint]models.DeadLetter),
+		lastUserMessages:    make(map[int64]models.LastUserMessage),
+		conversations:       make(map[int64]models.Conversation),
+		restTimers:          make(map[int64]models.RestTimer),
+		callbackStates:      make(map[string]models.CallbackState),
+	}
+}
+
+func (s *Store) nextId(table string) uint {
+	s.sequences[table]++
+
+	return s.sequences[table]
+}
+
+// transaction runs fn under the store lock and rolls every table back if fn fails.
+func (s *Store) transaction(fn func() error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshot := s.clone()
+
+	err := fn()
+
+	if err != nil {
+		s.restore(snapshot)
+	}
+
+	return err
+}
+
+func (s *Store) clone() *Store {
+	return &Store{
+		sequences:           cloneMap(s.sequences),
+		users:               cloneMap(s.users),
+		programs:            cloneMap(s.programs),
+		exercises:           cloneMap(s.exercises),
+		measures:            cloneMap(s.measures),
+		userPrograms:        cloneMap(s.userPrograms),
+		userResults:         cloneMap(s.userResults),
+		userResultHistories: cloneMap(s.userResultHistories),
+		userMeasures:        cloneMap(s.userMeasures),
+		workoutSessions:     cloneMap(s.workoutSessions),
+		workoutSets:         cloneMap(s.workoutSets),
+		deadLetters:         cloneMap(s.deadLetters),
+		lastUserMessages:    cloneMap(s.lastUserMessages),
+		conversations:       cloneMap(s.conversations),
+		restTimers:          cloneMap(s.restTimers),
+		callbackStates:      cloneMap(s.callbackStates),
+	}
+}
+
+func (s *Store) restore(snapshot *Store) {
+	s.sequences = snapshot.sequences
+	s.users = snapshot.users
+	s.programs = snapshot.programs
+	s.exercises = snapshot.exercises
+	s.measures = snapshot.measures
+	s.userPrograms = snapshot.userPrograms
+	s.userResults = snapshot.userResults
+	s.userResultHistories = snapshot.userResultHistories
+	s.userMeasures = snapshot.userMeasures
+	s.workoutSessions = snapshot.workoutSessions
+	s.workoutSets = snapshot.workoutSets
+	s.deadLetters = snapshot.deadLetters
+	s.lastUserMessages = snapshot.lastUserMessages
+	s.conversations = snapshot.conversations
+	s.restTimers = snapshot.restTimers
+	s.callbackStates = snapshot.callbackStates
+}
+
+func (s *Store) deleteUser(id int64) {
+	delete(s.users, id)
+
+	for measureId, userMeasure := range s.userMeasures {
+		if userMeasure.UserId == id {
+			delete(s.userMeasures, measureId)
+		}
+	}
+
+	for userProgramId, userProgram := range s.userPrograms {
+		if userProgram.UserId == id {
+			s.deleteUserProgram(userProgramId)
+		}
+	}
+
+	for sessionId, session := range s.workoutSessions {
+		if session.UserId == id {
+			s.deleteWorkoutSession(sessionId)
+		}
+	}
+}
+
+func (s *Store) deleteProgram(id uint) {
+	delete(s.programs, id)
+
+	for exerciseId, exercise := range s.exercises {
+		if exercise.ProgramId == id {
+			s.deleteExercise(exerciseId)
+		}
+	}
+
+	for userProgramId, userProgram := range s.userPrograms {
+		if userProgram.ProgramId == id {
+			s.deleteUserProgram(userProgramId)
+		}
+	}
+}
+
+func (s *Store) deleteExercise(id uint) {
+	delete(s.exercises, id)
+
+	for resultId, result := range s.userResults {
+		if result.ExerciseId == id {
+			s.deleteUserResult(resultId)
+		}
+	}
+
+	for historyId, history := range s.userResultHistories {
+		if history.ExerciseId == id {
+			delete(s.userResultHistories, historyId)
+		}
+	}
+
+	for setId, set := range s.workoutSets {
+		if set.ExerciseId == id {
+			delete(s.workoutSets, setId)
+		}
+	}
+}
+
+func (s *Store) deleteMeasure(id uint) {
+	delete(s.measures, id)
+
+	for userMeasureId, userMeasure := range s.userMeasures {
+		if userMeasure.MeasureId == id {
+			delete(s.userMeasures, userMeasureId)
+		}
+	}
+}
+
+// user_results has no foreign key to user_programs, so results outlive their program on purpose.
+func (s *Store) deleteUserProgram(id uint) {
+	delete(s.userPrograms, id)
+
+	for sessionId, session := range s.workoutSessions {
+		if session.UserProgramId == id {
+			s.deleteWorkoutSession(sessionId)
+		}
+	}
+}
+
+func (s *Store) deleteUserResult(id uint) {
+	delete(s.userResults, id)
+
+	for historyId, history := range s.userResultHistories {
+		if history.UserResultId == id {
+			delete(s.userResultHistories, historyId)
+		}
+	}
+}
+
+func (s *Store) deleteWorkoutSession(id uint) {
+	delete(s.workoutSessions, id)
+
+	for setId, set := range s.workoutSets {
+		if set.WorkoutSessionId == id {
+			delete(s.workoutSets, setId)
+		}
+	}
+}
+
+func (s *Store) programWithExercises(id uint) (models.Program, bool) {
+	program, ok := s.programs[id]
+
+	if !ok {
+		return program, false
+	}
+
+	program.Exercises = filter(s.exercises, byProgramId(id), exerciseLess)
+
+	return program, true
+}
+
+func duplicatedKey(constraint string) error {
+	return fmt.Errorf("%w: %w: %s", repositories.ErrConflict, gorm.ErrDuplicatedKey, constraint)
+}
+
+func foreignKeyViolated(constraint string) error {
+	return fmt.Errorf("%w: %w: %s", repositories.ErrConflict, gorm.ErrForeignKeyViolated, constraint)
+}
+
+func cloneMap[K comparable, V any](m map[K]V) map[K]V {
+	result := make(map[K]V, len(m))
+
+	for key, value := range m {
+		result[key] = value
+	}
+
+	return result
+}
+
+func cloneData(data map[string]string) map[string]string {
+	if data == nil {
+		return nil
+	}
+
+	return cloneMap(data)
+}
+
+func filter[K comparable, V any](m map[K]V, match func(V) bool, less func(a, b V) bool) []V {
+	var result []V
+
+	for _, value := range m {
+		if match(value) {
+			result = append(result, value)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return less(result[i], result[j])
+	})
+
+	return result
+}
+
+func count[K comparable, V any](m map[K]V, match func(V) bool) int64 {
+	var result int64
+
+	for _, value := range m {
+		if match(value) {
+			result++
+		}
+	}
+
+	return result
+}
+
+// paginate follows GORM: a negative limit disables the limit and a non-positive offset is ignored.
+func paginate[T any](items []T, limit, offset int) []T {
+	if offset > 0 {
+		if offset >= len(items) {
+			return nil
+		}
+
+		items = items[offset:]
+	}
+
+	if limit >= 0 && limit < len(items) {
+		items = items[:limit]
+	}
+
+	return items
+}
+
+func all[V any](V) bool {
+	return true
+}
+
+type beforeCreateHook interface {
+	BeforeCreate(tx *gorm.DB) error
+}
+
+type beforeUpdateHook interface {
+	BeforeUpdate(tx *gorm.DB) error
+}
+
+func beforeCreate(model any) {
+	if hook, ok := model.(beforeCreateHook); ok {
+		hook.BeforeCreate(nil)
+	}
+}
+
+// applyUpdates mirrors GORM's Updates with a struct: only non-zero columns are written,
+// associations and the primary key are left alone, and UpdatedAt is refreshed.
+func applyUpdates[T any](dst *T, src T) {
+	if hook, ok := any(&src).(beforeUpdateHook); ok {
+		hook.BeforeUpdate(nil)
+	}
+
+	dstValue := reflect.ValueOf(dst).Elem()
+	srcValue := reflect.ValueOf(src)
+	timeType := reflect.TypeOf(time.Time{})
+
+	for i := 0; i < srcValue.NumField(); i++ {
+		field := srcValue.Type().Field(i)
+		value := srcValue.Field(i)
+
+		if field.Name == "Id" || value.IsZero() {
+			continue
+		}
+
+		switch field.Type.Kind() {
+		case reflect.Slice:
+			continue
+		case reflect.Struct:
+			if field.Type != timeType {
+				continue
+			}
+		case reflect.Ptr:
+			if field.Type.Elem().Kind() == reflect.Struct && field.Type.Elem() != timeType {
+				continue
+			}
+		}
+
+		dstValue.Field(i).Set(value)
+	}
+
+	if updatedAt := dstValue.FieldByName("UpdatedAt"); updatedAt.IsValid() {
+		updatedAt.Set(reflect.ValueOf(time.Now()))
+	}
+}
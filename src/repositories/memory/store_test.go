@@ -0,0 +1,300 @@
+package memory_repositories
+
+import (
+	"context"
+	"errors"
+	"rezvin-pro-bot/src/models"
+	"rezvin-pro-bot/src/repositories"
+	"strings"
+	"testing"
+)
+
+func TestConflicts(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		constraint string
+		run        func(store *Store) error
+	}{
+		{
+			name:       "duplicate program name",
+			constraint: "uni_programs_name",
+			run: func(store *Store) error {
+				programs := &programRepository{store: store}
+
+				if _, err := programs.Create(ctx, models.Program{Name: "Base"}); err != nil {
+					return err
+				}
+
+				_, err := programs.Create(ctx, models.Program{Name: "Base"})
+
+				return err
+			},
+		},
+		{
+			name:       "program renamed to an existing name",
+			constraint: "uni_programs_name",
+			run: func(store *Store) error {
+				programs := &programRepository{store: store}
+
+				if _, err := programs.Create(ctx, models.Program{Name: "Base"}); err != nil {
+					return err
+				}
+
+				id, err := programs.Create(ctx, models.Program{Name: "Split"})
+
+				if err != nil {
+					return err
+				}
+
+				return programs.UpdateById(ctx, id, models.Program{Name: "Base"})
+			},
+		},
+		{
+			name:       "duplicate exercise name in a program",
+			constraint: "idx_exercise",
+			run: func(store *Store) error {
+				programId, err := (&programRepository{store: store}).Create(ctx, models.Program{Name: "Base"})
+
+				if err != nil {
+					return err
+				}
+
+				exercises := &exerciseRepository{store: store}
+
+				if _, err = exercises.Create(ctx, models.Exercise{Name: "Squat", ProgramId: programId}); err != nil {
+					return err
+				}
+
+				_, err = exercises.Create(ctx, models.Exercise{Name: "Squat", ProgramId: programId})
+
+				return err
+			},
+		},
+		{
+			name:       "exercise in a missing program",
+			constraint: "fk_programs_exercises",
+			run: func(store *Store) error {
+				_, err := (&exerciseRepository{store: store}).Create(ctx, models.Exercise{Name: "Squat", ProgramId: 42})
+
+				return err
+			},
+		},
+		{
+			name:       "duplicate measure name",
+			constraint: "uni_measures_name",
+			run: func(store *Store) error {
+				measures := &measureRepository{store: store}
+
+				if _, err := measures.Create(ctx, models.Measure{Name: "Weight", Units: "kg"}); err != nil {
+					return err
+				}
+
+				_, err := measures.Create(ctx, models.Measure{Name: "Weight", Units: "kg"})
+
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.run(NewStore())
+
+			if !errors.Is(err, repositories.ErrConflict) {
+				t.Fatalf("got %v, want %v", err, repositories.ErrConflict)
+			}
+
+			if !strings.HasSuffix(err.Error(), tt.constraint) {
+				t.Errorf("got %q, want constraint %s", err, tt.constraint)
+			}
+		})
+	}
+}
+
+func TestSameExerciseNameInAnotherProgram(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	programs := &programRepository{store: store}
+	exercises := &exerciseRepository{store: store}
+
+	for _, name := range []string{"Base", "Split"} {
+		programId, err := programs.Create(ctx, models.Program{Name: name})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if _, err = exercises.Create(ctx, models.Exercise{Name: "Squat", ProgramId: programId}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+}
+
+func TestDeleteProgramCascades(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+
+	programs := &programRepository{store: store}
+	exercises := &exerciseRepository{store: store}
+	users := &userRepository{store: store}
+	userPrograms := &userProgramRepository{store: store}
+	sessions := &workoutSessionRepository{store: store}
+	userResults := &userResultRepository{store: store}
+
+	userId, err := users.Create(ctx, models.User{Id: 1, ChatId: 1, FirstName: "Client"})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var programIds [2]uint
+	var exerciseIds [2]uint
+	var userProgramIds [2]uint
+	var sessionIds [2]uint
+
+	for i, name := range []string{"Base", "Split"} {
+		if programIds[i], err = programs.Create(ctx, models.Program{Name: name}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if exerciseIds[i], err = exercises.Create(ctx, models.Exercise{Name: "Squat", ProgramId: programIds[i]}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if userProgramIds[i], err = userPrograms.Create(ctx, models.UserProgram{UserId: userId, ProgramId: programIds[i]}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if sessionIds[i], err = sessions.Create(ctx, models.WorkoutSession{UserId: userId, UserProgramId: userProgramIds[i]}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		err = userResults.Create(ctx, models.UserResult{UserProgramId: userProgramIds[i], ExerciseId: exerciseIds[i], Reps: 6, Weight: 100})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	if err = programs.DeleteById(ctx, programIds[0]); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	deleted := []struct {
+		name string
+		get  func(i int) error
+	}{
+		{"program", func(i int) error { _, err := programs.GetById(ctx, programIds[i]); return err }},
+		{"exercise", func(i int) error { _, err := exercises.GetById(ctx, exerciseIds[i]); return err }},
+		{"user program", func(i int) error { _, err := userPrograms.GetById(ctx, userProgramIds[i]); return err }},
+		{"workout session", func(i int) error { _, err := sessions.GetById(ctx, sessionIds[i]); return err }},
+	}
+
+	for _, tt := range deleted {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.get(0); !errors.Is(err, repositories.ErrNotFound) {
+				t.Errorf("expected deleted program %s to be gone, got %v", tt.name, err)
+			}
+
+			if err := tt.get(1); err != nil {
+				t.Errorf("expected other program %s to survive, got %v", tt.name, err)
+			}
+		})
+	}
+
+	results, err := userResults.GetAllByExerciseId(ctx, exerciseIds[0])
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(results) != 0 {
+		t.Errorf("expected results of deleted exercises to be gone, got %d", len(results))
+	}
+
+	if _, err = users.GetById(ctx, userId); err != nil {
+		t.Errorf("expected user to survive, got %v", err)
+	}
+}
+
+func TestUpdateWeightRollsBackWithHistory(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+
+	programId, err := (&programRepository{store: store}).Create(ctx, models.Program{Name: "Base"})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	exerciseId, err := (&exerciseRepository{store: store}).Create(ctx, models.Exercise{Name: "Squat", ProgramId: programId})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	userResults := &userResultRepository{store: store}
+	histories := &userResultHistoryRepository{store: store}
+
+	if err = userResults.Create(ctx, models.UserResult{UserProgramId: 1, ExerciseId: exerciseId, Reps: 6, Weight: 80}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	records, err := userResults.GetAllByExerciseId(ctx, exerciseId)
+
+	if err != nil || len(records) != 1 {
+		t.Fatalf("expected one result, got %d and %v", len(records), err)
+	}
+
+	resultId := records[0].Id
+
+	tests := []struct {
+		name        string
+		exerciseId  uint
+		wantErr     error
+		wantWeight  float64
+		wantHistory int64
+	}{
+		{"history rejected", exerciseId + 1, repositories.ErrConflict, 80, 0},
+		{"history written", exerciseId, nil, 100, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := userResults.UpdateWeight(ctx, models.UserResultHistory{
+				UserResultId:  resultId,
+				UserId:        1,
+				UserProgramId: 1,
+				ExerciseId:    tt.exerciseId,
+				Reps:          6,
+				Weight:        100,
+			})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+
+			record, err := userResults.GetById(ctx, resultId)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if record.Weight != tt.wantWeight {
+				t.Errorf("got weight %v, want %v", record.Weight, tt.wantWeight)
+			}
+
+			count, err := histories.CountByUserResultId(ctx, resultId)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if count != tt.wantHistory {
+				t.Errorf("got %d history rows, want %d", count, tt.wantHistory)
+			}
+		})
+	}
+}
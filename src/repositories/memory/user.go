@@ -0,0 +1,132 @@
+package memory_repositories
+
+import (
+	"context"
+	"go.uber.org/dig"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/models"
+	"rezvin-pro-bot/src/repositories"
+)
+
+type userRepositoryDependencies struct {
+	dig.In
+
+	Store *Store `name:"MemoryStore"`
+}
+
+type userRepository struct {
+	store *Store
+}
+
+func NewUserRepository(deps userRepositoryDependencies) *userRepository {
+	return &userRepository{
+		store: deps.Store,
+	}
+}
+
+func (r *userRepository) CountPendingUsers(ctx context.Context) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return count(r.store.users, isPendingUser), nil
+}
+
+func (r *userRepository) GetPendingUsers(ctx context.Context, limit, offset int) ([]models.User, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return paginate(filter(r.store.users, isPendingUser, userLess), limit, offset), nil
+}
+
+func (r *userRepository) CountClients(ctx context.Context) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return count(r.store.users, isClient), nil
+}
+
+func (r *userRepository) GetClients(ctx context.Context, limit, offset int) ([]models.User, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return paginate(filter(r.store.users, isClient, userLess), limit, offset), nil
+}
+
+func (r *userRepository) GetAdminUsers(ctx context.Context) ([]models.User, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return filter(r.store.users, func(user models.User) bool {
+		return user.IsAdmin
+	}, userLess), nil
+}
+
+func (r *userRepository) Create(ctx context.Context, user models.User) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.users[user.Id]; ok {
+		return 0, duplicatedKey("users_pkey")
+	}
+
+	if user.WeightUnit == "" {
+		user.WeightUnit = constants.DefaultWeightUnit
+	}
+
+	beforeCreate(&user)
+
+	r.store.users[user.Id] = user
+
+	return user.Id, nil
+}
+
+func (r *userRepository) GetById(ctx context.Context, id int64) (*models.User, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	user, ok := r.store.users[id]
+
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+
+	return &user, nil
+}
+
+func (r *userRepository) UpdateById(ctx context.Context, id int64, user models.User) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.users[id]
+
+	if !ok {
+		return nil
+	}
+
+	applyUpdates(&existing, user)
+
+	r.store.users[id] = existing
+
+	return nil
+}
+
+func (r *userRepository) DeleteById(ctx context.Context, id int64) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.deleteUser(id)
+
+	return nil
+}
+
+func isPendingUser(user models.User) bool {
+	return !user.IsApproved && !user.IsAdmin && !user.IsDeclined
+}
+
+func isClient(user models.User) bool {
+	return user.IsApproved && !user.IsAdmin && !user.IsDeclined
+}
+
+func userLess(a, b models.User) bool {
+	return a.CreatedAt.Before(b.CreatedAt) || (a.CreatedAt.Equal(b.CreatedAt) && a.Id < b.Id)
+}
@@ -0,0 +1,135 @@
+package memory_repositories
+
+import (
+	"context"
+	"go.uber.org/dig"
+	"rezvin-pro-bot/src/models"
+	"rezvin-pro-bot/src/repositories"
+)
+
+type userMeasureRepositoryDependencies struct {
+	dig.In
+
+	Store *Store `name:"MemoryStore"`
+}
+
+type userMeasureRepository struct {
+	store *Store
+}
+
+func NewUserMeasureRepository(deps userMeasureRepositoryDependencies) *userMeasureRepository {
+	return &userMeasureRepository{
+		store: deps.Store,
+	}
+}
+
+func (r *userMeasureRepository) Create(ctx context.Context, record models.UserMeasure) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.users[record.UserId]; !ok {
+		return foreignKeyViolated("fk_user_measures_user")
+	}
+
+	if _, ok := r.store.measures[record.MeasureId]; !ok {
+		return foreignKeyViolated("fk_user_measures_measure")
+	}
+
+	record.Id = r.store.nextId("user_measures")
+	record.User = models.User{}
+	record.Measure = models.Measure{}
+
+	beforeCreate(&record)
+
+	r.store.userMeasures[record.Id] = record
+
+	return nil
+}
+
+func (r *userMeasureRepository) GetAllByUserIdAndMeasureId(ctx context.Context, userId int64, measureId uint) ([]models.UserMeasure, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.withMeasures(filter(r.store.userMeasures, func(record models.UserMeasure) bool {
+		return record.UserId == userId && record.MeasureId == measureId
+	}, userMeasureLess)), nil
+}
+
+func (r *userMeasureRepository) GetAllByUserId(ctx context.Context, userId int64) ([]models.UserMeasure, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.withMeasures(filter(r.store.userMeasures, func(record models.UserMeasure) bool {
+		return record.UserId == userId
+	}, func(a, b models.UserMeasure) bool {
+		if a.MeasureId != b.MeasureId {
+			return a.MeasureId < b.MeasureId
+		}
+
+		return userMeasureLess(a, b)
+	})), nil
+}
+
+func (r *userMeasureRepository) GetLastByUserIdAndMeasureId(ctx context.Context, userId int64, measureId uint) (*models.UserMeasure, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	records := filter(r.store.userMeasures, func(record models.UserMeasure) bool {
+		return record.UserId == userId && record.MeasureId == measureId
+	}, userMeasureLess)
+
+	if len(records) == 0 {
+		return nil, repositories.ErrNotFound
+	}
+
+	return &records[len(records)-1], nil
+}
+
+func (r *userMeasureRepository) GetById(ctx context.Context, id uint) (*models.UserMeasure, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	record, ok := r.store.userMeasures[id]
+
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+
+	record.Measure = r.store.measures[record.MeasureId]
+
+	return &record, nil
+}
+
+func (r *userMeasureRepository) DeleteById(ctx context.Context, id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.userMeasures, id)
+
+	return nil
+}
+
+func (r *userMeasureRepository) DeleteByMeasureId(ctx context.Context, measureId uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for id, record := range r.store.userMeasures {
+		if record.MeasureId == measureId {
+			delete(r.store.userMeasures, id)
+		}
+	}
+
+	return nil
+}
+
+func (r *userMeasureRepository) withMeasures(records []models.UserMeasure) []models.UserMeasure {
+	for i := range records {
+		records[i].Measure = r.store.measures[records[i].MeasureId]
+	}
+
+	return records
+}
+
+func userMeasureLess(a, b models.UserMeasure) bool {
+	return a.CreatedAt.Before(b.CreatedAt) || (a.CreatedAt.Equal(b.CreatedAt) && a.Id < b.Id)
+}
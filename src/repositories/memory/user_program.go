@@ -0,0 +1,164 @@
+package memory_repositories
+
+import (
+	"context"
+	"go.uber.org/dig"
+	"rezvin-pro-bot/src/models"
+	"rezvin-pro-bot/src/repositories"
+)
+
+type userProgramRepositoryDependencies struct {
+	dig.In
+
+	Store *Store `name:"MemoryStore"`
+}
+
+type userProgramRepository struct {
+	store *Store
+}
+
+func NewUserProgramRepository(deps userProgramRepositoryDependencies) *userProgramRepository {
+	return &userProgramRepository{
+		store: deps.Store,
+	}
+}
+
+func (r *userProgramRepository) Create(ctx context.Context, userProgram models.UserProgram) (uint, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.users[userProgram.UserId]; !ok {
+		return 0, foreignKeyViolated("fk_user_programs_user")
+	}
+
+	if _, ok := r.store.programs[userProgram.ProgramId]; !ok {
+		return 0, foreignKeyViolated("fk_user_programs_program")
+	}
+
+	for _, existing := range r.store.userPrograms {
+		if existing.UserId == userProgram.UserId && existing.ProgramId == userProgram.ProgramId {
+			return 0, duplicatedKey("idx_user_program")
+		}
+	}
+
+	userProgram.Id = r.store.nextId("user_programs")
+	userProgram.User = models.User{}
+	userProgram.Program = models.Program{}
+
+	beforeCreate(&userProgram)
+
+	r.store.userPrograms[userProgram.Id] = userProgram
+
+	return userProgram.Id, nil
+}
+
+func (r *userProgramRepository) GetById(ctx context.Context, id uint) (*models.UserProgram, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	userProgram, ok := r.store.userPrograms[id]
+
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+
+	userProgram = r.withProgram(userProgram)
+
+	return &userProgram, nil
+}
+
+func (r *userProgramRepository) GetAllByProgramId(ctx context.Context, programId uint) ([]models.UserProgram, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	userPrograms := filter(r.store.userPrograms, func(userProgram models.UserProgram) bool {
+		return userProgram.ProgramId == programId
+	}, userProgramLess)
+
+	for i := range userPrograms {
+		userPrograms[i].User = r.store.users[userPrograms[i].UserId]
+	}
+
+	return userPrograms, nil
+}
+
+func (r *userProgramRepository) CountAllByUserId(ctx context.Context, userId int64) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return count(r.store.userPrograms, byUserId(userId)), nil
+}
+
+func (r *userProgramRepository) GetByUserIdAndProgramId(ctx context.Context, userId int64, programId uint) (*models.UserProgram, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, userProgram := range r.store.userPrograms {
+		if userProgram.UserId == userId && userProgram.ProgramId == programId {
+			userProgram = r.withProgram(userProgram)
+			return &userProgram, nil
+		}
+	}
+
+	return nil, repositories.ErrNotFound
+}
+
+func (r *userProgramRepository) GetByUserId(ctx context.Context, userId int64, limit, offset int) ([]models.UserProgram, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.withPrograms(paginate(filter(r.store.userPrograms, byUserId(userId), userProgramLess), limit, offset)), nil
+}
+
+func (r *userProgramRepository) GetAllByUserId(ctx context.Context, userId int64) ([]models.UserProgram, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.withPrograms(filter(r.store.userPrograms, byUserId(userId), userProgramLess)), nil
+}
+
+func (r *userProgramRepository) DeleteById(ctx context.Context, id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.deleteUserProgram(id)
+
+	return nil
+}
+
+func (r *userProgramRepository) DeleteByUserIdAndProgramId(ctx context.Context, userId int64, programId uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for id, userProgram := range r.store.userPrograms {
+		if userProgram.UserId == userId && userProgram.ProgramId == programId {
+			r.store.deleteUserProgram(id)
+		}
+	}
+
+	return nil
+}
+
+func (r *userProgramRepository) withProgram(userProgram models.UserProgram) models.UserProgram {
+	userProgram.Program = r.store.programs[userProgram.ProgramId]
+
+	return userProgram
+}
+
+func (r *userProgramRepository) withPrograms(userPrograms []models.UserProgram) []models.UserProgram {
+	for i := range userPrograms {
+		userPrograms[i] = r.withProgram(userPrograms[i])
+	}
+
+	return userPrograms
+}
+
+func byUserId(userId int64) func(models.UserProgram) bool {
+	return func(userProgram models.UserProgram) bool {
+		return userProgram.UserId == userId
+	}
+}
+
+func userProgramLess(a, b models.UserProgram) bool {
+	return a.CreatedAt.Before(b.CreatedAt) || (a.CreatedAt.Equal(b.CreatedAt) && a.Id < b.Id)
+}
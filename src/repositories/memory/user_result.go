@@ -0,0 +1,290 @@
+package memory_repositories
+
+import (
+	"context"
+	"go.uber.org/dig"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/models"
+	"rezvin-pro-bot/src/repositories"
+	"slices"
+)
+
+type userResultRepositoryDependencies struct {
+	dig.In
+
+	Store *Store `name:"MemoryStore"`
+}
+
+type userResultRepository struct {
+	store *Store
+}
+
+func NewUserResultRepository(deps userResultRepositoryDependencies) *userResultRepository {
+	return &userResultRepository{
+		store: deps.Store,
+	}
+}
+
+func (r *userResultRepository) Create(ctx context.Context, record models.UserResult) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.create(record)
+}
+
+func (r *userResultRepository) CreateMany(ctx context.Context, records []models.UserResult) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	return r.store.transaction(func() error {
+		for _, record := range records {
+			if err := r.create(record); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *userResultRepository) CountAllByUserProgramId(ctx context.Context, userProgramId uint) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return count(r.store.userResults, byUserProgramId(userProgramId)), nil
+}
+
+func (r *userResultRepository) GetById(ctx context.Context, id uint) (*models.UserResult, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	record, ok := r.store.userResults[id]
+
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+
+	record.Exercise = r.store.exercises[record.ExerciseId]
+
+	return &record, nil
+}
+
+func (r *userResultRepository) GetAllByUserProgramId(ctx context.Context, userProgramId uint) ([]models.UserResult, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.withExercises(filter(r.store.userResults, byUserProgramId(userProgramId), userResultLess)), nil
+}
+
+func (r *userResultRepository) GetAllByUserProgramIdAndExerciseId(
+	ctx context.Context,
+	userProgramId, exerciseId uint,
+) ([]models.UserResult, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.withExercises(filter(r.store.userResults, func(record models.UserResult) bool {
+		return record.UserProgramId == userProgramId && record.ExerciseId == exerciseId
+	}, userResultLess)), nil
+}
+
+func (r *userResultRepository) GetAllByExerciseId(ctx context.Context, exerciseId uint) ([]models.UserResult, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.withExercises(filter(r.store.userResults, func(record models.UserResult) bool {
+		return record.ExerciseId == exerciseId
+	}, userResultLess)), nil
+}
+
+func (r *userResultRepository) GetByUserProgramId(
+	ctx context.Context,
+	userProgramId uint,
+	limit, offset int,
+) ([]models.UserResult, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.withExercises(paginate(filter(r.store.userResults, byUserProgramId(userProgramId), userResultLess), limit, offset)), nil
+}
+
+func (r *userResultRepository) UpdateById(ctx context.Context, id uint, record models.UserResult) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.userResults[id]
+
+	if !ok {
+		return nil
+	}
+
+	applyUpdates(&existing, record)
+
+	if err := r.check(existing); err != nil {
+		return err
+	}
+
+	r.store.userResults[id] = existing
+
+	return nil
+}
+
+func (r *userResultRepository) UpdateWeight(ctx context.Context, history models.UserResultHistory) error {
+	return r.store.transaction(func() error {
+		if existing, ok := r.store.userResults[history.UserResultId]; ok {
+			existing.Weight = history.Weight
+			r.store.userResults[existing.Id] = existing
+		}
+
+		return (&userResultHistoryRepository{store: r.store}).create(history)
+	})
+}
+
+// user_results has no user_id column, the owner is resolved through user_programs.
+func (r *userResultRepository) UpdateByUserIdAndExerciseId(
+	ctx context.Context, userId int64,
+	exerciseId uint,
+	record models.UserResult,
+) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for id, existing := range r.store.userResults {
+		if existing.ExerciseId != exerciseId || r.store.userPrograms[existing.UserProgramId].UserId != userId {
+			continue
+		}
+
+		applyUpdates(&existing, record)
+
+		r.store.userResults[id] = existing
+	}
+
+	return nil
+}
+
+func (r *userResultRepository) DeleteByUserProgramId(ctx context.Context, userProgramId uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for id, record := range r.store.userResults {
+		if record.UserProgramId == userProgramId {
+			r.store.deleteUserResult(id)
+		}
+	}
+
+	return nil
+}
+
+func (r *userResultRepository) DeleteByExerciseId(ctx context.Context, exerciseId uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for id, record := range r.store.userResults {
+		if record.ExerciseId == exerciseId {
+			r.store.deleteUserResult(id)
+		}
+	}
+
+	return nil
+}
+
+func (r *userResultRepository) SyncReps(ctx context.Context, userProgramId, exerciseId uint, reps []constants.Reps) error {
+	return r.store.transaction(func() error {
+		return r.syncReps(userProgramId, exerciseId, reps)
+	})
+}
+
+func (r *userResultRepository) syncReps(userProgramId, exerciseId uint, reps []constants.Reps) error {
+	if len(reps) == 0 {
+		return nil
+	}
+
+	repsValues := make([]uint, 0, len(reps))
+
+	for _, rep := range reps {
+		repsValues = append(repsValues, uint(rep))
+
+		if r.find(userProgramId, exerciseId, uint(rep)) != nil {
+			continue
+		}
+
+		err := r.create(models.UserResult{
+			UserProgramId: userProgramId,
+			ExerciseId:    exerciseId,
+			Weight:        0,
+			Reps:          uint(rep),
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	for id, record := range r.store.userResults {
+		if record.UserProgramId != userProgramId || record.ExerciseId != exerciseId || record.Weight != 0 {
+			continue
+		}
+
+		if !slices.Contains(repsValues, record.Reps) {
+			r.store.deleteUserResult(id)
+		}
+	}
+
+	return nil
+}
+
+func (r *userResultRepository) create(record models.UserResult) error {
+	record.Id = r.store.nextId("user_results")
+	record.Exercise = models.Exercise{}
+
+	if err := r.check(record); err != nil {
+		return err
+	}
+
+	beforeCreate(&record)
+
+	r.store.userResults[record.Id] = record
+
+	return nil
+}
+
+func (r *userResultRepository) check(record models.UserResult) error {
+	if _, ok := r.store.exercises[record.ExerciseId]; !ok {
+		return foreignKeyViolated("fk_user_results_exercise")
+	}
+
+	if existing := r.find(record.UserProgramId, record.ExerciseId, record.Reps); existing != nil && existing.Id != record.Id {
+		return duplicatedKey("idx_record")
+	}
+
+	return nil
+}
+
+func (r *userResultRepository) find(userProgramId, exerciseId uint, reps uint) *models.UserResult {
+	for _, record := range r.store.userResults {
+		if record.UserProgramId == userProgramId && record.ExerciseId == exerciseId && record.Reps == reps {
+			return &record
+		}
+	}
+
+	return nil
+}
+
+func (r *userResultRepository) withExercises(records []models.UserResult) []models.UserResult {
+	for i := range records {
+		records[i].Exercise = r.store.exercises[records[i].ExerciseId]
+	}
+
+	return records
+}
+
+func byUserProgramId(userProgramId uint) func(models.UserResult) bool {
+	return func(record models.UserResult) bool {
+		return record.UserProgramId == userProgramId
+	}
+}
+
+func userResultLess(a, b models.UserResult) bool {
+	return a.Reps < b.Reps || (a.Reps == b.Reps && a.Id < b.Id)
+}
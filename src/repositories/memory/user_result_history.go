@@ -0,0 +1,159 @@
+package memory_repositories
+
+import (
+	"context"
+	"go.uber.org/dig"
+	"rezvin-pro-bot/src/models"
+)
+
+type userResultHistoryRepositoryDependencies struct {
+	dig.In
+
+	Store *Store `name:"MemoryStore"`
+}
+
+type userResultHistoryRepository struct {
+	store *Store
+}
+
+func NewUserResultHistoryRepository(deps userResultHistoryRepositoryDependencies) *userResultHistoryRepository {
+	return &userResultHistoryRepository{
+		store: deps.Store,
+	}
+}
+
+func (r *userResultHistoryRepository) Create(ctx context.Context, record models.UserResultHistory) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.create(record)
+}
+
+func (r *userResultHistoryRepository) create(record models.UserResultHistory) error {
+	if _, ok := r.store.userResults[record.UserResultId]; !ok {
+		return foreignKeyViolated("fk_user_result_histories_user_result")
+	}
+
+	if _, ok := r.store.exercises[record.ExerciseId]; !ok {
+		return foreignKeyViolated("fk_user_result_histories_exercise")
+	}
+
+	record.Id = r.store.nextId("user_result_histories")
+	record.UserResult = models.UserResult{}
+	record.Exercise = models.Exercise{}
+
+	beforeCreate(&record)
+
+	r.store.userResultHistories[record.Id] = record
+
+	return nil
+}
+
+func (r *userResultHistoryRepository) CountByUserResultId(ctx context.Context, userResultId uint) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return count(r.store.userResultHistories, byUserResultId(userResultId)), nil
+}
+
+func (r *userResultHistoryRepository) GetByUserResultId(
+	ctx context.Context,
+	userResultId uint,
+	limit, offset int,
+) ([]models.UserResultHistory, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	records := paginate(filter(r.store.userResultHistories, byUserResultId(userResultId), func(a, b models.UserResultHistory) bool {
+		return loggedBefore(b, a)
+	}), limit, offset)
+
+	return r.withExercises(records), nil
+}
+
+func (r *userResultHistoryRepository) GetBestWeight(ctx context.Context, userId int64, exerciseId uint, reps uint) (float64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var best float64
+
+	for _, record := range r.store.userResultHistories {
+		if record.UserId == userId && record.ExerciseId == exerciseId && record.Reps == reps && record.Weight > best {
+			best = record.Weight
+		}
+	}
+
+	return best, nil
+}
+
+// GetRecordsByUserId mirrors DISTINCT ON (exercise_id, reps): the heaviest entry wins and ties go to the earliest one.
+func (r *userResultHistoryRepository) GetRecordsByUserId(ctx context.Context, userId int64) ([]models.UserResultHistory, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	sorted := filter(r.store.userResultHistories, func(record models.UserResultHistory) bool {
+		return record.UserId == userId && record.Weight > 0
+	}, func(a, b models.UserResultHistory) bool {
+		if a.ExerciseId != b.ExerciseId {
+			return a.ExerciseId < b.ExerciseId
+		}
+
+		if a.Reps != b.Reps {
+			return a.Reps < b.Reps
+		}
+
+		if a.Weight != b.Weight {
+			return a.Weight > b.Weight
+		}
+
+		return loggedBefore(a, b)
+	})
+
+	var records []models.UserResultHistory
+
+	for i, record := range sorted {
+		if i > 0 && sorted[i-1].ExerciseId == record.ExerciseId && sorted[i-1].Reps == record.Reps {
+			continue
+		}
+
+		records = append(records, record)
+	}
+
+	return r.withExercises(records), nil
+}
+
+func (r *userResultHistoryRepository) GetAllByUserIdAndExerciseId(ctx context.Context, userId int64, exerciseId uint) ([]models.UserResultHistory, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return filter(r.store.userResultHistories, func(record models.UserResultHistory) bool {
+		return record.UserId == userId && record.ExerciseId == exerciseId
+	}, loggedBefore), nil
+}
+
+func (r *userResultHistoryRepository) GetAllByUserId(ctx context.Context, userId int64) ([]models.UserResultHistory, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.withExercises(filter(r.store.userResultHistories, func(record models.UserResultHistory) bool {
+		return record.UserId == userId
+	}, loggedBefore)), nil
+}
+
+func (r *userResultHistoryRepository) withExercises(records []models.UserResultHistory) []models.UserResultHistory {
+	for i := range records {
+		records[i].Exercise = r.store.exercises[records[i].ExerciseId]
+	}
+
+	return records
+}
+
+func byUserResultId(userResultId uint) func(models.UserResultHistory) bool {
+	return func(record models.UserResultHistory) bool {
+		return record.UserResultId == userResultId
+	}
+}
+
+func loggedBefore(a, b models.UserResultHistory) bool {
+	return a.LoggedAt.Before(b.LoggedAt) || (a.LoggedAt.Equal(b.LoggedAt) && a.Id < b.Id)
+}
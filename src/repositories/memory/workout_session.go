@@ -0,0 +1,157 @@
+package memory_repositories
+
+import (
+	"context"
+	"go.uber.org/dig"
+	"rezvin-pro-bot/src/models"
+	"rezvin-pro-bot/src/repositories"
+)
+
+type workoutSessionRepositoryDependencies struct {
+	dig.In
+
+	Store *Store `name:"MemoryStore"`
+}
+
+type workoutSessionRepository struct {
+	store *Store
+}
+
+func NewWorkoutSessionRepository(deps workoutSessionRepositoryDependencies) *workoutSessionRepository {
+	return &workoutSessionRepository{
+		store: deps.Store,
+	}
+}
+
+func (r *workoutSessionRepository) Create(ctx context.Context, session models.WorkoutSession) (uint, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.users[session.UserId]; !ok {
+		return 0, foreignKeyViolated("fk_workout_sessions_user")
+	}
+
+	if _, ok := r.store.userPrograms[session.UserProgramId]; !ok {
+		return 0, foreignKeyViolated("fk_workout_sessions_user_program")
+	}
+
+	session.Id = r.store.nextId("workout_sessions")
+	session.User = models.User{}
+	session.UserProgram = models.UserProgram{}
+	session.Sets = nil
+
+	beforeCreate(&session)
+
+	r.store.workoutSessions[session.Id] = session
+
+	return session.Id, nil
+}
+
+func (r *workoutSessionRepository) GetById(ctx context.Context, id uint) (*models.WorkoutSession, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	session, ok := r.store.workoutSessions[id]
+
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+
+	session = r.withUserProgram(session)
+
+	session.Sets = filter(r.store.workoutSets, func(set models.WorkoutSet) bool {
+		return set.WorkoutSessionId == id
+	}, func(a, b models.WorkoutSet) bool {
+		return a.Id < b.Id
+	})
+
+	for i := range session.Sets {
+		session.Sets[i].Exercise = r.store.exercises[session.Sets[i].ExerciseId]
+	}
+
+	return &session, nil
+}
+
+func (r *workoutSessionRepository) GetActiveByUserId(ctx context.Context, userId int64) (*models.WorkoutSession, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	sessions := filter(r.store.workoutSessions, func(session models.WorkoutSession) bool {
+		return session.UserId == userId && session.FinishedAt == nil
+	}, startedLater)
+
+	if len(sessions) == 0 {
+		return nil, repositories.ErrNotFound
+	}
+
+	session := r.withUserProgram(sessions[0])
+
+	return &session, nil
+}
+
+func (r *workoutSessionRepository) CountFinishedByUserId(ctx context.Context, userId int64) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return count(r.store.workoutSessions, finishedBy(userId)), nil
+}
+
+func (r *workoutSessionRepository) GetFinishedByUserId(
+	ctx context.Context,
+	userId int64,
+	limit, offset int,
+) ([]models.WorkoutSession, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	sessions := paginate(filter(r.store.workoutSessions, finishedBy(userId), startedLater), limit, offset)
+
+	for i := range sessions {
+		sessions[i] = r.withUserProgram(sessions[i])
+	}
+
+	return sessions, nil
+}
+
+func (r *workoutSessionRepository) UpdateById(ctx context.Context, id uint, session models.WorkoutSession) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.workoutSessions[id]
+
+	if !ok {
+		return nil
+	}
+
+	applyUpdates(&existing, session)
+
+	r.store.workoutSessions[id] = existing
+
+	return nil
+}
+
+func (r *workoutSessionRepository) DeleteById(ctx context.Context, id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.deleteWorkoutSession(id)
+
+	return nil
+}
+
+func (r *workoutSessionRepository) withUserProgram(session models.WorkoutSession) models.WorkoutSession {
+	session.UserProgram = r.store.userPrograms[session.UserProgramId]
+	session.UserProgram.Program = r.store.programs[session.UserProgram.ProgramId]
+
+	return session
+}
+
+func finishedBy(userId int64) func(models.WorkoutSession) bool {
+	return func(session models.WorkoutSession) bool {
+		return session.UserId == userId && session.FinishedAt != nil
+	}
+}
+
+func startedLater(a, b models.WorkoutSession) bool {
+	return a.StartedAt.After(b.StartedAt) || (a.StartedAt.Equal(b.StartedAt) && a.Id > b.Id)
+}
@@ -0,0 +1,59 @@
+package memory_repositories
+
+import (
+	"context"
+	"go.uber.org/dig"
+	"rezvin-pro-bot/src/models"
+)
+
+type workoutSetRepositoryDependencies struct {
+	dig.In
+
+	Store *Store `name:"MemoryStore"`
+}
+
+type workoutSetRepository struct {
+	store *Store
+}
+
+func NewWorkoutSetRepository(deps workoutSetRepositoryDependencies) *workoutSetRepository {
+	return &workoutSetRepository{
+		store: deps.Store,
+	}
+}
+
+func (r *workoutSetRepository) Create(ctx context.Context, set models.WorkoutSet) (uint, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.workoutSessions[set.WorkoutSessionId]; !ok {
+		return 0, foreignKeyViolated("fk_workout_sessions_sets")
+	}
+
+	if _, ok := r.store.exercises[set.ExerciseId]; !ok {
+		return 0, foreignKeyViolated("fk_workout_sets_exercise")
+	}
+
+	set.Id = r.store.nextId("workout_sets")
+	set.Exercise = models.Exercise{}
+
+	beforeCreate(&set)
+
+	r.store.workoutSets[set.Id] = set
+
+	return set.Id, nil
+}
+
+func (r *workoutSetRepository) GetAllByWorkoutSessionIdAndExerciseId(
+	ctx context.Context,
+	workoutSessionId, exerciseId uint,
+) ([]models.WorkoutSet, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return filter(r.store.workoutSets, func(set models.WorkoutSet) bool {
+		return set.WorkoutSessionId == workoutSessionId && set.ExerciseId == exerciseId
+	}, func(a, b models.WorkoutSet) bool {
+		return a.SetNumber < b.SetNumber || (a.SetNumber == b.SetNumber && a.Id < b.Id)
+	}), nil
+}
@@ -5,29 +5,29 @@ import (
 	"go.uber.org/dig"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
-	"rezvin-pro-bot/src/config"
 	"rezvin-pro-bot/src/internal/db"
 	"rezvin-pro-bot/src/models"
-	"rezvin-pro-bot/src/utils"
+	"rezvin-pro-bot/src/utils/importer"
+	"rezvin-pro-bot/src/utils/rep_scheme"
 )
 
 type IProgramRepository interface {
-	Create(ctx context.Context, program models.Program) uint
-	GetById(ctx context.Context, id uint) *models.Program
-	CountAll(ctx context.Context) int64
-	GetAll(ctx context.Context, limit, offset int) []models.Program
-	GetByName(ctx context.Context, name string) *models.Program
-	CountNotAssignedToUser(ctx context.Context, userId int64) int64
-	GetNotAssignedToUser(ctx context.Context, userId int64, limit, offset int) []models.Program
-	UpdateById(ctx context.Context, id uint, program models.Program)
-	DeleteById(ctx context.Context, id uint)
+	Create(ctx context.Context, program models.Program) (uint, error)
+	GetById(ctx context.Context, id uint) (*models.Program, error)
+	CountAll(ctx context.Context) (int64, error)
+	GetAll(ctx context.Context, limit, offset int) ([]models.Program, error)
+	GetByName(ctx context.Context, name string) (*models.Program, error)
+	CountNotAssignedToUser(ctx context.Context, userId int64) (int64, error)
+	GetNotAssignedToUser(ctx context.Context, userId int64, limit, offset int) ([]models.Program, error)
+	UpdateById(ctx context.Context, id uint, program models.Program) error
+	DeleteById(ctx context.Context, id uint) error
+	Import(ctx context.Context, plan importer.Plan) error
 }
 
 type programRepositoryDependencies struct {
 	dig.In
 
-	Database db.IDatabase   `name:"Database"`
-	Config   config.IConfig `name:"Config"`
+	Database db.IDatabase `name:"Database"`
 }
 
 type programRepository struct {
@@ -35,112 +35,197 @@ type programRepository struct {
 }
 
 func NewProgramRepository(deps programRepositoryDependencies) *programRepository {
-	r := &programRepository{
+	return &programRepository{
 		db: deps.Database.GetInstance(),
 	}
-
-	if deps.Config.RunMigrations() {
-		err := r.db.AutoMigrate(&models.Program{})
-
-		utils.PanicIfError(err)
-	}
-
-	return r
 }
 
-func (r *programRepository) CountNotAssignedToUser(ctx context.Context, userId int64) int64 {
+func (r *programRepository) CountNotAssignedToUser(ctx context.Context, userId int64) (int64, error) {
 	var count int64
 
-	subQuery := r.db.WithContext(ctx).Model(&models.UserProgram{}).Select("program_id").Where("user_id = ?", userId)
-
-	err := r.db.WithContext(ctx).Model(&models.Program{}).Where("id NOT IN (?)", subQuery).Count(&count).Error
+	err := execute(ctx, func() error {
+		subQuery := r.db.WithContext(ctx).Model(&models.UserProgram{}).Select("program_id").Where("user_id = ?", userId)
 
-	utils.PanicIfNotContextError(err)
+		return r.db.WithContext(ctx).Model(&models.Program{}).Where("id NOT IN (?)", subQuery).Count(&count).Error
+	})
 
-	return count
+	return count, err
 }
 
-func (r *programRepository) GetNotAssignedToUser(ctx context.Context, userId int64, limit, offset int) []models.Program {
+func (r *programRepository) GetNotAssignedToUser(ctx context.Context, userId int64, limit, offset int) ([]models.Program, error) {
 	var programs []models.Program
 
-	subQuery := r.db.WithContext(ctx).Model(&models.UserProgram{}).Select("program_id").Where("user_id = ?", userId)
-
-	err := r.db.
-		WithContext(ctx).
-		Model(&models.Program{}).
-		Where("id NOT IN (?)", subQuery).
-		Limit(limit).
-		Offset(offset).
-		Find(&programs).
-		Error
+	err := execute(ctx, func() error {
+		subQuery := r.db.WithContext(ctx).Model(&models.UserProgram{}).Select("program_id").Where("user_id = ?", userId)
 
-	utils.PanicIfError(err)
+		return r.db.
+			WithContext(ctx).
+			Model(&models.Program{}).
+			Where("id NOT IN (?)", subQuery).
+			Limit(limit).
+			Offset(offset).
+			Find(&programs).
+			Error
+	})
 
-	return programs
+	return programs, err
 }
 
-func (r *programRepository) Create(ctx context.Context, program models.Program) uint {
-	err := r.db.WithContext(ctx).Create(&program).Error
+func (r *programRepository) Create(ctx context.Context, program models.Program) (uint, error) {
+	err := executeOnce(ctx, func() error {
+		return r.db.WithContext(ctx).Create(&program).Error
+	})
 
-	utils.PanicIfNotContextError(err)
-
-	return program.Id
+	return program.Id, err
 }
 
-func (r *programRepository) CountAll(ctx context.Context) int64 {
+func (r *programRepository) CountAll(ctx context.Context) (int64, error) {
 	var count int64
 
-	err := r.db.WithContext(ctx).Model(&models.Program{}).Count(&count).Error
-
-	utils.PanicIfNotContextError(err)
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).Model(&models.Program{}).Count(&count).Error
+	})
 
-	return count
+	return count, err
 }
 
-func (r *programRepository) GetById(ctx context.Context, id uint) *models.Program {
+func (r *programRepository) GetById(ctx context.Context, id uint) (*models.Program, error) {
 	var program models.Program
-	err := r.db.WithContext(ctx).Clauses(clause.Returning{}).Preload("Exercises").Where("id = ?", id).First(&program).Error
 
-	if err != nil && utils.IsRecordNotFoundError(err) {
-		return nil
-	}
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).Clauses(clause.Returning{}).Preload("Exercises", orderExercises).Where("id = ?", id).First(&program).Error
+	})
 
-	utils.PanicIfNotRecordNotFound(err)
+	if err != nil {
+		return nil, err
+	}
 
-	return &program
+	return &program, nil
 }
 
-func (r *programRepository) GetAll(ctx context.Context, limit, offset int) []models.Program {
+func (r *programRepository) GetAll(ctx context.Context, limit, offset int) ([]models.Program, error) {
 	var programs []models.Program
 
-	err := r.db.WithContext(ctx).Limit(limit).Offset(offset).Find(&programs).Error
-
-	utils.PanicIfNotContextError(err)
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).Limit(limit).Offset(offset).Find(&programs).Error
+	})
 
-	return programs
+	return programs, err
 }
 
-func (r *programRepository) GetByName(ctx context.Context, name string) *models.Program {
+func (r *programRepository) GetByName(ctx context.Context, name string) (*models.Program, error) {
 	var program models.Program
-	err := r.db.WithContext(ctx).Where("name = ?", name).First(&program).Error
 
-	if err != nil && utils.IsRecordNotFoundError(err) {
-		return nil
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).Where("name = ?", name).First(&program).Error
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
-	utils.PanicIfNotRecordNotFound(err)
+	return &program, nil
+}
 
-	return &program
+func (r *programRepository) UpdateById(ctx context.Context, id uint, program models.Program) error {
+	return execute(ctx, func() error {
+		return r.db.WithContext(ctx).Model(&models.Program{}).Where("id = ?", id).Updates(&program).Error
+	})
 }
 
-func (r *programRepository) UpdateById(ctx context.Context, id uint, program models.Program) {
-	err := r.db.WithContext(ctx).Model(&models.Program{}).Where("id = ?", id).Updates(&program).Error
+func (r *programRepository) DeleteById(ctx context.Context, id uint) error {
+	return execute(ctx, func() error {
+		return r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.Program{}).Error
+	})
+}
 
-	utils.PanicIfNotContextError(err)
+// Import applies the plan and syncs the empty results of every affected user program
+// in one transaction, so a failure leaves nothing half imported.
+func (r *programRepository) Import(ctx context.Context, plan importer.Plan) error {
+	return executeOnce(ctx, func() error {
+		return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			for _, change := range plan.Programs {
+				programId := change.ProgramId
+
+				if change.IsNew() {
+					program := models.Program{Name: change.Name, RepScheme: change.RepScheme}
+
+					if err := tx.Create(&program).Error; err != nil {
+						return err
+					}
+
+					programId = program.Id
+				} else if change.RepSchemeChanged() {
+					err := tx.Model(&models.Program{}).Where("id = ?", programId).Update("rep_scheme", change.RepScheme).Error
+
+					if err != nil {
+						return err
+					}
+				}
+
+				for _, exerciseChange := range change.Exercises {
+					if exerciseChange.IsNew() {
+						exercise := models.Exercise{
+							Name:      exerciseChange.Name,
+							ProgramId: programId,
+							RepScheme: exerciseChange.RepScheme,
+							Position:  exerciseChange.Position,
+						}
+
+						if err := tx.Create(&exercise).Error; err != nil {
+							return err
+						}
+
+						continue
+					}
+
+					updates := make(map[string]any)
+
+					if exerciseChange.RepSchemeChanged() {
+						updates["rep_scheme"] = exerciseChange.RepScheme
+					}
+
+					if exerciseChange.PositionChanged() {
+						updates["position"] = exerciseChange.Position
+					}
+
+					err := tx.Model(&models.Exercise{}).Where("id = ?", exerciseChange.ExerciseId).Updates(updates).Error
+
+					if err != nil {
+						return err
+					}
+				}
+
+				if err := syncProgramResults(tx, programId); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	})
 }
 
-func (r *programRepository) DeleteById(ctx context.Context, id uint) {
-	err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.Program{}).Error
+func syncProgramResults(tx *gorm.DB, programId uint) error {
+	var program models.Program
+
+	if err := tx.Preload("Exercises").Where("id = ?", programId).First(&program).Error; err != nil {
+		return err
+	}
+
+	var userPrograms []models.UserProgram
+
+	if err := tx.Where("program_id = ?", programId).Find(&userPrograms).Error; err != nil {
+		return err
+	}
+
+	for _, userProgram := range userPrograms {
+		for _, exercise := range program.Exercises {
+			if err := syncReps(tx, userProgram.Id, exercise.Id, rep_scheme.ForExercise(program, exercise)); err != nil {
+				return err
+			}
+		}
+	}
 
-	utils.PanicIfNotContextError(err)
+	return nil
 }
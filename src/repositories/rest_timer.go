@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"context"
+	"go.uber.org/dig"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"rezvin-pro-bot/src/internal/db"
+	"rezvin-pro-bot/src/models"
+	"time"
+)
+
+type IRestTimerRepository interface {
+	Save(ctx context.Context, timer models.RestTimer) error
+	GetAll(ctx context.Context) ([]models.RestTimer, error)
+	DeleteByChatId(ctx context.Context, chatId int64) error
+	Claim(ctx context.Context, chatId int64, fireAt time.Time) (bool, error)
+}
+
+type restTimerRepositoryDependencies struct {
+	dig.In
+
+	Database db.IDatabase `name:"Database"`
+}
+
+type restTimerRepository struct {
+	db *gorm.DB
+}
+
+func NewRestTimerRepository(deps restTimerRepositoryDependencies) *restTimerRepository {
+	return &restTimerRepository{
+		db: deps.Database.GetInstance(),
+	}
+}
+
+func (r *restTimerRepository) Save(ctx context.Context, timer models.RestTimer) error {
+	return execute(ctx, func() error {
+		return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "chat_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"workout_session_id", "seconds", "fire_at", "created_at"}),
+		}).Create(&timer).Error
+	})
+}
+
+func (r *restTimerRepository) GetAll(ctx context.Context) ([]models.RestTimer, error) {
+	var timers []models.RestTimer
+
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).Order("fire_at").Find(&timers).Error
+	})
+
+	return timers, err
+}
+
+func (r *restTimerRepository) DeleteByChatId(ctx context.Context, chatId int64) error {
+	return execute(ctx, func() error {
+		return r.db.WithContext(ctx).Where("chat_id = ?", chatId).Delete(&models.RestTimer{}).Error
+	})
+}
+
+// Claim deletes the timer only if it is still the one scheduled for fireAt, so exactly one replica notifies the chat.
+func (r *restTimerRepository) Claim(ctx context.Context, chatId int64, fireAt time.Time) (bool, error) {
+	var claimed []models.RestTimer
+
+	err := executeOnce(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Clauses(clause.Returning{}).
+			Where("chat_id = ? AND fire_at = ?", chatId, fireAt).
+			Delete(&claimed).Error
+	})
+
+	return len(claimed) > 0, err
+}
@@ -4,26 +4,24 @@ import (
 	"context"
 	"go.uber.org/dig"
 	"gorm.io/gorm"
-	"rezvin-pro-bot/src/config"
 	"rezvin-pro-bot/src/internal/db"
 	"rezvin-pro-bot/src/models"
-	"rezvin-pro-bot/src/utils"
 )
 
 type userMeasureRepositoryDependencies struct {
 	dig.In
 
-	Database db.IDatabase   `name:"Database"`
-	Config   config.IConfig `name:"Config"`
+	Database db.IDatabase `name:"Database"`
 }
 
 type IUserMeasureRepository interface {
-	Create(ctx context.Context, record models.UserMeasure)
-	GetById(ctx context.Context, id uint) *models.UserMeasure
-	GetAllByUserIdAndMeasureId(ctx context.Context, userId int64, measureId uint) []models.UserMeasure
-	DeleteById(ctx context.Context, id uint)
-	DeleteByMeasureId(ctx context.Context, measureId uint)
-	GetLastByUserIdAndMeasureId(ctx context.Context, userId int64, measureId uint) *models.UserMeasure
+	Create(ctx context.Context, record models.UserMeasure) error
+	GetById(ctx context.Context, id uint) (*models.UserMeasure, error)
+	GetAllByUserIdAndMeasureId(ctx context.Context, userId int64, measureId uint) ([]models.UserMeasure, error)
+	GetAllByUserId(ctx context.Context, userId int64) ([]models.UserMeasure, error)
+	DeleteById(ctx context.Context, id uint) error
+	DeleteByMeasureId(ctx context.Context, measureId uint) error
+	GetLastByUserIdAndMeasureId(ctx context.Context, userId int64, measureId uint) (*models.UserMeasure, error)
 }
 
 type userMeasureRepository struct {
@@ -31,86 +29,97 @@ type userMeasureRepository struct {
 }
 
 func NewUserMeasureRepository(deps userMeasureRepositoryDependencies) *userMeasureRepository {
-	r := &userMeasureRepository{
+	return &userMeasureRepository{
 		db: deps.Database.GetInstance(),
 	}
+}
 
-	if deps.Config.RunMigrations() {
-		err := r.db.AutoMigrate(&models.UserMeasure{})
-
-		utils.PanicIfError(err)
-	}
-
-	return r
+func (r *userMeasureRepository) Create(ctx context.Context, record models.UserMeasure) error {
+	return executeOnce(ctx, func() error {
+		return r.db.WithContext(ctx).Create(&record).Error
+	})
 }
 
-func (r *userMeasureRepository) Create(ctx context.Context, record models.UserMeasure) {
-	err := r.db.WithContext(ctx).Create(&record).Error
+func (r *userMeasureRepository) GetAllByUserIdAndMeasureId(ctx context.Context, userId int64, measureId uint) ([]models.UserMeasure, error) {
+	var records []models.UserMeasure
 
-	utils.PanicIfNotContextError(err)
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Preload("Measure").
+			Where("user_id = ? AND measure_id = ?", userId, measureId).
+			Order("created_at asc").
+			Find(&records).
+			Error
+	})
+
+	return records, err
 }
 
-func (r *userMeasureRepository) GetAllByUserIdAndMeasureId(ctx context.Context, userId int64, measureId uint) []models.UserMeasure {
+func (r *userMeasureRepository) GetAllByUserId(ctx context.Context, userId int64) ([]models.UserMeasure, error) {
 	var records []models.UserMeasure
 
-	err := r.db.WithContext(ctx).
-		Preload("Measure").
-		Where("user_id = ? AND measure_id = ?", userId, measureId).
-		Order("created_at asc").
-		Find(&records).
-		Error
-
-	utils.PanicIfNotContextError(err)
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Preload("Measure").
+			Where("user_id = ?", userId).
+			Order("measure_id asc, created_at asc").
+			Find(&records).
+			Error
+	})
 
-	return records
+	return records, err
 }
 
-func (r *userMeasureRepository) GetLastByUserIdAndMeasureId(ctx context.Context, userId int64, measureId uint) *models.UserMeasure {
+func (r *userMeasureRepository) GetLastByUserIdAndMeasureId(ctx context.Context, userId int64, measureId uint) (*models.UserMeasure, error) {
 	var record models.UserMeasure
 
-	err := r.db.WithContext(ctx).
-		Where("user_id = ? AND measure_id = ?", userId, measureId).
-		Order("created_at desc").
-		First(&record).
-		Error
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Where("user_id = ? AND measure_id = ?", userId, measureId).
+			Order("created_at desc").
+			First(&record).
+			Error
+	})
 
-	if err != nil && utils.IsRecordNotFoundError(err) {
-		return nil
+	if err != nil {
+		return nil, err
 	}
 
-	return &record
+	return &record, nil
 }
 
-func (r *userMeasureRepository) GetById(ctx context.Context, id uint) *models.UserMeasure {
+func (r *userMeasureRepository) GetById(ctx context.Context, id uint) (*models.UserMeasure, error) {
 	var record models.UserMeasure
 
-	err := r.db.WithContext(ctx).
-		Preload("Measure").
-		Where("id = ?", id).
-		First(&record).
-		Error
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Preload("Measure").
+			Where("id = ?", id).
+			First(&record).
+			Error
+	})
 
-	if err != nil && utils.IsRecordNotFoundError(err) {
-		return nil
+	if err != nil {
+		return nil, err
 	}
 
-	return &record
+	return &record, nil
 }
 
-func (r *userMeasureRepository) DeleteById(ctx context.Context, id uint) {
-	err := r.db.WithContext(ctx).
-		Where("id = ?", id).
-		Delete(&models.UserMeasure{}).
-		Error
-
-	utils.PanicIfNotContextError(err)
+func (r *userMeasureRepository) DeleteById(ctx context.Context, id uint) error {
+	return execute(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Where("id = ?", id).
+			Delete(&models.UserMeasure{}).
+			Error
+	})
 }
 
-func (r *userMeasureRepository) DeleteByMeasureId(ctx context.Context, measureId uint) {
-	err := r.db.WithContext(ctx).
-		Where("measure_id = ?", measureId).
-		Delete(&models.UserMeasure{}).
-		Error
-
-	utils.PanicIfNotContextError(err)
+func (r *userMeasureRepository) DeleteByMeasureId(ctx context.Context, measureId uint) error {
+	return execute(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Where("measure_id = ?", measureId).
+			Delete(&models.UserMeasure{}).
+			Error
+	})
 }
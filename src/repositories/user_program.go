@@ -4,28 +4,26 @@ import (
 	"context"
 	"go.uber.org/dig"
 	"gorm.io/gorm"
-	"rezvin-pro-bot/src/config"
 	"rezvin-pro-bot/src/internal/db"
 	"rezvin-pro-bot/src/models"
-	"rezvin-pro-bot/src/utils"
 )
 
 type userProgramRepositoryDependencies struct {
 	dig.In
 
-	Database db.IDatabase   `name:"Database"`
-	Config   config.IConfig `name:"Config"`
+	Database db.IDatabase `name:"Database"`
 }
 
 type IUserProgramRepository interface {
-	Create(ctx context.Context, userProgram models.UserProgram) uint
-	GetById(ctx context.Context, id uint) *models.UserProgram
-	GetAllByProgramId(ctx context.Context, programId uint) []models.UserProgram
-	GetByUserIdAndProgramId(ctx context.Context, userId int64, programId uint) *models.UserProgram
-	CountAllByUserId(ctx context.Context, userId int64) int64
-	GetByUserId(ctx context.Context, userId int64, limit, offset int) []models.UserProgram
-	DeleteById(ctx context.Context, id uint)
-	DeleteByUserIdAndProgramId(ctx context.Context, userId int64, programId uint)
+	Create(ctx context.Context, userProgram models.UserProgram) (uint, error)
+	GetById(ctx context.Context, id uint) (*models.UserProgram, error)
+	GetAllByProgramId(ctx context.Context, programId uint) ([]models.UserProgram, error)
+	GetByUserIdAndProgramId(ctx context.Context, userId int64, programId uint) (*models.UserProgram, error)
+	CountAllByUserId(ctx context.Context, userId int64) (int64, error)
+	GetByUserId(ctx context.Context, userId int64, limit, offset int) ([]models.UserProgram, error)
+	GetAllByUserId(ctx context.Context, userId int64) ([]models.UserProgram, error)
+	DeleteById(ctx context.Context, id uint) error
+	DeleteByUserIdAndProgramId(ctx context.Context, userId int64, programId uint) error
 }
 
 type userProgramRepository struct {
@@ -33,125 +31,132 @@ type userProgramRepository struct {
 }
 
 func NewUserProgramRepository(deps userProgramRepositoryDependencies) *userProgramRepository {
-	r := &userProgramRepository{
+	return &userProgramRepository{
 		db: deps.Database.GetInstance(),
 	}
-
-	if deps.Config.RunMigrations() {
-		err := r.db.AutoMigrate(&models.UserProgram{})
-
-		utils.PanicIfError(err)
-	}
-
-	return r
 }
 
-func (r *userProgramRepository) Create(ctx context.Context, userProgram models.UserProgram) uint {
-	err := r.db.WithContext(ctx).Create(&userProgram).Error
+func (r *userProgramRepository) Create(ctx context.Context, userProgram models.UserProgram) (uint, error) {
+	err := executeOnce(ctx, func() error {
+		return r.db.WithContext(ctx).Create(&userProgram).Error
+	})
 
-	utils.PanicIfNotContextError(err)
-
-	return userProgram.Id
+	return userProgram.Id, err
 }
 
-func (r *userProgramRepository) GetById(ctx context.Context, id uint) *models.UserProgram {
+func (r *userProgramRepository) GetById(ctx context.Context, id uint) (*models.UserProgram, error) {
 	var userProgram models.UserProgram
 
-	err := r.db.WithContext(ctx).
-		Preload("Program").
-		Where("id = ?", id).
-		First(&userProgram).
-		Error
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Preload("Program").
+			Where("id = ?", id).
+			First(&userProgram).
+			Error
+	})
 
-	if err != nil && utils.IsRecordNotFoundError(err) {
-		return nil
+	if err != nil {
+		return nil, err
 	}
 
-	utils.PanicIfNotRecordNotFound(err)
-
-	return &userProgram
+	return &userProgram, nil
 }
 
-func (r *userProgramRepository) GetAllByProgramId(ctx context.Context, programId uint) []models.UserProgram {
+func (r *userProgramRepository) GetAllByProgramId(ctx context.Context, programId uint) ([]models.UserProgram, error) {
 	var userPrograms []models.UserProgram
 
-	err := r.db.WithContext(ctx).
-		Preload("User").
-		Where("program_id = ?", programId).
-		Find(&userPrograms).
-		Error
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Preload("User").
+			Where("program_id = ?", programId).
+			Find(&userPrograms).
+			Error
+	})
 
-	utils.PanicIfNotContextError(err)
-
-	return userPrograms
+	return userPrograms, err
 }
 
-func (r *userProgramRepository) CountAllByUserId(ctx context.Context, userId int64) int64 {
+func (r *userProgramRepository) CountAllByUserId(ctx context.Context, userId int64) (int64, error) {
 	var count int64
 
-	err := r.db.WithContext(ctx).
-		Model(&models.UserProgram{}).
-		Where("user_id = ?", userId).
-		Count(&count).
-		Error
-
-	utils.PanicIfNotContextError(err)
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Model(&models.UserProgram{}).
+			Where("user_id = ?", userId).
+			Count(&count).
+			Error
+	})
 
-	return count
+	return count, err
 }
 
-func (r *userProgramRepository) GetByUserIdAndProgramId(ctx context.Context, userId int64, programId uint) *models.UserProgram {
+func (r *userProgramRepository) GetByUserIdAndProgramId(ctx context.Context, userId int64, programId uint) (*models.UserProgram, error) {
 	var userProgram models.UserProgram
 
-	err := r.db.WithContext(ctx).
-		Preload("Program").
-		Where("user_id = ?", userId).
-		Where("program_id = ?", programId).
-		First(&userProgram).
-		Error
-
-	if err != nil && utils.IsRecordNotFoundError(err) {
-		return nil
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Preload("Program").
+			Where("user_id = ?", userId).
+			Where("program_id = ?", programId).
+			First(&userProgram).
+			Error
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
-	utils.PanicIfNotRecordNotFound(err)
-
-	return &userProgram
+	return &userProgram, nil
 }
 
-func (r *userProgramRepository) GetByUserId(ctx context.Context, userId int64, limit, offset int) []models.UserProgram {
+func (r *userProgramRepository) GetByUserId(ctx context.Context, userId int64, limit, offset int) ([]models.UserProgram, error) {
 	var userPrograms []models.UserProgram
 
-	err := r.db.WithContext(ctx).
-		Preload("Program").
-		Where("user_id = ?", userId).
-		Limit(limit).
-		Offset(offset).
-		Find(&userPrograms).
-		Error
-
-	utils.PanicIfNotContextError(err)
-
-	return userPrograms
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Preload("Program").
+			Where("user_id = ?", userId).
+			Limit(limit).
+			Offset(offset).
+			Find(&userPrograms).
+			Error
+	})
+
+	return userPrograms, err
 }
 
-func (r *userProgramRepository) DeleteById(ctx context.Context, id uint) {
-	err := r.db.
-		WithContext(ctx).
-		Where("id = ?", id).
-		Delete(&models.UserProgram{}).
-		Error
+func (r *userProgramRepository) GetAllByUserId(ctx context.Context, userId int64) ([]models.UserProgram, error) {
+	var userPrograms []models.UserProgram
+
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Preload("Program").
+			Where("user_id = ?", userId).
+			Order("created_at ASC").
+			Find(&userPrograms).
+			Error
+	})
 
-	utils.PanicIfNotContextError(err)
+	return userPrograms, err
 }
 
-func (r *userProgramRepository) DeleteByUserIdAndProgramId(ctx context.Context, userId int64, programId uint) {
-	err := r.db.
-		WithContext(ctx).
-		Where("user_id = ?", userId).
-		Where("program_id = ?", programId).
-		Delete(&models.UserProgram{}).
-		Error
+func (r *userProgramRepository) DeleteById(ctx context.Context, id uint) error {
+	return execute(ctx, func() error {
+		return r.db.
+			WithContext(ctx).
+			Where("id = ?", id).
+			Delete(&models.UserProgram{}).
+			Error
+	})
+}
 
-	utils.PanicIfNotContextError(err)
+func (r *userProgramRepository) DeleteByUserIdAndProgramId(ctx context.Context, userId int64, programId uint) error {
+	return execute(ctx, func() error {
+		return r.db.
+			WithContext(ctx).
+			Where("user_id = ?", userId).
+			Where("program_id = ?", programId).
+			Delete(&models.UserProgram{}).
+			Error
+	})
 }
@@ -4,32 +4,33 @@ import (
 	"context"
 	"go.uber.org/dig"
 	"gorm.io/gorm"
-	"rezvin-pro-bot/src/config"
+	"gorm.io/gorm/clause"
+	"rezvin-pro-bot/src/constants"
 	"rezvin-pro-bot/src/internal/db"
 	"rezvin-pro-bot/src/models"
-	"rezvin-pro-bot/src/utils"
 )
 
 type userResultRepositoryDependencies struct {
 	dig.In
 
-	Database db.IDatabase   `name:"Database"`
-	Config   config.IConfig `name:"Config"`
+	Database db.IDatabase `name:"Database"`
 }
 
 type IUserResultRepository interface {
-	Create(ctx context.Context, record models.UserResult)
-	CreateMany(ctx context.Context, records []models.UserResult)
-	GetById(ctx context.Context, id uint) *models.UserResult
-	CountAllByUserProgramId(ctx context.Context, userProgramId uint) int64
-	GetAllByUserProgramIdAndExerciseId(ctx context.Context, userProgramId, exerciseId uint) []models.UserResult
-	GetAllByUserProgramId(ctx context.Context, userProgramId uint) []models.UserResult
-	GetAllByExerciseId(ctx context.Context, exerciseId uint) []models.UserResult
-	GetByUserProgramId(ctx context.Context, userProgramId uint, limit, offset int) []models.UserResult
-	UpdateById(ctx context.Context, id uint, record models.UserResult)
-	UpdateByUserIdAndExerciseId(ctx context.Context, userId int64, exerciseId uint, record models.UserResult)
-	DeleteByUserProgramId(ctx context.Context, userProgramId uint)
-	DeleteByExerciseId(ctx context.Context, exerciseId uint)
+	Create(ctx context.Context, record models.UserResult) error
+	CreateMany(ctx context.Context, records []models.UserResult) error
+	GetById(ctx context.Context, id uint) (*models.UserResult, error)
+	CountAllByUserProgramId(ctx context.Context, userProgramId uint) (int64, error)
+	GetAllByUserProgramIdAndExerciseId(ctx context.Context, userProgramId, exerciseId uint) ([]models.UserResult, error)
+	GetAllByUserProgramId(ctx context.Context, userProgramId uint) ([]models.UserResult, error)
+	GetAllByExerciseId(ctx context.Context, exerciseId uint) ([]models.UserResult, error)
+	GetByUserProgramId(ctx context.Context, userProgramId uint, limit, offset int) ([]models.UserResult, error)
+	UpdateById(ctx context.Context, id uint, record models.UserResult) error
+	UpdateWeight(ctx context.Context, history models.UserResultHistory) error
+	UpdateByUserIdAndExerciseId(ctx context.Context, userId int64, exerciseId uint, record models.UserResult) error
+	DeleteByUserProgramId(ctx context.Context, userProgramId uint) error
+	DeleteByExerciseId(ctx context.Context, exerciseId uint) error
+	SyncReps(ctx context.Context, userProgramId, exerciseId uint, reps []constants.Reps) error
 }
 
 type userResultRepository struct {
@@ -37,170 +38,227 @@ type userResultRepository struct {
 }
 
 func NewUserResultRepository(deps userResultRepositoryDependencies) *userResultRepository {
-	r := &userResultRepository{
+	return &userResultRepository{
 		db: deps.Database.GetInstance(),
 	}
-
-	if deps.Config.RunMigrations() {
-		err := r.db.AutoMigrate(&models.UserResult{})
-
-		utils.PanicIfError(err)
-	}
-
-	return r
 }
 
-func (r *userResultRepository) Create(ctx context.Context, record models.UserResult) {
-	err := r.db.WithContext(ctx).Create(&record).Error
-
-	utils.PanicIfNotContextError(err)
+func (r *userResultRepository) Create(ctx context.Context, record models.UserResult) error {
+	return executeOnce(ctx, func() error {
+		return r.db.WithContext(ctx).Create(&record).Error
+	})
 }
 
-func (r *userResultRepository) CreateMany(ctx context.Context, records []models.UserResult) {
+func (r *userResultRepository) CreateMany(ctx context.Context, records []models.UserResult) error {
 	if len(records) == 0 {
-		return
+		return nil
 	}
 
-	err := r.db.WithContext(ctx).Create(&records).Error
-
-	utils.PanicIfNotContextError(err)
+	return executeOnce(ctx, func() error {
+		return r.db.WithContext(ctx).Create(&records).Error
+	})
 }
 
-func (r *userResultRepository) CountAllByUserProgramId(ctx context.Context, userProgramId uint) int64 {
+func (r *userResultRepository) CountAllByUserProgramId(ctx context.Context, userProgramId uint) (int64, error) {
 	var count int64
 
-	err := r.db.WithContext(ctx).
-		Model(&models.UserResult{}).
-		Where("user_program_id = ?", userProgramId).
-		Count(&count).
-		Error
-
-	utils.PanicIfNotContextError(err)
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Model(&models.UserResult{}).
+			Where("user_program_id = ?", userProgramId).
+			Count(&count).
+			Error
+	})
 
-	return count
+	return count, err
 }
 
-func (r *userResultRepository) GetById(ctx context.Context, id uint) *models.UserResult {
+func (r *userResultRepository) GetById(ctx context.Context, id uint) (*models.UserResult, error) {
 	var record models.UserResult
 
-	err := r.db.WithContext(ctx).
-		Preload("Exercise").
-		Where("id = ?", id).
-		First(&record).
-		Error
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Preload("Exercise").
+			Where("id = ?", id).
+			First(&record).
+			Error
+	})
 
-	if err != nil && utils.IsRecordNotFoundError(err) {
-		return nil
+	if err != nil {
+		return nil, err
 	}
 
-	return &record
+	return &record, nil
 }
 
-func (r *userResultRepository) GetAllByUserProgramId(ctx context.Context, userProgramId uint) []models.UserResult {
+func (r *userResultRepository) GetAllByUserProgramId(ctx context.Context, userProgramId uint) ([]models.UserResult, error) {
 	var records []models.UserResult
 
-	err := r.db.WithContext(ctx).
-		Preload("Exercise").
-		Where("user_program_id = ?", userProgramId).
-		Order("reps ASC").
-		Find(&records).
-		Error
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Preload("Exercise").
+			Where("user_program_id = ?", userProgramId).
+			Order("reps ASC").
+			Find(&records).
+			Error
+	})
 
-	utils.PanicIfNotContextError(err)
-
-	return records
+	return records, err
 }
 
 func (r *userResultRepository) GetAllByUserProgramIdAndExerciseId(
 	ctx context.Context,
 	userProgramId, exerciseId uint,
-) []models.UserResult {
+) ([]models.UserResult, error) {
 	var records []models.UserResult
 
-	err := r.db.WithContext(ctx).
-		Preload("Exercise").
-		Where("user_program_id = ? AND exercise_id = ?", userProgramId, exerciseId).
-		Order("reps ASC").
-		Find(&records).
-		Error
-
-	utils.PanicIfNotContextError(err)
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Preload("Exercise").
+			Where("user_program_id = ? AND exercise_id = ?", userProgramId, exerciseId).
+			Order("reps ASC").
+			Find(&records).
+			Error
+	})
 
-	return records
+	return records, err
 }
 
-func (r *userResultRepository) GetAllByExerciseId(ctx context.Context, exerciseId uint) []models.UserResult {
+func (r *userResultRepository) GetAllByExerciseId(ctx context.Context, exerciseId uint) ([]models.UserResult, error) {
 	var records []models.UserResult
 
-	err := r.db.WithContext(ctx).
-		Preload("Exercise").
-		Where("exercise_id = ?", exerciseId).
-		Order("reps ASC").
-		Find(&records).
-		Error
-
-	utils.PanicIfNotContextError(err)
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Preload("Exercise").
+			Where("exercise_id = ?", exerciseId).
+			Order("reps ASC").
+			Find(&records).
+			Error
+	})
 
-	return records
+	return records, err
 }
 
 func (r *userResultRepository) GetByUserProgramId(
 	ctx context.Context,
 	userProgramId uint,
 	limit, offset int,
-) []models.UserResult {
+) ([]models.UserResult, error) {
 	var records []models.UserResult
 
-	err := r.db.WithContext(ctx).
-		Preload("Exercise").
-		Where("user_program_id = ?", userProgramId).
-		Order("reps ASC").
-		Limit(limit).
-		Offset(offset).
-		Find(&records).
-		Error
-
-	utils.PanicIfNotContextError(err)
-
-	return records
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Preload("Exercise").
+			Where("user_program_id = ?", userProgramId).
+			Order("reps ASC").
+			Limit(limit).
+			Offset(offset).
+			Find(&records).
+			Error
+	})
+
+	return records, err
 }
 
-func (r *userResultRepository) UpdateById(ctx context.Context, id uint, record models.UserResult) {
-	err := r.db.WithContext(ctx).
-		Where("id = ?", id).
-		Updates(&record).Error
+func (r *userResultRepository) UpdateById(ctx context.Context, id uint, record models.UserResult) error {
+	return execute(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Where("id = ?", id).
+			Updates(&record).Error
+	})
+}
 
-	utils.PanicIfNotContextError(err)
+// UpdateWeight sets the current weight of the result and appends it to the history
+// in one transaction, so the history never misses a value the result had.
+func (r *userResultRepository) UpdateWeight(ctx context.Context, history models.UserResultHistory) error {
+	return executeOnce(ctx, func() error {
+		return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			err := tx.Model(&models.UserResult{}).
+				Where("id = ?", history.UserResultId).
+				Update("weight", history.Weight).
+				Error
+
+			if err != nil {
+				return err
+			}
+
+			entry := history
+
+			return tx.Create(&entry).Error
+		})
+	})
 }
 
 func (r *userResultRepository) UpdateByUserIdAndExerciseId(
 	ctx context.Context, userId int64,
 	exerciseId uint,
 	record models.UserResult,
-) {
-	err := r.db.
-		WithContext(ctx).
-		Where("user_id = ?", userId).
-		Where("exercise_id = ?", exerciseId).
-		Updates(&record).Error
+) error {
+	return execute(ctx, func() error {
+		return r.db.
+			WithContext(ctx).
+			Where("user_id = ?", userId).
+			Where("exercise_id = ?", exerciseId).
+			Updates(&record).Error
+	})
+}
 
-	utils.PanicIfNotContextError(err)
+func (r *userResultRepository) DeleteByUserProgramId(ctx context.Context, userProgramId uint) error {
+	return execute(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Where("user_program_id = ?", userProgramId).
+			Delete(&models.UserResult{}).
+			Error
+	})
 }
 
-func (r *userResultRepository) DeleteByUserProgramId(ctx context.Context, userProgramId uint) {
-	err := r.db.WithContext(ctx).
-		Where("user_program_id = ?", userProgramId).
-		Delete(&models.UserResult{}).
-		Error
+func (r *userResultRepository) DeleteByExerciseId(ctx context.Context, exerciseId uint) error {
+	return execute(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Where("exercise_id = ?", exerciseId).
+			Delete(&models.UserResult{}).
+			Error
+	})
+}
 
-	utils.PanicIfNotContextError(err)
+func (r *userResultRepository) SyncReps(ctx context.Context, userProgramId, exerciseId uint, reps []constants.Reps) error {
+	return execute(ctx, func() error {
+		return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return syncReps(tx, userProgramId, exerciseId, reps)
+		})
+	})
 }
 
-func (r *userResultRepository) DeleteByExerciseId(ctx context.Context, exerciseId uint) {
-	err := r.db.WithContext(ctx).
-		Where("exercise_id = ?", exerciseId).
+// syncReps creates empty records for the reps of the scheme and drops empty records
+// for reps that are no longer in it, so it is safe to repeat.
+func syncReps(tx *gorm.DB, userProgramId, exerciseId uint, reps []constants.Reps) error {
+	if len(reps) == 0 {
+		return nil
+	}
+
+	records := make([]models.UserResult, 0, len(reps))
+	repsValues := make([]uint, 0, len(reps))
+
+	for _, rep := range reps {
+		records = append(records, models.UserResult{
+			UserProgramId: userProgramId,
+			ExerciseId:    exerciseId,
+			Weight:        0,
+			Reps:          uint(rep),
+		})
+		repsValues = append(repsValues, uint(rep))
+	}
+
+	err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&records).Error
+
+	if err != nil {
+		return err
+	}
+
+	return tx.
+		Where("user_program_id = ? AND exercise_id = ?", userProgramId, exerciseId).
+		Where("weight = ?", 0).
+		Where("reps NOT IN ?", repsValues).
 		Delete(&models.UserResult{}).
 		Error
-
-	utils.PanicIfNotContextError(err)
 }
@@ -0,0 +1,140 @@
+package repositories
+
+import (
+	"context"
+	"go.uber.org/dig"
+	"gorm.io/gorm"
+	"rezvin-pro-bot/src/internal/db"
+	"rezvin-pro-bot/src/models"
+)
+
+type userResultHistoryRepositoryDependencies struct {
+	dig.In
+
+	Database db.IDatabase `name:"Database"`
+}
+
+type IUserResultHistoryRepository interface {
+	Create(ctx context.Context, record models.UserResultHistory) error
+	CountByUserResultId(ctx context.Context, userResultId uint) (int64, error)
+	GetByUserResultId(ctx context.Context, userResultId uint, limit, offset int) ([]models.UserResultHistory, error)
+	GetBestWeight(ctx context.Context, userId int64, exerciseId uint, reps uint) (float64, error)
+	GetRecordsByUserId(ctx context.Context, userId int64) ([]models.UserResultHistory, error)
+	GetAllByUserIdAndExerciseId(ctx context.Context, userId int64, exerciseId uint) ([]models.UserResultHistory, error)
+	GetAllByUserId(ctx context.Context, userId int64) ([]models.UserResultHistory, error)
+}
+
+type userResultHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewUserResultHistoryRepository(deps userResultHistoryRepositoryDependencies) *userResultHistoryRepository {
+	return &userResultHistoryRepository{
+		db: deps.Database.GetInstance(),
+	}
+}
+
+func (r *userResultHistoryRepository) Create(ctx context.Context, record models.UserResultHistory) error {
+	return executeOnce(ctx, func() error {
+		return r.db.WithContext(ctx).Create(&record).Error
+	})
+}
+
+func (r *userResultHistoryRepository) CountByUserResultId(ctx context.Context, userResultId uint) (int64, error) {
+	var count int64
+
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Model(&models.UserResultHistory{}).
+			Where("user_result_id = ?", userResultId).
+			Count(&count).
+			Error
+	})
+
+	return count, err
+}
+
+func (r *userResultHistoryRepository) GetByUserResultId(
+	ctx context.Context,
+	userResultId uint,
+	limit, offset int,
+) ([]models.UserResultHistory, error) {
+	var records []models.UserResultHistory
+
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Preload("Exercise").
+			Where("user_result_id = ?", userResultId).
+			Order("logged_at DESC").
+			Limit(limit).
+			Offset(offset).
+			Find(&records).
+			Error
+	})
+
+	return records, err
+}
+
+func (r *userResultHistoryRepository) GetBestWeight(ctx context.Context, userId int64, exerciseId uint, reps uint) (float64, error) {
+	var best float64
+
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Model(&models.UserResultHistory{}).
+			Select("COALESCE(MAX(weight), 0)").
+			Where("user_id = ?", userId).
+			Where("exercise_id = ?", exerciseId).
+			Where("reps = ?", reps).
+			Scan(&best).
+			Error
+	})
+
+	return best, err
+}
+
+func (r *userResultHistoryRepository) GetRecordsByUserId(ctx context.Context, userId int64) ([]models.UserResultHistory, error) {
+	var records []models.UserResultHistory
+
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Preload("Exercise").
+			Select("DISTINCT ON (exercise_id, reps) *").
+			Where("user_id = ?", userId).
+			Where("weight > ?", 0).
+			Order("exercise_id, reps, weight DESC, logged_at ASC").
+			Find(&records).
+			Error
+	})
+
+	return records, err
+}
+
+func (r *userResultHistoryRepository) GetAllByUserIdAndExerciseId(ctx context.Context, userId int64, exerciseId uint) ([]models.UserResultHistory, error) {
+	var records []models.UserResultHistory
+
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Where("user_id = ?", userId).
+			Where("exercise_id = ?", exerciseId).
+			Order("logged_at ASC").
+			Find(&records).
+			Error
+	})
+
+	return records, err
+}
+
+func (r *userResultHistoryRepository) GetAllByUserId(ctx context.Context, userId int64) ([]models.UserResultHistory, error) {
+	var records []models.UserResultHistory
+
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Preload("Exercise").
+			Where("user_id = ?", userId).
+			Order("logged_at ASC, id ASC").
+			Find(&records).
+			Error
+	})
+
+	return records, err
+}
@@ -0,0 +1,141 @@
+package repositories
+
+import (
+	"context"
+	"go.uber.org/dig"
+	"gorm.io/gorm"
+	"rezvin-pro-bot/src/internal/db"
+	"rezvin-pro-bot/src/models"
+)
+
+type workoutSessionRepositoryDependencies struct {
+	dig.In
+
+	Database db.IDatabase `name:"Database"`
+}
+
+type IWorkoutSessionRepository interface {
+	Create(ctx context.Context, session models.WorkoutSession) (uint, error)
+	GetById(ctx context.Context, id uint) (*models.WorkoutSession, error)
+	GetActiveByUserId(ctx context.Context, userId int64) (*models.WorkoutSession, error)
+	CountFinishedByUserId(ctx context.Context, userId int64) (int64, error)
+	GetFinishedByUserId(ctx context.Context, userId int64, limit, offset int) ([]models.WorkoutSession, error)
+	UpdateById(ctx context.Context, id uint, session models.WorkoutSession) error
+	DeleteById(ctx context.Context, id uint) error
+}
+
+type workoutSessionRepository struct {
+	db *gorm.DB
+}
+
+func NewWorkoutSessionRepository(deps workoutSessionRepositoryDependencies) *workoutSessionRepository {
+	return &workoutSessionRepository{
+		db: deps.Database.GetInstance(),
+	}
+}
+
+func (r *workoutSessionRepository) Create(ctx context.Context, session models.WorkoutSession) (uint, error) {
+	err := executeOnce(ctx, func() error {
+		return r.db.WithContext(ctx).Create(&session).Error
+	})
+
+	return session.Id, err
+}
+
+func (r *workoutSessionRepository) GetById(ctx context.Context, id uint) (*models.WorkoutSession, error) {
+	var session models.WorkoutSession
+
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Preload("UserProgram.Program").
+			Preload("Sets", func(db *gorm.DB) *gorm.DB {
+				return db.Order("id ASC")
+			}).
+			Preload("Sets.Exercise").
+			Where("id = ?", id).
+			First(&session).
+			Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (r *workoutSessionRepository) GetActiveByUserId(ctx context.Context, userId int64) (*models.WorkoutSession, error) {
+	var session models.WorkoutSession
+
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Preload("UserProgram.Program").
+			Where("user_id = ?", userId).
+			Where("finished_at IS NULL").
+			Order("started_at DESC").
+			First(&session).
+			Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (r *workoutSessionRepository) CountFinishedByUserId(ctx context.Context, userId int64) (int64, error) {
+	var count int64
+
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Model(&models.WorkoutSession{}).
+			Where("user_id = ?", userId).
+			Where("finished_at IS NOT NULL").
+			Count(&count).
+			Error
+	})
+
+	return count, err
+}
+
+func (r *workoutSessionRepository) GetFinishedByUserId(
+	ctx context.Context,
+	userId int64,
+	limit, offset int,
+) ([]models.WorkoutSession, error) {
+	var sessions []models.WorkoutSession
+
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Preload("UserProgram.Program").
+			Where("user_id = ?", userId).
+			Where("finished_at IS NOT NULL").
+			Order("started_at DESC").
+			Limit(limit).
+			Offset(offset).
+			Find(&sessions).
+			Error
+	})
+
+	return sessions, err
+}
+
+func (r *workoutSessionRepository) UpdateById(ctx context.Context, id uint, session models.WorkoutSession) error {
+	return execute(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Model(&models.WorkoutSession{}).
+			Where("id = ?", id).
+			Updates(&session).
+			Error
+	})
+}
+
+func (r *workoutSessionRepository) DeleteById(ctx context.Context, id uint) error {
+	return execute(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Where("id = ?", id).
+			Delete(&models.WorkoutSession{}).
+			Error
+	})
+}
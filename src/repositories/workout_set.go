@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"context"
+	"go.uber.org/dig"
+	"gorm.io/gorm"
+	"rezvin-pro-bot/src/internal/db"
+	"rezvin-pro-bot/src/models"
+)
+
+type workoutSetRepositoryDependencies struct {
+	dig.In
+
+	Database db.IDatabase `name:"Database"`
+}
+
+type IWorkoutSetRepository interface {
+	Create(ctx context.Context, set models.WorkoutSet) (uint, error)
+	GetAllByWorkoutSessionIdAndExerciseId(ctx context.Context, workoutSessionId, exerciseId uint) ([]models.WorkoutSet, error)
+}
+
+type workoutSetRepository struct {
+	db *gorm.DB
+}
+
+func NewWorkoutSetRepository(deps workoutSetRepositoryDependencies) *workoutSetRepository {
+	return &workoutSetRepository{
+		db: deps.Database.GetInstance(),
+	}
+}
+
+func (r *workoutSetRepository) Create(ctx context.Context, set models.WorkoutSet) (uint, error) {
+	err := executeOnce(ctx, func() error {
+		return r.db.WithContext(ctx).Create(&set).Error
+	})
+
+	return set.Id, err
+}
+
+func (r *workoutSetRepository) GetAllByWorkoutSessionIdAndExerciseId(
+	ctx context.Context,
+	workoutSessionId, exerciseId uint,
+) ([]models.WorkoutSet, error) {
+	var sets []models.WorkoutSet
+
+	err := execute(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Where("workout_session_id = ?", workoutSessionId).
+			Where("exercise_id = ?", exerciseId).
+			Order("set_number ASC").
+			Find(&sets).
+			Error
+	})
+
+	return sets, err
+}
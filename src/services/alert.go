@@ -0,0 +1,270 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	tg_bot "github.com/go-telegram/bot"
+	tg_models "github.com/go-telegram/bot/models"
+	"go.uber.org/dig"
+	"hash/fnv"
+	"regexp"
+	"rezvin-pro-bot/src/config"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/internal/logger"
+	"rezvin-pro-bot/src/types"
+	"rezvin-pro-bot/src/utils"
+	utils_context "rezvin-pro-bot/src/utils/context"
+	"rezvin-pro-bot/src/utils/messages"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+type IAlertService interface {
+	Start(b *tg_bot.Bot)
+	Report(ctx context.Context, kind constants.AlertKind, err any)
+	ReportPanic(ctx context.Context, r any)
+	Shutdown(ctx context.Context) error
+}
+
+type alertServiceDependencies struct {
+	dig.In
+
+	Logger logger.ILogger `name:"Logger"`
+	Config config.IConfig `name:"Config"`
+}
+
+type alertEntry struct {
+	kind       constants.AlertKind
+	message    string
+	lastSentAt time.Time
+	suppressed int
+}
+
+type alertService struct {
+	logger logger.ILogger
+	config config.IConfig
+
+	queue   chan string
+	stop    chan struct{}
+	done    chan struct{}
+	started bool
+
+	mu      sync.Mutex
+	entries map[uint64]*alertEntry
+	sent    int
+	dropped int
+}
+
+var alertNumberRegexp = regexp.MustCompile(`\d+`)
+
+func NewAlertService(deps alertServiceDependencies) *alertService {
+	return &alertService{
+		logger:  deps.Logger,
+		config:  deps.Config,
+		queue:   make(chan string, constants.AlertQueueSize),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+		entries: make(map[uint64]*alertEntry),
+	}
+}
+
+func (s *alertService) Start(b *tg_bot.Bot) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return
+	}
+
+	s.started = true
+
+	go s.run(b)
+}
+
+func (s *alertService) ReportPanic(ctx context.Context, r any) {
+	kind := constants.PanicAlertKind
+
+	if err, ok := r.(error); ok && utils.IsDatabaseError(err) {
+		kind = constants.RepositoryAlertKind
+	}
+
+	s.Report(ctx, kind, r)
+}
+
+func (s *alertService) Report(ctx context.Context, kind constants.AlertKind, err any) {
+	message := fmt.Sprint(err)
+
+	if !s.allow(kind, message) {
+		return
+	}
+
+	stack := make([]byte, s.config.ErrorStackTraceSizeInKb()*1024)
+	stack = stack[:runtime.Stack(stack, false)]
+
+	text := messages.AlertMessage(kind, message, utils_context.GetLogFieldsFromContext(ctx), trimAlertFrames(string(stack)))
+
+	select {
+	case s.queue <- text:
+	default:
+		s.mu.Lock()
+		s.dropped++
+		s.mu.Unlock()
+	}
+}
+
+func (s *alertService) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	started := s.started
+	s.mu.Unlock()
+
+	if !started {
+		return nil
+	}
+
+	close(s.stop)
+
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// allow decides whether an alert goes out right away. Repeats of the same error
+// within the suppress window and anything over the per-interval budget are only
+// counted and reported later in the digest.
+func (s *alertService) allow(kind constants.AlertKind, message string) bool {
+	fingerprint := alertFingerprint(kind, message)
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[fingerprint]
+
+	if !ok {
+		entry = &alertEntry{kind: kind, message: message}
+		s.entries[fingerprint] = entry
+	}
+
+	if now.Sub(entry.lastSentAt) < constants.AlertSuppressWindow || s.sent >= constants.MaxAlertsPerDigestInterval {
+		entry.suppressed++
+		return false
+	}
+
+	entry.lastSentAt = now
+	s.sent++
+
+	return true
+}
+
+func (s *alertService) run(b *tg_bot.Bot) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(constants.AlertDigestInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			s.drain(b)
+			s.sendDigest(b)
+			return
+		case text := <-s.queue:
+			s.send(b, text)
+		case <-ticker.C:
+			s.sendDigest(b)
+		}
+	}
+}
+
+func (s *alertService) drain(b *tg_bot.Bot) {
+	for {
+		select {
+		case text := <-s.queue:
+			s.send(b, text)
+		default:
+			return
+		}
+	}
+}
+
+func (s *alertService) sendDigest(b *tg_bot.Bot) {
+	s.mu.Lock()
+
+	entries := make([]types.AlertDigestEntry, 0)
+	now := time.Now()
+
+	for fingerprint, entry := range s.entries {
+		if entry.suppressed > 0 {
+			entries = append(entries, types.AlertDigestEntry{
+				Kind:       entry.kind,
+				Message:    entry.message,
+				Suppressed: entry.suppressed,
+			})
+			entry.suppressed = 0
+		}
+
+		if now.Sub(entry.lastSentAt) >= constants.AlertSuppressWindow {
+			delete(s.entries, fingerprint)
+		}
+	}
+
+	dropped := s.dropped
+	s.dropped = 0
+	s.sent = 0
+
+	s.mu.Unlock()
+
+	if len(entries) == 0 && dropped == 0 {
+		return
+	}
+
+	s.send(b, messages.AlertDigestMessage(entries, dropped))
+}
+
+// send talks to the Bot API directly instead of going through the outbound
+// service, so a failing alert never turns into another alert.
+func (s *alertService) send(b *tg_bot.Bot, text string) {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.AlertSendTimeout)
+	defer cancel()
+
+	_, err := b.SendMessage(ctx, &tg_bot.SendMessageParams{
+		ChatID:    s.config.AlertChatId(),
+		Text:      text,
+		ParseMode: tg_models.ParseModeMarkdown,
+	})
+
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to send alert")
+	}
+}
+
+// trimAlertFrames drops the alert service's own frames from the top of the stack,
+// keeping the goroutine header.
+func trimAlertFrames(stack string) string {
+	lines := strings.Split(stack, "\n")
+
+	if len(lines) < 3 {
+		return stack
+	}
+
+	frames := lines[1:]
+
+	for len(frames) >= 2 && strings.Contains(frames[0], "(*alertService)") {
+		frames = frames[2:]
+	}
+
+	return strings.Join(append([]string{lines[0]}, frames...), "\n")
+}
+
+func alertFingerprint(kind constants.AlertKind, message string) uint64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(kind))
+	hash.Write([]byte(alertNumberRegexp.ReplaceAllString(message, "#")))
+
+	return hash.Sum64()
+}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"go.uber.org/dig"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/internal/logger"
+	"rezvin-pro-bot/src/models"
+	"rezvin-pro-bot/src/repositories"
+	"rezvin-pro-bot/src/types"
+	"strings"
+	"sync"
+	"time"
+)
+
+type ICallbackStateService interface {
+	Store(ctx context.Context, chatId int64, prefix string, params *types.Params, data map[string]string) (string, error)
+	Resolve(ctx context.Context, chatId int64, callbackData string) (*models.CallbackState, error)
+	Shutdown(ctx context.Context) error
+}
+
+type callbackStateServiceDependencies struct {
+	dig.In
+
+	Logger                  logger.ILogger                        `name:"Logger"`
+	AlertService            IAlertService                         `name:"AlertService"`
+	CallbackStateRepository repositories.ICallbackStateRepository `name:"CallbackStateRepository"`
+}
+
+type callbackStateService struct {
+	logger                  logger.ILogger
+	alertService            IAlertService
+	callbackStateRepository repositories.ICallbackStateRepository
+	stop                    chan struct{}
+	stopOnce                sync.Once
+	done                    chan struct{}
+}
+
+func NewCallbackStateService(deps callbackStateServiceDependencies) *callbackStateService {
+	s := &callbackStateService{
+		logger:                  deps.Logger,
+		alertService:            deps.AlertService,
+		callbackStateRepository: deps.CallbackStateRepository,
+		stop:                    make(chan struct{}),
+		done:                    make(chan struct{}),
+	}
+
+	go s.collectGarbage()
+
+	return s
+}
+
+func (s *callbackStateService) Store(
+	ctx context.Context,
+	chatId int64,
+	prefix string,
+	params *types.Params,
+	data map[string]string,
+) (string, error) {
+	token := make([]byte, constants.CallbackStateTokenLength)
+
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+
+	state := models.CallbackState{
+		Token:     base64.RawURLEncoding.EncodeToString(token),
+		ChatId:    chatId,
+		Prefix:    prefix,
+		Params:    *types.NewEmptyParams(),
+		Data:      data,
+		ExpiresAt: time.Now().Add(constants.CallbackStateTTL),
+	}
+
+	if params != nil {
+		state.Params = *params
+	}
+
+	if err := s.callbackStateRepository.Create(ctx, state); err != nil {
+		return "", err
+	}
+
+	return prefix + constants.CallbackStateSeparator + state.Token, nil
+}
+
+// Resolve returns nil without an error when the state is unknown, expired or
+// belongs to another chat or prefix.
+func (s *callbackStateService) Resolve(ctx context.Context, chatId int64, callbackData string) (*models.CallbackState, error) {
+	prefix, token, found := strings.Cut(callbackData, constants.CallbackStateSeparator)
+
+	if !found || token == "" {
+		return nil, nil
+	}
+
+	state, err := s.callbackStateRepository.GetByToken(ctx, token)
+
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if state.ChatId != chatId || state.Prefix != prefix {
+		return nil, nil
+	}
+
+	return state, nil
+}
+
+func (s *callbackStateService) Shutdown(ctx context.Context) error {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *callbackStateService) collectGarbage() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(constants.CallbackStateGCInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.deleteExpired()
+		}
+	}
+}
+
+func (s *callbackStateService) deleteExpired() {
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.Error(fmt.Sprintf("Failed to delete expired callback states: %v", r))
+			s.alertService.ReportPanic(context.Background(), r)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	deleted, err := s.callbackStateRepository.DeleteExpired(ctx)
+
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to delete expired callback states")
+		s.alertService.Report(ctx, constants.RepositoryAlertKind, err)
+		return
+	}
+
+	if deleted > 0 {
+		s.logger.Log(fmt.Sprintf("Deleted %d expired callback states", deleted))
+	}
+}
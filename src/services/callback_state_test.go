@@ -0,0 +1,146 @@
+package services_test
+
+import (
+	"context"
+	"go.uber.org/dig"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/di"
+	"rezvin-pro-bot/src/services"
+	"rezvin-pro-bot/src/types"
+	bot_utils "rezvin-pro-bot/src/utils/bot"
+	"strings"
+	"testing"
+)
+
+type callbackStateTestDependencies struct {
+	dig.In
+
+	CallbackStateService services.ICallbackStateService `name:"CallbackStateService"`
+}
+
+func newCallbackStateService(t *testing.T) services.ICallbackStateService {
+	t.Setenv("APP_ENV", "development")
+	t.Setenv("BOT_TOKEN", "1:test")
+	t.Setenv("POSTGRES_DSN", "unused")
+	t.Setenv("ALERT_CHAT_ID", "-100")
+	t.Setenv("LOCK_BACKEND", "memory")
+
+	var deps callbackStateTestDependencies
+
+	if err := di.BuildMemoryContainer().Invoke(func(d callbackStateTestDependencies) { deps = d }); err != nil {
+		t.Fatalf("failed to build container: %s", err)
+	}
+
+	t.Cleanup(func() {
+		_ = deps.CallbackStateService.Shutdown(context.Background())
+	})
+
+	return deps.CallbackStateService
+}
+
+func TestCallbackStateRoundTrip(t *testing.T) {
+	service := newCallbackStateService(t)
+	ctx := context.Background()
+
+	params := &types.Params{
+		ProgramId:        4294967295,
+		UserId:           -1001234567890,
+		ExerciseId:       4294967295,
+		UserProgramId:    4294967295,
+		UserResultId:     4294967295,
+		MeasureId:        4294967295,
+		UserMeasureId:    4294967295,
+		WorkoutSessionId: 4294967295,
+		Limit:            constants.DefaultLimit,
+		Offset:           4294967295,
+		Reps:             constants.MaxReps,
+		RestSeconds:      180,
+	}
+
+	if encoded := bot_utils.EncodeCallbackData(constants.ClientResultExerciseReps, params); len(encoded) <= constants.MaxCallbackDataLength {
+		t.Fatalf("expected params to exceed the callback data limit, got %d bytes", len(encoded))
+	}
+
+	data := map[string]string{"key": "value"}
+
+	callbackData, err := service.Store(ctx, 1, constants.ClientResultExerciseReps, params, data)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(callbackData) > constants.MaxCallbackDataLength {
+		t.Errorf("stored callback data is %d bytes", len(callbackData))
+	}
+
+	if !strings.HasPrefix(callbackData, constants.ClientResultExerciseReps+constants.CallbackStateSeparator) {
+		t.Errorf("callback data %q does not start with the prefix", callbackData)
+	}
+
+	state, err := service.Resolve(ctx, 1, callbackData)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if state == nil {
+		t.Fatal("expected stored state")
+	}
+
+	if state.Params != *params {
+		t.Errorf("got params %+v, want %+v", state.Params, *params)
+	}
+
+	if state.Data["key"] != "value" {
+		t.Errorf("got data %v, want %v", state.Data, data)
+	}
+
+	_, token, _ := strings.Cut(callbackData, constants.CallbackStateSeparator)
+
+	tests := []struct {
+		name         string
+		chatId       int64
+		callbackData string
+	}{
+		{"another chat", 2, callbackData},
+		{"another prefix", 1, constants.ClientResultHistory + constants.CallbackStateSeparator + token},
+		{"unknown token", 1, constants.ClientResultExerciseReps + constants.CallbackStateSeparator + "unknown"},
+		{"empty token", 1, constants.ClientResultExerciseReps + constants.CallbackStateSeparator},
+		{"no separator", 1, constants.ClientResultExerciseReps},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, err := service.Resolve(ctx, tt.chatId, tt.callbackData)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if state != nil {
+				t.Errorf("expected no state, got %+v", state)
+			}
+		})
+	}
+}
+
+func TestCallbackStateWithoutParams(t *testing.T) {
+	service := newCallbackStateService(t)
+	ctx := context.Background()
+
+	callbackData, err := service.Store(ctx, 1, constants.ImportConfirm, nil, nil)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	state, err := service.Resolve(ctx, 1, callbackData)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if state == nil || state.Params != *types.NewEmptyParams() {
+		t.Errorf("got %+v, want empty params", state)
+	}
+}
@@ -2,74 +2,254 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	tg_bot "github.com/go-telegram/bot"
+	tg_models "github.com/go-telegram/bot/models"
+	"go.uber.org/dig"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/internal/logger"
+	"rezvin-pro-bot/src/internal/metrics"
+	"rezvin-pro-bot/src/models"
+	"rezvin-pro-bot/src/repositories"
 	"rezvin-pro-bot/src/types"
 	"sync"
 )
 
+type ConversationStep func(ctx context.Context, b *tg_bot.Bot, conversation *models.Conversation, answer string)
+
 type IConversationService interface {
-	Shutdown(ctx context.Context) error
-	CreateConversation(chatId int64) *types.Conversation
-	IsConversationExists(chatId int64) bool
-	GetConversation(chatId int64) *types.Conversation
-	DeleteConversation(chatId int64)
+	RegisterStep(state constants.ConversationState, step ConversationStep)
+	Start(ctx context.Context, b *tg_bot.Bot, chatId int64, state constants.ConversationState, params types.Params, prompt string)
+	StartWithKb(
+		ctx context.Context,
+		b *tg_bot.Bot,
+		chatId int64,
+		state constants.ConversationState,
+		params types.Params,
+		prompt string,
+		kb *tg_models.InlineKeyboardMarkup,
+	)
+	Advance(ctx context.Context, b *tg_bot.Bot, conversation *models.Conversation, state constants.ConversationState, prompt string)
+	AdvanceWithKb(
+		ctx context.Context,
+		b *tg_bot.Bot,
+		conversation *models.Conversation,
+		state constants.ConversationState,
+		prompt string,
+		kb *tg_models.InlineKeyboardMarkup,
+	)
+	Resume(ctx context.Context, b *tg_bot.Bot, chatId int64, answer string) bool
+	Finish(ctx context.Context, b *tg_bot.Bot, conversation *models.Conversation)
+	Abandon(ctx context.Context, b *tg_bot.Bot, chatId int64) (bool, error)
+	GetConversation(ctx context.Context, chatId int64) (*models.Conversation, error)
+	IsConversationExists(ctx context.Context, chatId int64) (bool, error)
+}
+
+type conversationServiceDependencies struct {
+	dig.In
+
+	Logger                 logger.ILogger                       `name:"Logger"`
+	Metrics                metrics.IMetrics                     `name:"Metrics"`
+	SenderService          ISenderService                       `name:"SenderService"`
+	ConversationRepository repositories.IConversationRepository `name:"ConversationRepository"`
 }
 
 type conversationService struct {
-	state map[int64]*types.Conversation
-	mu    sync.RWMutex
+	logger                 logger.ILogger
+	senderService          ISenderService
+	conversationRepository repositories.IConversationRepository
+	steps                  map[constants.ConversationState]ConversationStep
+	mu                     sync.RWMutex
 }
 
-func NewConversationService() *conversationService {
-	return &conversationService{
-		state: make(map[int64]*types.Conversation),
-		mu:    sync.RWMutex{},
+func NewConversationService(deps conversationServiceDependencies) *conversationService {
+	s := &conversationService{
+		logger:                 deps.Logger,
+		senderService:          deps.SenderService,
+		conversationRepository: deps.ConversationRepository,
+		steps:                  make(map[constants.ConversationState]ConversationStep),
+		mu:                     sync.RWMutex{},
 	}
+
+	deps.Metrics.RegisterActiveConversations(s.countActive)
+
+	return s
 }
 
-func (s *conversationService) Shutdown(_ context.Context) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+// countActive runs on a metrics scrape, outside of any handler, so a failure is only logged.
+func (s *conversationService) countActive() float64 {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.MetricsCollectTimeout)
+	defer cancel()
 
-	for chatId, conv := range s.state {
-		conv.Close()
-		delete(s.state, chatId)
+	count, err := s.conversationRepository.CountAll(ctx)
+
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to count active conversations")
+		return 0
 	}
 
-	return nil
+	return float64(count)
 }
 
-func (s *conversationService) CreateConversation(chatId int64) *types.Conversation {
+func (s *conversationService) RegisterStep(state constants.ConversationState, step ConversationStep) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if _, ok := s.state[chatId]; ok {
-		s.state[chatId].Close()
-		delete(s.state, chatId)
+	if _, ok := s.steps[state]; ok {
+		panic(fmt.Sprintf("Conversation step %s is already registered", state))
 	}
 
-	s.state[chatId] = types.NewConversation(chatId)
+	s.steps[state] = step
+}
 
-	return s.state[chatId]
+func (s *conversationService) Start(
+	ctx context.Context,
+	b *tg_bot.Bot,
+	chatId int64,
+	state constants.ConversationState,
+	params types.Params,
+	prompt string,
+) {
+	s.StartWithKb(ctx, b, chatId, state, params, prompt, nil)
 }
 
-func (s *conversationService) IsConversationExists(userId int64) bool {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	_, ok := s.state[userId]
-	return ok
+func (s *conversationService) StartWithKb(
+	ctx context.Context,
+	b *tg_bot.Bot,
+	chatId int64,
+	state constants.ConversationState,
+	params types.Params,
+	prompt string,
+	kb *tg_models.InlineKeyboardMarkup,
+) {
+	if _, err := s.Abandon(ctx, b, chatId); err != nil {
+		s.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	messageId := s.sendPrompt(ctx, b, chatId, prompt, kb)
+
+	err := s.conversationRepository.Save(ctx, models.Conversation{
+		ChatId:    chatId,
+		State:     state,
+		Params:    params,
+		Data:      make(map[string]string),
+		MessageId: messageId,
+	})
+
+	if err != nil {
+		s.senderService.Delete(ctx, b, chatId, messageId)
+		s.senderService.SendError(ctx, b, chatId, err)
+	}
 }
 
-func (s *conversationService) GetConversation(userId int64) *types.Conversation {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	return s.state[userId]
+func (s *conversationService) Advance(
+	ctx context.Context,
+	b *tg_bot.Bot,
+	conversation *models.Conversation,
+	state constants.ConversationState,
+	prompt string,
+) {
+	s.AdvanceWithKb(ctx, b, conversation, state, prompt, nil)
 }
 
-func (s *conversationService) DeleteConversation(userId int64) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	if conv, ok := s.state[userId]; ok {
-		conv.Close()
-		delete(s.state, userId)
+func (s *conversationService) AdvanceWithKb(
+	ctx context.Context,
+	b *tg_bot.Bot,
+	conversation *models.Conversation,
+	state constants.ConversationState,
+	prompt string,
+	kb *tg_models.InlineKeyboardMarkup,
+) {
+	s.senderService.Delete(ctx, b, conversation.ChatId, conversation.MessageId)
+
+	conversation.State = state
+	conversation.MessageId = s.sendPrompt(ctx, b, conversation.ChatId, prompt, kb)
+
+	if err := s.conversationRepository.Save(ctx, *conversation); err != nil {
+		s.senderService.SendError(ctx, b, conversation.ChatId, err)
+	}
+}
+
+// Resume reports whether the answer was consumed by a conversation, including
+// when loading the conversation failed and the user got an error message.
+func (s *conversationService) Resume(ctx context.Context, b *tg_bot.Bot, chatId int64, answer string) bool {
+	conversation, err := s.GetConversation(ctx, chatId)
+
+	if err != nil {
+		s.senderService.SendError(ctx, b, chatId, err)
+		return true
+	}
+
+	if conversation == nil {
+		return false
 	}
+
+	s.mu.RLock()
+	step, ok := s.steps[conversation.State]
+	s.mu.RUnlock()
+
+	if !ok {
+		s.logger.WithContext(ctx).Warn(fmt.Sprintf("Unknown conversation state %s for chat %d", conversation.State, chatId))
+		s.Finish(ctx, b, conversation)
+		return false
+	}
+
+	step(ctx, b, conversation, answer)
+
+	return true
+}
+
+func (s *conversationService) Finish(ctx context.Context, b *tg_bot.Bot, conversation *models.Conversation) {
+	s.senderService.Delete(ctx, b, conversation.ChatId, conversation.MessageId)
+
+	if err := s.conversationRepository.DeleteByChatId(ctx, conversation.ChatId); err != nil {
+		s.senderService.SendError(ctx, b, conversation.ChatId, err)
+	}
+}
+
+func (s *conversationService) Abandon(ctx context.Context, b *tg_bot.Bot, chatId int64) (bool, error) {
+	conversation, err := s.GetConversation(ctx, chatId)
+
+	if err != nil || conversation == nil {
+		return false, err
+	}
+
+	s.senderService.Delete(ctx, b, conversation.ChatId, conversation.MessageId)
+
+	if err := s.conversationRepository.DeleteByChatId(ctx, chatId); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetConversation returns nil without an error when the chat has no conversation.
+func (s *conversationService) GetConversation(ctx context.Context, chatId int64) (*models.Conversation, error) {
+	conversation, err := s.conversationRepository.GetByChatId(ctx, chatId)
+
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, nil
+	}
+
+	return conversation, err
+}
+
+func (s *conversationService) IsConversationExists(ctx context.Context, chatId int64) (bool, error) {
+	return s.conversationRepository.ExistsByChatId(ctx, chatId)
+}
+
+func (s *conversationService) sendPrompt(
+	ctx context.Context,
+	b *tg_bot.Bot,
+	chatId int64,
+	prompt string,
+	kb *tg_models.InlineKeyboardMarkup,
+) int {
+	if kb == nil {
+		return s.senderService.SendSafe(ctx, b, chatId, prompt)
+	}
+
+	return s.senderService.SendSafeWithKb(ctx, b, chatId, prompt, kb)
 }
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"errors"
+	tg_bot "github.com/go-telegram/bot"
+	"go.uber.org/dig"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/internal/db"
+	"rezvin-pro-bot/src/internal/logger"
+	"sync"
+	"time"
+)
+
+var errShuttingDown = errors.New("shutting down")
+
+type HealthReport struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+func (r HealthReport) IsOk() bool {
+	return r.Status == constants.HealthStatusOk
+}
+
+type IHealthService interface {
+	Readiness(ctx context.Context, b *tg_bot.Bot) HealthReport
+}
+
+type healthServiceDependencies struct {
+	dig.In
+
+	Logger          logger.ILogger   `name:"Logger"`
+	Database        db.IDatabase     `name:"Database"`
+	ShutdownService IShutdownService `name:"ShutdownService"`
+}
+
+type healthService struct {
+	logger          logger.ILogger
+	database        db.IDatabase
+	shutdownService IShutdownService
+
+	telegramMu        sync.Mutex
+	telegramCheckedAt time.Time
+	telegramErr       error
+}
+
+func NewHealthService(deps healthServiceDependencies) *healthService {
+	return &healthService{
+		logger:          deps.Logger,
+		database:        deps.Database,
+		shutdownService: deps.ShutdownService,
+	}
+}
+
+func (s *healthService) Readiness(ctx context.Context, b *tg_bot.Bot) HealthReport {
+	ctx, cancel := context.WithTimeout(ctx, constants.HealthCheckTimeout)
+	defer cancel()
+
+	report := HealthReport{
+		Status: constants.HealthStatusOk,
+		Checks: make(map[string]string),
+	}
+
+	check := func(name string, err error) {
+		if err == nil {
+			report.Checks[name] = constants.HealthStatusOk
+			return
+		}
+
+		report.Status = constants.HealthStatusUnavailable
+		report.Checks[name] = err.Error()
+	}
+
+	if s.shutdownService.IsShuttingDown() {
+		check("shutdown", errShuttingDown)
+	} else {
+		check("shutdown", nil)
+	}
+
+	check("database", s.database.Ping(ctx))
+
+	check("telegram", s.checkTelegram(ctx, b))
+
+	return report
+}
+
+// checkTelegram calls getMe at most once per TelegramHealthCacheTTL, so frequent probes
+// do not eat into the Bot API rate limits.
+func (s *healthService) checkTelegram(ctx context.Context, b *tg_bot.Bot) error {
+	s.telegramMu.Lock()
+	defer s.telegramMu.Unlock()
+
+	if !s.telegramCheckedAt.IsZero() && time.Since(s.telegramCheckedAt) < constants.TelegramHealthCacheTTL {
+		return s.telegramErr
+	}
+
+	_, err := b.GetMe(ctx)
+
+	s.telegramCheckedAt = time.Now()
+	s.telegramErr = err
+
+	return err
+}
@@ -4,65 +4,141 @@ import (
 	"context"
 	"fmt"
 	"go.uber.org/dig"
+	"rezvin-pro-bot/src/config"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/internal/db"
 	"rezvin-pro-bot/src/internal/logger"
 	"sync"
 )
 
 type ILockService interface {
-	Lock(key string)
+	Lock(ctx context.Context, key string) error
 	Unlock(key string)
-	TryLock(key string) bool
+	TryLock(ctx context.Context, key string) bool
 	Shutdown(ctx context.Context) error
 }
 
 type lockServiceDependencies struct {
 	dig.In
 
-	Logger logger.ILogger `name:"Logger"`
+	Logger   logger.ILogger `name:"Logger"`
+	Config   config.IConfig `name:"Config"`
+	Database db.IDatabase   `name:"Database"`
 }
 
-type lockService struct {
-	state  map[string]*sync.RWMutex
+func NewLockService(deps lockServiceDependencies) (ILockService, error) {
+	if deps.Config.LockBackend() != constants.PostgresLockBackend {
+		return NewMemoryLockService(deps.Logger), nil
+	}
+
+	if deps.Database.GetInstance() == nil {
+		return nil, fmt.Errorf("lock backend %s requires a database connection", constants.PostgresLockBackend)
+	}
+
+	return NewPostgresLockService(deps.Logger, deps.Database, deps.Config.PostgresMaxOpenConns())
+}
+
+type memoryLockEntry struct {
+	ch   chan struct{}
+	refs int
+}
+
+type memoryLockService struct {
+	state  map[string]*memoryLockEntry
+	mu     sync.Mutex
 	logger logger.ILogger
 }
 
-func NewLockService(deps lockServiceDependencies) *lockService {
-	return &lockService{
-		state:  make(map[string]*sync.RWMutex),
-		logger: deps.Logger,
+func NewMemoryLockService(logger logger.ILogger) *memoryLockService {
+	return &memoryLockService{
+		state:  make(map[string]*memoryLockEntry),
+		mu:     sync.Mutex{},
+		logger: logger,
 	}
 }
 
-func (ls *lockService) Lock(key string) {
-	if _, ok := ls.state[key]; !ok {
-		ls.state[key] = &sync.RWMutex{}
-	}
+func (ls *memoryLockService) Lock(ctx context.Context, key string) error {
+	entry := ls.acquire(key)
 
-	ls.state[key].Lock()
+	select {
+	case entry.ch <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		ls.release(key)
+		return ctx.Err()
+	}
 }
 
-func (ls *lockService) Unlock(key string) {
-	if _, ok := ls.state[key]; !ok {
+func (ls *memoryLockService) Unlock(key string) {
+	ls.mu.Lock()
+	entry, ok := ls.state[key]
+	ls.mu.Unlock()
+
+	if !ok {
 		ls.logger.Warn(fmt.Sprintf("Lock with key %s does not exist", key))
+		return
 	}
 
-	ls.state[key].Unlock()
-}
-
-func (ls *lockService) TryLock(key string) bool {
-	if _, ok := ls.state[key]; !ok {
-		ls.state[key] = &sync.RWMutex{}
+	select {
+	case <-entry.ch:
+	default:
+		ls.logger.Warn(fmt.Sprintf("Lock with key %s is not locked", key))
+		return
 	}
 
-	return ls.state[key].TryLock()
+	ls.release(key)
 }
 
-func (ls *lockService) Shutdown(_ context.Context) error {
-	for key, _ := range ls.state {
-		delete(ls.state, key)
+func (ls *memoryLockService) TryLock(_ context.Context, key string) bool {
+	entry := ls.acquire(key)
+
+	select {
+	case entry.ch <- struct{}{}:
+		return true
+	default:
+		ls.release(key)
+		return false
 	}
+}
+
+func (ls *memoryLockService) Shutdown(_ context.Context) error {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
 
 	clear(ls.state)
 
 	return nil
 }
+
+func (ls *memoryLockService) acquire(key string) *memoryLockEntry {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	entry, ok := ls.state[key]
+
+	if !ok {
+		entry = &memoryLockEntry{ch: make(chan struct{}, 1)}
+		ls.state[key] = entry
+	}
+
+	entry.refs++
+
+	return entry
+}
+
+func (ls *memoryLockService) release(key string) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	entry, ok := ls.state[key]
+
+	if !ok {
+		return
+	}
+
+	entry.refs--
+
+	if entry.refs <= 0 {
+		delete(ls.state, key)
+	}
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"rezvin-pro-bot/src/config"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/internal/db"
+	"rezvin-pro-bot/src/internal/logger"
+	"testing"
+)
+
+func newLockServiceDependencies(t *testing.T, backend constants.LockBackend) lockServiceDependencies {
+	t.Setenv("APP_ENV", "development")
+	t.Setenv("BOT_TOKEN", "1:test")
+	t.Setenv("POSTGRES_DSN", "unused")
+	t.Setenv("ALERT_CHAT_ID", "-100")
+	t.Setenv("LOCK_BACKEND", string(backend))
+
+	return lockServiceDependencies{
+		Logger:   logger.NewLogger(constants.ErrorLogLevel, constants.TextLogFormat),
+		Config:   config.NewConfig(),
+		Database: db.NewMemoryDatabase(),
+	}
+}
+
+func TestNewLockServicePostgresRequiresDatabase(t *testing.T) {
+	_, err := NewLockService(newLockServiceDependencies(t, constants.PostgresLockBackend))
+
+	if err == nil {
+		t.Fatal("expected an error for the postgres backend without a database connection")
+	}
+}
+
+func TestMemoryLockServiceTryLock(t *testing.T) {
+	ls, err := NewLockService(newLockServiceDependencies(t, constants.MemoryLockBackend))
+
+	if err != nil {
+		t.Fatalf("NewLockService: %v", err)
+	}
+
+	ctx := context.Background()
+
+	if !ls.TryLock(ctx, "1:1") {
+		t.Fatal("expected the first TryLock to take the lock")
+	}
+
+	if ls.TryLock(ctx, "1:1") {
+		t.Fatal("expected TryLock to fail while the lock is held")
+	}
+
+	if !ls.TryLock(ctx, "1:2") {
+		t.Fatal("expected a different key to be lockable")
+	}
+
+	ls.Unlock("1:1")
+
+	if !ls.TryLock(ctx, "1:1") {
+		t.Fatal("expected TryLock to succeed after Unlock")
+	}
+}
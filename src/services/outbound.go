@@ -0,0 +1,317 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	tg_bot "github.com/go-telegram/bot"
+	"go.uber.org/dig"
+	"regexp"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/internal/logger"
+	"rezvin-pro-bot/src/internal/metrics"
+	"rezvin-pro-bot/src/models"
+	"rezvin-pro-bot/src/repositories"
+	"rezvin-pro-bot/src/utils/ratelimit"
+	"strings"
+	"sync"
+	"time"
+)
+
+type OutboundCall func(ctx context.Context) error
+
+type IOutboundService interface {
+	Send(ctx context.Context, chatId int64, method string, payload any, call OutboundCall) error
+	Enqueue(ctx context.Context, chatId int64, method string, payload any, call OutboundCall)
+	Shutdown(ctx context.Context) error
+}
+
+type outboundServiceDependencies struct {
+	dig.In
+
+	Logger               logger.ILogger                     `name:"Logger"`
+	Metrics              metrics.IMetrics                   `name:"Metrics"`
+	AlertService         IAlertService                      `name:"AlertService"`
+	DeadLetterRepository repositories.IDeadLetterRepository `name:"DeadLetterRepository"`
+}
+
+type outboundJob struct {
+	ctx     context.Context
+	chatId  int64
+	method  string
+	payload any
+	call    OutboundCall
+	// done is nil for enqueued jobs nobody waits for.
+	done chan error
+}
+
+type outboundService struct {
+	logger               logger.ILogger
+	metrics              metrics.IMetrics
+	alertService         IAlertService
+	deadLetterRepository repositories.IDeadLetterRepository
+	limiter              *ratelimit.ChatLimiter
+	queues               map[int64][]*outboundJob
+	mu                   sync.Mutex
+	workers              sync.WaitGroup
+}
+
+var (
+	serverErrorRegexp = regexp.MustCompile(`error response from telegram for method \w+, 5\d\d `)
+
+	errOutboundQueueFull = errors.New("outbound queue is full")
+)
+
+func NewOutboundService(deps outboundServiceDependencies) *outboundService {
+	return &outboundService{
+		logger:               deps.Logger,
+		metrics:              deps.Metrics,
+		alertService:         deps.AlertService,
+		deadLetterRepository: deps.DeadLetterRepository,
+		limiter:              ratelimit.NewChatLimiter(),
+		queues:               make(map[int64][]*outboundJob),
+		mu:                   sync.Mutex{},
+	}
+}
+
+// Send queues the call behind earlier sends to the same chat and waits for its result,
+// callers that need the sent message use it.
+func (s *outboundService) Send(ctx context.Context, chatId int64, method string, payload any, call OutboundCall) error {
+	job := &outboundJob{ctx: ctx, chatId: chatId, method: method, payload: payload, call: call, done: make(chan error, 1)}
+
+	if !s.enqueue(job) {
+		return errOutboundQueueFull
+	}
+
+	select {
+	case err := <-job.done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Enqueue queues the call and returns at once. The call outlives the handler, so it
+// only keeps the values of ctx and is bounded by OutboundSendTimeout instead.
+func (s *outboundService) Enqueue(ctx context.Context, chatId int64, method string, payload any, call OutboundCall) {
+	s.enqueue(&outboundJob{ctx: context.WithoutCancel(ctx), chatId: chatId, method: method, payload: payload, call: call})
+}
+
+func (s *outboundService) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+
+	go func() {
+		s.workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// enqueue starts a worker for the chat when its queue was empty. The job being sent stays
+// at the head of the queue until it is done, so there is at most one worker per chat.
+func (s *outboundService) enqueue(job *outboundJob) bool {
+	s.mu.Lock()
+
+	queue := s.queues[job.chatId]
+
+	if len(queue) >= constants.MaxQueuedSendsPerChat {
+		s.mu.Unlock()
+		s.deadLetter(job, 0, errOutboundQueueFull)
+		return false
+	}
+
+	s.queues[job.chatId] = append(queue, job)
+
+	if len(queue) == 0 {
+		s.workers.Add(1)
+		go s.drain(job.chatId)
+	}
+
+	s.mu.Unlock()
+
+	return true
+}
+
+func (s *outboundService) drain(chatId int64) {
+	defer s.workers.Done()
+
+	for {
+		s.mu.Lock()
+		job := s.queues[chatId][0]
+		s.mu.Unlock()
+
+		err := s.process(job)
+
+		if job.done != nil {
+			job.done <- err
+		}
+
+		s.mu.Lock()
+		queue := s.queues[chatId][1:]
+
+		if len(queue) == 0 {
+			delete(s.queues, chatId)
+			s.mu.Unlock()
+			return
+		}
+
+		s.queues[chatId] = queue
+		s.mu.Unlock()
+	}
+}
+
+func (s *outboundService) process(job *outboundJob) (err error) {
+	ctx := job.ctx
+
+	if job.done == nil {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, constants.OutboundSendTimeout)
+		defer cancel()
+	}
+
+	attempts := 0
+
+	defer func() {
+		if r := recover(); r != nil {
+			s.alertService.ReportPanic(ctx, r)
+			err = fmt.Errorf("%s to chat %d panicked: %v", job.method, job.chatId, r)
+		}
+
+		if err != nil {
+			s.deadLetter(job, attempts, err)
+		}
+	}()
+
+	if err = s.limiter.Wait(ctx, job.chatId); err != nil {
+		return err
+	}
+
+	attempts, err = s.retry(ctx, job.method, job.call)
+
+	return err
+}
+
+// deadLetter records every send that never reached the chat, including the ones whose
+// context was cancelled, so the row is written with a context that cannot be cancelled.
+func (s *outboundService) deadLetter(job *outboundJob, attempts int, err error) {
+	ctx := context.WithoutCancel(job.ctx)
+
+	s.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+		"method":   job.method,
+		"attempts": attempts,
+	}).Error(fmt.Sprintf("Failed to %s to chat %d, moving it to dead letters", job.method, job.chatId))
+
+	s.metrics.ObserveTelegramCall(job.method, constants.TelegramCallDeadLettered)
+	s.alertService.Report(ctx, constants.TelegramAlertKind, fmt.Errorf("%s to chat %d failed after %d attempts: %w", job.method, job.chatId, attempts, err))
+
+	_, deadLetterErr := s.deadLetterRepository.Create(ctx, models.DeadLetter{
+		ChatId:   job.chatId,
+		Method:   job.method,
+		Payload:  encodePayload(job.payload),
+		Error:    err.Error(),
+		Attempts: attempts,
+	})
+
+	if deadLetterErr != nil {
+		s.logger.WithContext(ctx).WithError(deadLetterErr).Error(fmt.Sprintf("Failed to save dead letter for %s to chat %d", job.method, job.chatId))
+		s.alertService.Report(ctx, constants.RepositoryAlertKind, deadLetterErr)
+	}
+}
+
+func (s *outboundService) retry(ctx context.Context, method string, call OutboundCall) (int, error) {
+	var err error
+
+	for attempt := 1; attempt <= constants.MaxSendAttempts; attempt++ {
+		err = call(ctx)
+
+		if err == nil {
+			s.metrics.ObserveTelegramCall(method, constants.TelegramCallOk)
+			return attempt, nil
+		}
+
+		if ctx.Err() != nil {
+			s.metrics.ObserveTelegramCall(method, constants.TelegramCallCanceled)
+			return attempt, err
+		}
+
+		delay, ok := retryDelay(err, attempt)
+
+		s.metrics.ObserveTelegramCall(method, callOutcome(err, ok))
+
+		if !ok || attempt == constants.MaxSendAttempts {
+			return attempt, err
+		}
+
+		s.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+			"method":  method,
+			"attempt": attempt,
+			"delay":   delay.String(),
+		}).Warn(fmt.Sprintf("Retrying %s", method))
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return attempt, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	return constants.MaxSendAttempts, err
+}
+
+func retryDelay(err error, attempt int) (time.Duration, bool) {
+	var tooManyRequests *tg_bot.TooManyRequestsError
+
+	if errors.As(err, &tooManyRequests) {
+		return time.Duration(tooManyRequests.RetryAfter) * time.Second, true
+	}
+
+	message := err.Error()
+
+	if !serverErrorRegexp.MatchString(message) &&
+		!strings.HasPrefix(message, "error do request") &&
+		!strings.HasPrefix(message, "error decode response body") {
+		return 0, false
+	}
+
+	delay := constants.SendRetryBaseDelay << (attempt - 1)
+
+	if delay > constants.SendRetryMaxDelay {
+		delay = constants.SendRetryMaxDelay
+	}
+
+	return delay, true
+}
+
+func callOutcome(err error, retryable bool) string {
+	var tooManyRequests *tg_bot.TooManyRequestsError
+
+	switch {
+	case errors.As(err, &tooManyRequests):
+		return constants.TelegramCallRateLimited
+	case retryable:
+		return constants.TelegramCallTransientError
+	default:
+		return constants.TelegramCallError
+	}
+}
+
+func encodePayload(payload any) string {
+	data, err := json.Marshal(payload)
+
+	if err != nil {
+		return fmt.Sprintf("%+v", payload)
+	}
+
+	return string(data)
+}
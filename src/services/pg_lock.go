@@ -0,0 +1,197 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"rezvin-pro-bot/src/internal/db"
+	"rezvin-pro-bot/src/internal/logger"
+	"rezvin-pro-bot/src/utils"
+	"sync"
+	"time"
+)
+
+const postgresUnlockTimeout = 5 * time.Second
+
+// Every held lock pins a pool connection for the whole handler run, so only
+// part of the pool may be taken by locks and the rest stays free for queries.
+const postgresLockPoolShare = 2
+
+type postgresLockService struct {
+	db     *sql.DB
+	held   map[string]*sql.Conn
+	slots  chan struct{}
+	mu     sync.Mutex
+	logger logger.ILogger
+}
+
+func NewPostgresLockService(logger logger.ILogger, database db.IDatabase, maxOpenConns int) (*postgresLockService, error) {
+	sqlDB, err := database.GetInstance().DB()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database instance: %w", err)
+	}
+
+	return &postgresLockService{
+		db:     sqlDB,
+		held:   make(map[string]*sql.Conn),
+		slots:  make(chan struct{}, max(maxOpenConns/postgresLockPoolShare, 1)),
+		mu:     sync.Mutex{},
+		logger: logger,
+	}, nil
+}
+
+func (ls *postgresLockService) Lock(ctx context.Context, key string) error {
+	select {
+	case ls.slots <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	conn, err := ls.db.Conn(ctx)
+
+	if err != nil {
+		ls.release()
+		return err
+	}
+
+	_, err = conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", advisoryLockId(key))
+
+	if err != nil {
+		ls.discard(conn)
+		ls.release()
+		return err
+	}
+
+	ls.hold(key, conn)
+
+	return nil
+}
+
+func (ls *postgresLockService) Unlock(key string) {
+	ls.mu.Lock()
+	conn, ok := ls.held[key]
+	delete(ls.held, key)
+	ls.mu.Unlock()
+
+	if !ok {
+		ls.logger.Warn(fmt.Sprintf("Lock with key %s does not exist", key))
+		return
+	}
+
+	ls.unlock(key, conn)
+	ls.release()
+}
+
+func (ls *postgresLockService) TryLock(ctx context.Context, key string) bool {
+	select {
+	case ls.slots <- struct{}{}:
+	default:
+		ls.logger.WithContext(ctx).Warn(fmt.Sprintf("Too many advisory locks are held, lock %s is not taken", key))
+		return false
+	}
+
+	conn, err := ls.db.Conn(ctx)
+
+	if err != nil {
+		ls.release()
+		ls.logTryLockError(ctx, key, err)
+		return false
+	}
+
+	var locked bool
+
+	err = conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", advisoryLockId(key)).Scan(&locked)
+
+	if err != nil || !locked {
+		ls.discard(conn)
+		ls.release()
+		ls.logTryLockError(ctx, key, err)
+		return false
+	}
+
+	ls.hold(key, conn)
+
+	return true
+}
+
+func (ls *postgresLockService) Shutdown(_ context.Context) error {
+	ls.mu.Lock()
+	held := ls.held
+	ls.held = make(map[string]*sql.Conn)
+	ls.mu.Unlock()
+
+	for key, conn := range held {
+		ls.unlock(key, conn)
+		ls.release()
+	}
+
+	return nil
+}
+
+func (ls *postgresLockService) hold(key string, conn *sql.Conn) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	if previous, ok := ls.held[key]; ok {
+		ls.logger.Warn(fmt.Sprintf("Lock with key %s is already held by this process", key))
+		ls.discard(previous)
+		ls.release()
+	}
+
+	ls.held[key] = conn
+}
+
+func (ls *postgresLockService) unlock(key string, conn *sql.Conn) {
+	ctx, cancel := context.WithTimeout(context.Background(), postgresUnlockTimeout)
+	defer cancel()
+
+	_, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", advisoryLockId(key))
+
+	if err != nil {
+		ls.logger.Error(fmt.Sprintf("Failed to release advisory lock %s: %s", key, err))
+		ls.discard(conn)
+		return
+	}
+
+	err = conn.Close()
+
+	if err != nil {
+		ls.logger.Error(fmt.Sprintf("Failed to return advisory lock connection %s: %s", key, err))
+	}
+}
+
+func (ls *postgresLockService) release() {
+	<-ls.slots
+}
+
+func (ls *postgresLockService) logTryLockError(ctx context.Context, key string, err error) {
+	if err == nil || utils.IsContextError(err) {
+		return
+	}
+
+	ls.logger.WithContext(ctx).WithError(err).Error(fmt.Sprintf("Failed to take advisory lock %s", key))
+}
+
+// discard drops the connection from the pool, so a session-level lock
+// that might still be held on it is released by closing the session.
+func (ls *postgresLockService) discard(conn *sql.Conn) {
+	_ = conn.Raw(func(_ any) error {
+		return driver.ErrBadConn
+	})
+
+	err := conn.Close()
+
+	if err != nil && !errors.Is(err, sql.ErrConnDone) {
+		ls.logger.Error(fmt.Sprintf("Failed to discard advisory lock connection: %s", err))
+	}
+}
+
+func advisoryLockId(key string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	return int64(h.Sum64())
+}
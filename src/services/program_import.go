@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go.uber.org/dig"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/repositories"
+	"rezvin-pro-bot/src/utils/importer"
+)
+
+type IProgramImportService interface {
+	Prepare(ctx context.Context, chatId int64, filename string, data []byte) (*importer.Plan, string, error)
+	Apply(ctx context.Context, encodedPlan string) (*importer.Plan, error)
+}
+
+type programImportServiceDependencies struct {
+	dig.In
+
+	ProgramRepository    repositories.IProgramRepository  `name:"ProgramRepository"`
+	ExerciseRepository   repositories.IExerciseRepository `name:"ExerciseRepository"`
+	CallbackStateService ICallbackStateService            `name:"CallbackStateService"`
+}
+
+type programImportService struct {
+	programRepository    repositories.IProgramRepository
+	exerciseRepository   repositories.IExerciseRepository
+	callbackStateService ICallbackStateService
+}
+
+func NewProgramImportService(deps programImportServiceDependencies) *programImportService {
+	return &programImportService{
+		programRepository:    deps.ProgramRepository,
+		exerciseRepository:   deps.ExerciseRepository,
+		callbackStateService: deps.CallbackStateService,
+	}
+}
+
+// Prepare builds the dry-run plan and keeps it in the callback state store, the returned
+// callback data of the confirm button carries it back to Apply. It is empty when there is nothing to change.
+func (s *programImportService) Prepare(ctx context.Context, chatId int64, filename string, data []byte) (*importer.Plan, string, error) {
+	doc, err := importer.Parse(filename, data)
+
+	if err != nil {
+		return nil, "", &importer.DocumentError{Err: err}
+	}
+
+	if err = importer.Normalize(doc); err != nil {
+		return nil, "", &importer.DocumentError{Err: err}
+	}
+
+	existing := make(map[string]importer.ExistingProgram)
+
+	for _, spec := range doc.Programs {
+		program, err := s.programRepository.GetByName(ctx, spec.Name)
+
+		if errors.Is(err, repositories.ErrNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		exercises, err := s.exerciseRepository.GetAllByProgramId(ctx, program.Id)
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		existing[spec.Name] = importer.ExistingProgram{
+			Program:   *program,
+			Exercises: exercises,
+		}
+	}
+
+	plan := importer.BuildPlan(*doc, existing)
+
+	if plan.IsEmpty() {
+		return &plan, "", nil
+	}
+
+	encoded, err := json.Marshal(plan)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	callbackData, err := s.callbackStateService.Store(ctx, chatId, constants.ImportConfirm, nil, map[string]string{
+		constants.ImportPlanDataKey: string(encoded),
+	})
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &plan, callbackData, nil
+}
+
+// Apply returns nil without an error when there is no plan, e.g. the stored one has expired.
+func (s *programImportService) Apply(ctx context.Context, encodedPlan string) (*importer.Plan, error) {
+	if encodedPlan == "" {
+		return nil, nil
+	}
+
+	var plan importer.Plan
+
+	if err := json.Unmarshal([]byte(encodedPlan), &plan); err != nil {
+		return nil, err
+	}
+
+	if err := s.programRepository.Import(ctx, plan); err != nil {
+		return nil, err
+	}
+
+	return &plan, nil
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	tg_bot "github.com/go-telegram/bot"
+	"go.uber.org/dig"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/internal/logger"
+	"rezvin-pro-bot/src/models"
+	"rezvin-pro-bot/src/repositories"
+	"rezvin-pro-bot/src/utils/inline_keyboards"
+	"rezvin-pro-bot/src/utils/messages"
+	"sync"
+	"time"
+)
+
+type IRestTimerService interface {
+	Start(ctx context.Context, b *tg_bot.Bot, chatId int64, workoutSessionId uint, seconds constants.RestSeconds) error
+	Cancel(ctx context.Context, chatId int64) (bool, error)
+	Restore(ctx context.Context, b *tg_bot.Bot) error
+	Shutdown(ctx context.Context) error
+}
+
+type restTimerServiceDependencies struct {
+	dig.In
+
+	Logger              logger.ILogger                    `name:"Logger"`
+	SenderService       ISenderService                    `name:"SenderService"`
+	AlertService        IAlertService                     `name:"AlertService"`
+	RestTimerRepository repositories.IRestTimerRepository `name:"RestTimerRepository"`
+}
+
+type restTimerEntry struct {
+	timer *time.Timer
+}
+
+type restTimerService struct {
+	logger              logger.ILogger
+	senderService       ISenderService
+	alertService        IAlertService
+	restTimerRepository repositories.IRestTimerRepository
+	timers              map[int64]*restTimerEntry
+	mu                  sync.Mutex
+}
+
+func NewRestTimerService(deps restTimerServiceDependencies) *restTimerService {
+	return &restTimerService{
+		logger:              deps.Logger,
+		senderService:       deps.SenderService,
+		alertService:        deps.AlertService,
+		restTimerRepository: deps.RestTimerRepository,
+		timers:              make(map[int64]*restTimerEntry),
+		mu:                  sync.Mutex{},
+	}
+}
+
+func (s *restTimerService) Start(
+	ctx context.Context,
+	b *tg_bot.Bot,
+	chatId int64,
+	workoutSessionId uint,
+	seconds constants.RestSeconds,
+) error {
+	// Postgres stores microseconds and Claim matches fire_at exactly.
+	timer := models.RestTimer{
+		ChatId:           chatId,
+		WorkoutSessionId: workoutSessionId,
+		Seconds:          uint(seconds),
+		FireAt:           time.Now().Add(time.Duration(seconds) * time.Second).Truncate(time.Microsecond),
+	}
+
+	if err := s.restTimerRepository.Save(ctx, timer); err != nil {
+		return err
+	}
+
+	s.schedule(b, timer)
+
+	return nil
+}
+
+func (s *restTimerService) Cancel(ctx context.Context, chatId int64) (bool, error) {
+	s.mu.Lock()
+	entry, ok := s.timers[chatId]
+	delete(s.timers, chatId)
+	s.mu.Unlock()
+
+	if ok {
+		entry.timer.Stop()
+	}
+
+	if err := s.restTimerRepository.DeleteByChatId(ctx, chatId); err != nil {
+		return ok, err
+	}
+
+	return ok, nil
+}
+
+func (s *restTimerService) Restore(ctx context.Context, b *tg_bot.Bot) error {
+	timers, err := s.restTimerRepository.GetAll(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	for _, timer := range timers {
+		s.schedule(b, timer)
+	}
+
+	if len(timers) > 0 {
+		s.logger.Log(fmt.Sprintf("Restored %d rest timers", len(timers)))
+	}
+
+	return nil
+}
+
+func (s *restTimerService) Shutdown(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for chatId, entry := range s.timers {
+		entry.timer.Stop()
+		delete(s.timers, chatId)
+	}
+
+	return nil
+}
+
+func (s *restTimerService) schedule(b *tg_bot.Bot, timer models.RestTimer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.timers[timer.ChatId]; ok {
+		existing.timer.Stop()
+	}
+
+	entry := &restTimerEntry{}
+
+	entry.timer = time.AfterFunc(max(time.Until(timer.FireAt), 0), func() {
+		s.fire(b, timer, entry)
+	})
+
+	s.timers[timer.ChatId] = entry
+}
+
+func (s *restTimerService) fire(b *tg_bot.Bot, timer models.RestTimer, entry *restTimerEntry) {
+	s.mu.Lock()
+
+	if s.timers[timer.ChatId] != entry {
+		s.mu.Unlock()
+		return
+	}
+
+	delete(s.timers, timer.ChatId)
+	s.mu.Unlock()
+
+	ctx := context.Background()
+
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.Error(fmt.Sprintf("Failed to finish rest timer for chat %d: %v", timer.ChatId, r))
+			s.alertService.ReportPanic(ctx, r)
+		}
+	}()
+
+	claimed, err := s.restTimerRepository.Claim(ctx, timer.ChatId, timer.FireAt)
+
+	if err != nil {
+		s.logger.WithError(err).Error(fmt.Sprintf("Failed to claim rest timer for chat %d", timer.ChatId))
+		s.alertService.Report(ctx, constants.RepositoryAlertKind, err)
+		return
+	}
+
+	// Another replica has already notified the chat, or the timer was cancelled or restarted meanwhile.
+	if !claimed {
+		return
+	}
+
+	msg := messages.RestTimerFinishedMessage(timer.Seconds)
+	kb := inline_keyboards.UserWorkoutResume(timer.WorkoutSessionId)
+
+	s.senderService.NotifyWithKb(ctx, b, timer.ChatId, msg, kb)
+}
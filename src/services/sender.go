@@ -1,14 +1,23 @@
 package services
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	tg_bot "github.com/go-telegram/bot"
 	tg_models "github.com/go-telegram/bot/models"
 	"go.uber.org/dig"
+	"rezvin-pro-bot/src/constants"
 	"rezvin-pro-bot/src/internal/logger"
 	"rezvin-pro-bot/src/models"
 	"rezvin-pro-bot/src/repositories"
 	"rezvin-pro-bot/src/utils"
+	bot_utils "rezvin-pro-bot/src/utils/bot"
+	utils_context "rezvin-pro-bot/src/utils/context"
+	"rezvin-pro-bot/src/utils/messages"
+	"strings"
+	"time"
 )
 
 type ISenderService interface {
@@ -29,6 +38,26 @@ type ISenderService interface {
 		message string,
 		kb *tg_models.InlineKeyboardMarkup,
 	) int
+	SendPhotoWithKb(
+		ctx context.Context,
+		b *tg_bot.Bot,
+		chatId int64,
+		photo []byte,
+		caption string,
+		kb *tg_models.InlineKeyboardMarkup,
+	) int
+	SendDocumentWithKb(
+		ctx context.Context,
+		b *tg_bot.Bot,
+		chatId int64,
+		filename string,
+		document []byte,
+		caption string,
+		kb *tg_models.InlineKeyboardMarkup,
+	) int
+	Notify(ctx context.Context, b *tg_bot.Bot, chatId int64, message string)
+	NotifyWithKb(ctx context.Context, b *tg_bot.Bot, chatId int64, message string, kb *tg_models.InlineKeyboardMarkup)
+	SendError(ctx context.Context, b *tg_bot.Bot, chatId int64, err error)
 	Delete(ctx context.Context, b *tg_bot.Bot, chatId int64, messageId int)
 }
 
@@ -36,28 +65,51 @@ type senderServiceDependencies struct {
 	dig.In
 
 	Logger                logger.ILogger                          `name:"Logger"`
+	AlertService          IAlertService                           `name:"AlertService"`
+	OutboundService       IOutboundService                        `name:"OutboundService"`
+	CallbackStateService  ICallbackStateService                   `name:"CallbackStateService"`
 	LastMessageRepository repositories.ILastUserMessageRepository `name:"LastUserMessageRepository"`
 }
 
 type senderService struct {
 	logger                logger.ILogger
+	alertService          IAlertService
+	outboundService       IOutboundService
+	callbackStateService  ICallbackStateService
 	lastMessageRepository repositories.ILastUserMessageRepository
 }
 
 func NewSenderService(deps senderServiceDependencies) *senderService {
 	return &senderService{
 		logger:                deps.Logger,
+		alertService:          deps.AlertService,
+		outboundService:       deps.OutboundService,
+		callbackStateService:  deps.CallbackStateService,
 		lastMessageRepository: deps.LastMessageRepository,
 	}
 }
 
 func (s *senderService) AnswerCallbackQuery(ctx context.Context, b *tg_bot.Bot, update *tg_models.Update) bool {
-	result, err := b.AnswerCallbackQuery(ctx, &tg_bot.AnswerCallbackQueryParams{
+	var result bool
+
+	chatId := bot_utils.GetChatID(update)
+	params := &tg_bot.AnswerCallbackQueryParams{
 		CallbackQueryID: update.CallbackQuery.ID,
 		ShowAlert:       false,
+	}
+
+	err := s.outboundService.Send(ctx, chatId, "answerCallbackQuery", params, func(ctx context.Context) error {
+		var err error
+
+		result, err = b.AnswerCallbackQuery(ctx, params)
+
+		return err
 	})
 
-	utils.PanicIfError(err)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Error(fmt.Sprintf("Failed to answer callback query %s in chat %d", params.CallbackQueryID, chatId))
+		return false
+	}
 
 	return result
 }
@@ -69,6 +121,8 @@ func (s *senderService) SendWithKb(
 	message string,
 	kb *tg_models.InlineKeyboardMarkup,
 ) int {
+	kb = s.storeLongCallbackData(ctx, chatId, kb)
+
 	return s.send(ctx, b, &tg_bot.SendMessageParams{
 		ChatID:      chatId,
 		Text:        message,
@@ -84,6 +138,8 @@ func (s *senderService) SendSafeWithKb(
 	message string,
 	kb *tg_models.InlineKeyboardMarkup,
 ) int {
+	kb = s.storeLongCallbackData(ctx, chatId, kb)
+
 	return s.send(ctx, b, &tg_bot.SendMessageParams{
 		ChatID:      chatId,
 		Text:        message,
@@ -108,54 +164,348 @@ func (s *senderService) SendSafe(ctx context.Context, b *tg_bot.Bot, chatId int6
 	}, true)
 }
 
+// Notify queues the message and returns at once, for broadcasts and notifications
+// to other chats that the handler does not need to wait for.
+func (s *senderService) Notify(ctx context.Context, b *tg_bot.Bot, chatId int64, message string) {
+	s.NotifyWithKb(ctx, b, chatId, message, nil)
+}
+
+func (s *senderService) NotifyWithKb(
+	ctx context.Context,
+	b *tg_bot.Bot,
+	chatId int64,
+	message string,
+	kb *tg_models.InlineKeyboardMarkup,
+) {
+	params := &tg_bot.SendMessageParams{
+		ChatID:    chatId,
+		Text:      message,
+		ParseMode: tg_models.ParseModeMarkdown,
+	}
+
+	if kb != nil {
+		params.ReplyMarkup = s.storeLongCallbackData(ctx, chatId, kb)
+	}
+
+	s.outboundService.Enqueue(ctx, chatId, "sendMessage", params, func(ctx context.Context) error {
+		_, err := b.SendMessage(ctx, params)
+
+		return err
+	})
+}
+
+func (s *senderService) SendPhotoWithKb(
+	ctx context.Context,
+	b *tg_bot.Bot,
+	chatId int64,
+	photo []byte,
+	caption string,
+	kb *tg_models.InlineKeyboardMarkup,
+) int {
+	var msg *tg_models.Message
+
+	kb = s.storeLongCallbackData(ctx, chatId, kb)
+	payload := map[string]any{"filename": "chart.png", "caption": caption, "reply_markup": kb}
+
+	err := s.outboundService.Send(ctx, chatId, "sendPhoto", payload, func(ctx context.Context) error {
+		var err error
+
+		msg, err = b.SendPhoto(ctx, &tg_bot.SendPhotoParams{
+			ChatID: chatId,
+			Photo: &tg_models.InputFileUpload{
+				Filename: "chart.png",
+				Data:     bytes.NewReader(photo),
+			},
+			Caption:     caption,
+			ReplyMarkup: kb,
+			ParseMode:   tg_models.ParseModeMarkdown,
+		})
+
+		return err
+	})
+
+	if err != nil {
+		return 0
+	}
+
+	s.replaceLastMessage(ctx, b, chatId, msg.ID)
+
+	return msg.ID
+}
+
+func (s *senderService) SendDocumentWithKb(
+	ctx context.Context,
+	b *tg_bot.Bot,
+	chatId int64,
+	filename string,
+	document []byte,
+	caption string,
+	kb *tg_models.InlineKeyboardMarkup,
+) int {
+	var msg *tg_models.Message
+
+	kb = s.storeLongCallbackData(ctx, chatId, kb)
+	payload := map[string]any{"filename": filename, "caption": caption, "reply_markup": kb}
+
+	err := s.outboundService.Send(ctx, chatId, "sendDocument", payload, func(ctx context.Context) error {
+		var err error
+
+		msg, err = b.SendDocument(ctx, &tg_bot.SendDocumentParams{
+			ChatID: chatId,
+			Document: &tg_models.InputFileUpload{
+				Filename: filename,
+				Data:     bytes.NewReader(document),
+			},
+			Caption:     caption,
+			ReplyMarkup: kb,
+			ParseMode:   tg_models.ParseModeMarkdown,
+		})
+
+		return err
+	})
+
+	if err != nil {
+		return 0
+	}
+
+	s.replaceLastMessage(ctx, b, chatId, msg.ID)
+
+	return msg.ID
+}
+
+// storeLongCallbackData moves buttons whose callback data exceeds the Telegram
+// limit to the callback state store, the button then carries only a token.
+func (s *senderService) storeLongCallbackData(
+	ctx context.Context,
+	chatId int64,
+	kb *tg_models.InlineKeyboardMarkup,
+) *tg_models.InlineKeyboardMarkup {
+	if kb == nil {
+		return nil
+	}
+
+	stored := &tg_models.InlineKeyboardMarkup{InlineKeyboard: make([][]tg_models.InlineKeyboardButton, len(kb.InlineKeyboard))}
+
+	for i, row := range kb.InlineKeyboard {
+		stored.InlineKeyboard[i] = make([]tg_models.InlineKeyboardButton, len(row))
+
+		for j, button := range row {
+			stored.InlineKeyboard[i][j] = button
+
+			if len(button.CallbackData) <= constants.MaxCallbackDataLength {
+				continue
+			}
+
+			params, err := bot_utils.DecodeCallbackData(button.CallbackData)
+
+			if err == nil {
+				prefix := bot_utils.CallbackDataPrefix(button.CallbackData)
+				stored.InlineKeyboard[i][j].CallbackData, err = s.callbackStateService.Store(ctx, chatId, prefix, params, nil)
+			}
+
+			if err != nil {
+				s.logger.WithContext(ctx).WithError(err).Error(fmt.Sprintf("Failed to store callback data: %s", button.CallbackData))
+				stored.InlineKeyboard[i][j].CallbackData = button.CallbackData
+			}
+		}
+	}
+
+	return stored
+}
+
 func (s *senderService) send(ctx context.Context, b *tg_bot.Bot, params *tg_bot.SendMessageParams, safe bool) int {
 	chatId := params.ChatID.(int64)
 
-	msg, err := b.SendMessage(ctx, params)
+	if !safe {
+		if messageId, ok := s.editInPlace(ctx, b, params); ok {
+			return messageId
+		}
+	}
+
+	var msg *tg_models.Message
+
+	err := s.outboundService.Send(ctx, chatId, "sendMessage", params, func(ctx context.Context) error {
+		var err error
+
+		msg, err = b.SendMessage(ctx, params)
+
+		return err
+	})
 
-	utils.PanicIfNotContextError(err)
+	if err != nil {
+		return 0
+	}
 
 	if !safe {
-		lastMsg := s.lastMessageRepository.GetByChatId(ctx, chatId)
-
-		if lastMsg == nil {
-			s.lastMessageRepository.Create(ctx, models.LastUserMessage{
-				ChatId:    chatId,
-				MessageId: msg.ID,
-			})
-		} else {
-			s.lastMessageRepository.UpdateByChatId(ctx, chatId, models.LastUserMessage{
-				MessageId: msg.ID,
-			})
-
-			ok, err := b.DeleteMessage(ctx, &tg_bot.DeleteMessageParams{
-				ChatID:    chatId,
-				MessageID: lastMsg.MessageId,
-			})
-
-			if err != nil || !ok {
-				s.logger.Error(err.Error())
-				s.lastMessageRepository.DeleteByChatId(ctx, chatId)
-			}
-		}
+		s.replaceLastMessage(ctx, b, chatId, msg.ID)
 	}
 
 	return msg.ID
 }
 
+func (s *senderService) editInPlace(ctx context.Context, b *tg_bot.Bot, params *tg_bot.SendMessageParams) (int, bool) {
+	chatId := params.ChatID.(int64)
+	callbackMsg := utils_context.GetCallbackMessageFromContext(ctx)
+
+	if callbackMsg == nil || callbackMsg.Chat.ID != chatId || callbackMsg.Text == "" {
+		return 0, false
+	}
+
+	if time.Since(time.Unix(int64(callbackMsg.Date), 0)) >= constants.MessageEditableFor {
+		return 0, false
+	}
+
+	lastMsg, err := s.lastMessageRepository.GetByChatId(ctx, chatId)
+
+	if err != nil || lastMsg.MessageId != callbackMsg.ID {
+		return 0, false
+	}
+
+	edit := &tg_bot.EditMessageTextParams{
+		ChatID:      chatId,
+		MessageID:   callbackMsg.ID,
+		Text:        params.Text,
+		ParseMode:   params.ParseMode,
+		ReplyMarkup: params.ReplyMarkup,
+	}
+
+	err = s.outboundService.Send(ctx, chatId, "editMessageText", edit, func(ctx context.Context) error {
+		_, err := b.EditMessageText(ctx, edit)
+
+		if isMessageNotModifiedError(err) {
+			return nil
+		}
+
+		return err
+	})
+
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn(fmt.Sprintf("Failed to edit message %d in chat %d, resending", callbackMsg.ID, chatId))
+		return 0, false
+	}
+
+	return callbackMsg.ID, true
+}
+
+// replaceLastMessage only keeps the chat tidy, so its repository errors are
+// logged and never fail the send itself.
+func (s *senderService) replaceLastMessage(ctx context.Context, b *tg_bot.Bot, chatId int64, messageId int) {
+	lastMsg, err := s.lastMessageRepository.GetByChatId(ctx, chatId)
+
+	if errors.Is(err, repositories.ErrNotFound) {
+		_, err = s.lastMessageRepository.Create(ctx, models.LastUserMessage{
+			ChatId:    chatId,
+			MessageId: messageId,
+		})
+
+		s.logLastMessageError(ctx, chatId, err)
+		return
+	}
+
+	if err != nil {
+		s.logLastMessageError(ctx, chatId, err)
+		return
+	}
+
+	err = s.lastMessageRepository.UpdateByChatId(ctx, chatId, models.LastUserMessage{
+		MessageId: messageId,
+	})
+
+	if err != nil {
+		s.logLastMessageError(ctx, chatId, err)
+		return
+	}
+
+	if !s.deleteMessage(ctx, b, chatId, lastMsg.MessageId) {
+		s.logLastMessageError(ctx, chatId, s.lastMessageRepository.DeleteByChatId(ctx, chatId))
+	}
+}
+
 func (s *senderService) Delete(ctx context.Context, b *tg_bot.Bot, chatId int64, messageId int) {
-	lastMsg := s.lastMessageRepository.GetByChatId(ctx, chatId)
+	lastMsg, err := s.lastMessageRepository.GetByChatId(ctx, chatId)
+
+	if err == nil && lastMsg.MessageId == messageId {
+		err = s.lastMessageRepository.DeleteByChatId(ctx, chatId)
+	}
+
+	if !errors.Is(err, repositories.ErrNotFound) {
+		s.logLastMessageError(ctx, chatId, err)
+	}
+
+	s.deleteMessage(ctx, b, chatId, messageId)
+}
+
+// SendError tells the user why a request could not be completed. Transient and
+// unexpected repository errors are also logged and reported to the alert chat.
+// Context errors mean the request was abandoned, so nothing is sent.
+func (s *senderService) SendError(ctx context.Context, b *tg_bot.Bot, chatId int64, err error) {
+	if err == nil || utils.IsContextError(err) {
+		return
+	}
+
+	switch {
+	case errors.Is(err, repositories.ErrNotFound):
+		s.logger.WithContext(ctx).WithError(err).Warn("Requested record not found")
+		s.SendSafe(ctx, b, chatId, messages.NotFoundErrorMessage())
+	case errors.Is(err, repositories.ErrConflict):
+		s.logger.WithContext(ctx).WithError(err).Warn("Record conflicts with existing data")
+		s.SendSafe(ctx, b, chatId, messages.ConflictErrorMessage())
+	case errors.Is(err, repositories.ErrTransient):
+		s.logger.WithContext(ctx).WithError(err).Error("Database is temporarily unavailable")
+		s.alertService.Report(ctx, constants.RepositoryAlertKind, err)
+		s.SendSafe(ctx, b, chatId, messages.TransientErrorMessage())
+	default:
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to handle request")
+		s.alertService.Report(ctx, constants.RepositoryAlertKind, err)
+		s.SendSafe(ctx, b, chatId, messages.ErrorMessage())
+	}
+}
+
+func (s *senderService) logLastMessageError(ctx context.Context, chatId int64, err error) {
+	if err == nil || utils.IsContextError(err) {
+		return
+	}
+
+	s.logger.WithContext(ctx).WithError(err).Error(fmt.Sprintf("Failed to track last message in chat %d", chatId))
+}
 
-	if lastMsg == nil && lastMsg.MessageId == messageId {
-		s.lastMessageRepository.DeleteByChatId(ctx, chatId)
+func (s *senderService) deleteMessage(ctx context.Context, b *tg_bot.Bot, chatId int64, messageId int) bool {
+	if messageId == 0 {
+		return false
 	}
 
-	ok, err := b.DeleteMessage(ctx, &tg_bot.DeleteMessageParams{
+	var ok bool
+
+	params := &tg_bot.DeleteMessageParams{
 		ChatID:    chatId,
 		MessageID: messageId,
+	}
+
+	err := s.outboundService.Send(ctx, chatId, "deleteMessage", params, func(ctx context.Context) error {
+		var err error
+
+		ok, err = b.DeleteMessage(ctx, params)
+
+		if isMessageNotFoundError(err) {
+			return nil
+		}
+
+		return err
 	})
 
-	if err != nil || !ok {
-		s.logger.Error(err.Error())
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Error(fmt.Sprintf("Failed to delete message %d in chat %d", messageId, chatId))
+		return false
 	}
+
+	return ok
+}
+
+// The message is gone already, for example the user deleted it, so there is nothing to retry.
+func isMessageNotFoundError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "message to delete not found")
+}
+
+func isMessageNotModifiedError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "message is not modified")
 }
@@ -9,11 +9,13 @@ import (
 	"rezvin-pro-bot/src/types"
 	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 type IShutdownService interface {
 	Shutdown()
+	IsShuttingDown() bool
 	AddShutdownCallback(callback *types.ShutdownCallback)
 }
 
@@ -30,6 +32,7 @@ type shutdownService struct {
 	callbacks         []*types.ShutdownCallback
 	shutdownWaitGroup *sync.WaitGroup
 	shutdownContext   context.Context
+	shuttingDown      atomic.Bool
 }
 
 func NewShutdownService(deps shutdownServiceDependencies) *shutdownService {
@@ -61,7 +64,13 @@ func (s *shutdownService) AddShutdownCallback(callback *types.ShutdownCallback)
 	s.callbacks = append(s.callbacks, callback)
 }
 
+func (s *shutdownService) IsShuttingDown() bool {
+	return s.shuttingDown.Load()
+}
+
 func (s *shutdownService) Shutdown() {
+	s.shuttingDown.Store(true)
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	tg_bot "github.com/go-telegram/bot"
+	"go.uber.org/dig"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/internal/logger"
+	"rezvin-pro-bot/src/models"
+	"rezvin-pro-bot/src/repositories"
+	"rezvin-pro-bot/src/utils/messages"
+	"rezvin-pro-bot/src/utils/rep_scheme"
+)
+
+type IUserResultService interface {
+	SaveResult(
+		ctx context.Context,
+		b *tg_bot.Bot,
+		client models.User,
+		record models.UserResult,
+		weight float64,
+		source constants.ResultSource,
+		enteredById int64,
+	) (bool, error)
+	SyncResults(ctx context.Context, userProgramId uint, program models.Program, exercises []models.Exercise) error
+	SyncProgramResults(ctx context.Context, program models.Program, exercises []models.Exercise) error
+}
+
+type userResultServiceDependencies struct {
+	dig.In
+
+	Logger                      logger.ILogger                            `name:"Logger"`
+	SenderService               ISenderService                            `name:"SenderService"`
+	AlertService                IAlertService                             `name:"AlertService"`
+	UserRepository              repositories.IUserRepository              `name:"UserRepository"`
+	UserProgramRepository       repositories.IUserProgramRepository       `name:"UserProgramRepository"`
+	UserResultRepository        repositories.IUserResultRepository        `name:"UserResultRepository"`
+	UserResultHistoryRepository repositories.IUserResultHistoryRepository `name:"UserResultHistoryRepository"`
+}
+
+type userResultService struct {
+	logger                      logger.ILogger
+	senderService               ISenderService
+	alertService                IAlertService
+	userRepository              repositories.IUserRepository
+	userProgramRepository       repositories.IUserProgramRepository
+	userResultRepository        repositories.IUserResultRepository
+	userResultHistoryRepository repositories.IUserResultHistoryRepository
+}
+
+func NewUserResultService(deps userResultServiceDependencies) *userResultService {
+	return &userResultService{
+		logger:                      deps.Logger,
+		senderService:               deps.SenderService,
+		alertService:                deps.AlertService,
+		userRepository:              deps.UserRepository,
+		userProgramRepository:       deps.UserProgramRepository,
+		userResultRepository:        deps.UserResultRepository,
+		userResultHistoryRepository: deps.UserResultHistoryRepository,
+	}
+}
+
+func (s *userResultService) SaveResult(
+	ctx context.Context,
+	b *tg_bot.Bot,
+	client models.User,
+	record models.UserResult,
+	weight float64,
+	source constants.ResultSource,
+	enteredById int64,
+) (bool, error) {
+	best, err := s.userResultHistoryRepository.GetBestWeight(ctx, client.Id, record.ExerciseId, record.Reps)
+
+	if err != nil {
+		return false, err
+	}
+
+	if record.Weight > best {
+		best = record.Weight
+	}
+
+	isRecord := best > 0 && weight > best
+
+	err = s.userResultRepository.UpdateWeight(ctx, models.UserResultHistory{
+		UserResultId:  record.Id,
+		UserId:        client.Id,
+		UserProgramId: record.UserProgramId,
+		ExerciseId:    record.ExerciseId,
+		Reps:          record.Reps,
+		Weight:        weight,
+		IsRecord:      isRecord,
+		EnteredBy:     source,
+		EnteredById:   enteredById,
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	if !isRecord {
+		return false, nil
+	}
+
+	clientMsg := messages.UserPersonalRecordMessage(record.Name(), record.Reps, weight, best, client.GetWeightUnit())
+	s.notify(ctx, b, client.ChatId, clientMsg)
+
+	admins, err := s.userRepository.GetAdminUsers(ctx)
+
+	// The result is already saved, so failing to reach the trainers is not an error for the client.
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to load admins for personal record notification")
+		return true, nil
+	}
+
+	for _, admin := range admins {
+		msg := messages.ClientPersonalRecordMessage(
+			client.GetPrivateName(),
+			record.Name(),
+			record.Reps,
+			weight,
+			best,
+			admin.GetWeightUnit(),
+		)
+		s.notify(ctx, b, admin.ChatId, msg)
+	}
+
+	return true, nil
+}
+
+func (s *userResultService) SyncResults(
+	ctx context.Context,
+	userProgramId uint,
+	program models.Program,
+	exercises []models.Exercise,
+) error {
+	for _, exercise := range exercises {
+		err := s.userResultRepository.SyncReps(ctx, userProgramId, exercise.Id, rep_scheme.ForExercise(program, exercise))
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *userResultService) SyncProgramResults(ctx context.Context, program models.Program, exercises []models.Exercise) error {
+	userPrograms, err := s.userProgramRepository.GetAllByProgramId(ctx, program.Id)
+
+	if err != nil {
+		return err
+	}
+
+	for _, userProgram := range userPrograms {
+		if err = s.SyncResults(ctx, userProgram.Id, program, exercises); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *userResultService) notify(ctx context.Context, b *tg_bot.Bot, chatId int64, msg string) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.WithContext(ctx).Error(fmt.Sprintf("Failed to send personal record notification to chat %d: %v", chatId, r))
+			s.alertService.ReportPanic(ctx, r)
+		}
+	}()
+
+	s.senderService.Notify(ctx, b, chatId, msg)
+}
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	tg_bot "github.com/go-telegram/bot"
+	"go.uber.org/dig"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/internal/logger"
+	"rezvin-pro-bot/src/models"
+	"rezvin-pro-bot/src/repositories"
+	"rezvin-pro-bot/src/types"
+	"rezvin-pro-bot/src/utils/inline_keyboards"
+	"rezvin-pro-bot/src/utils/messages"
+	"sync"
+)
+
+type IWizardService interface {
+	Register(wizard types.Wizard)
+	Start(ctx context.Context, b *tg_bot.Bot, chatId int64, state constants.ConversationState, params types.Params)
+	Back(ctx context.Context, b *tg_bot.Bot, chatId int64) (bool, error)
+	Confirm(ctx context.Context, b *tg_bot.Bot, chatId int64) (bool, error)
+	Cancel(ctx context.Context, b *tg_bot.Bot, chatId int64)
+}
+
+type wizardServiceDependencies struct {
+	dig.In
+
+	Logger              logger.ILogger       `name:"Logger"`
+	SenderService       ISenderService       `name:"SenderService"`
+	ConversationService IConversationService `name:"ConversationService"`
+}
+
+type wizardService struct {
+	logger              logger.ILogger
+	senderService       ISenderService
+	conversationService IConversationService
+	wizards             map[constants.ConversationState]types.Wizard
+	mu                  sync.RWMutex
+}
+
+func NewWizardService(deps wizardServiceDependencies) *wizardService {
+	return &wizardService{
+		logger:              deps.Logger,
+		senderService:       deps.SenderService,
+		conversationService: deps.ConversationService,
+		wizards:             make(map[constants.ConversationState]types.Wizard),
+		mu:                  sync.RWMutex{},
+	}
+}
+
+func (s *wizardService) Register(wizard types.Wizard) {
+	if len(wizard.Steps) == 0 {
+		panic(fmt.Sprintf("Wizard %s has no steps", wizard.State))
+	}
+
+	s.mu.Lock()
+	s.wizards[wizard.State] = wizard
+	s.mu.Unlock()
+
+	s.conversationService.RegisterStep(wizard.State, s.answer)
+}
+
+func (s *wizardService) Start(
+	ctx context.Context,
+	b *tg_bot.Bot,
+	chatId int64,
+	state constants.ConversationState,
+	params types.Params,
+) {
+	wizard, ok := s.getWizard(state)
+
+	if !ok {
+		panic(fmt.Sprintf("Wizard %s is not registered", state))
+	}
+
+	prompt := wizard.Steps[0].Prompt(ctx, types.WizardData{})
+
+	s.conversationService.StartWithKb(ctx, b, chatId, state, params, prompt, inline_keyboards.WizardStep(false))
+}
+
+func (s *wizardService) Back(ctx context.Context, b *tg_bot.Bot, chatId int64) (bool, error) {
+	conversation, wizard, ok, err := s.getConversation(ctx, chatId)
+
+	if err != nil || !ok {
+		return false, err
+	}
+
+	if conversation.Step > 0 {
+		conversation.Step--
+	}
+
+	s.show(ctx, b, conversation, wizard)
+
+	return true, nil
+}
+
+func (s *wizardService) Confirm(ctx context.Context, b *tg_bot.Bot, chatId int64) (bool, error) {
+	conversation, wizard, ok, err := s.getConversation(ctx, chatId)
+
+	if err != nil || !ok || conversation.Step < len(wizard.Steps) {
+		return false, err
+	}
+
+	s.conversationService.Finish(ctx, b, conversation)
+
+	wizard.Complete(ctx, b, conversation.Data)
+
+	return true, nil
+}
+
+// Cancel backs both the cancel button and the /cancel command, so they drop any
+// conversation of the chat, a wizard or a single question, with the same reply.
+func (s *wizardService) Cancel(ctx context.Context, b *tg_bot.Bot, chatId int64) {
+	ok, err := s.conversationService.Abandon(ctx, b, chatId)
+
+	if err != nil {
+		s.senderService.SendError(ctx, b, chatId, err)
+		return
+	}
+
+	if !ok {
+		s.senderService.SendWithKb(ctx, b, chatId, messages.WizardNotFoundMessage(), inline_keyboards.StartOk())
+		return
+	}
+
+	s.senderService.SendWithKb(ctx, b, chatId, messages.WizardCancelledMessage(), inline_keyboards.StartOk())
+}
+
+func (s *wizardService) answer(ctx context.Context, b *tg_bot.Bot, conversation *models.Conversation, answer string) {
+	wizard, ok := s.getWizard(conversation.State)
+
+	if !ok {
+		return
+	}
+
+	if conversation.Step >= len(wizard.Steps) {
+		s.senderService.Send(ctx, b, conversation.ChatId, messages.WizardConfirmHintMessage())
+		return
+	}
+
+	step := wizard.Steps[conversation.Step]
+
+	value, err := step.Validate(ctx, answer)
+
+	if repositories.IsRepositoryError(err) {
+		s.senderService.SendError(ctx, b, conversation.ChatId, err)
+		return
+	}
+
+	if err != nil {
+		s.senderService.Send(ctx, b, conversation.ChatId, err.Error())
+		return
+	}
+
+	conversation.SetData(step.Key, value)
+	conversation.Step++
+
+	s.show(ctx, b, conversation, wizard)
+}
+
+func (s *wizardService) show(ctx context.Context, b *tg_bot.Bot, conversation *models.Conversation, wizard types.Wizard) {
+	data := types.WizardData(conversation.Data)
+
+	if conversation.Step >= len(wizard.Steps) {
+		msg := wizard.Summary(ctx, data)
+		s.conversationService.AdvanceWithKb(ctx, b, conversation, conversation.State, msg, inline_keyboards.WizardConfirm())
+		return
+	}
+
+	step := wizard.Steps[conversation.Step]
+	msg := step.Prompt(ctx, data)
+
+	if value := data.String(step.Key); value != "" {
+		if step.Format != nil {
+			value = step.Format(ctx, value)
+		}
+
+		msg = messages.WizardCurrentValueMessage(msg, value)
+	}
+
+	kb := inline_keyboards.WizardStep(conversation.Step > 0)
+
+	s.conversationService.AdvanceWithKb(ctx, b, conversation, conversation.State, msg, kb)
+}
+
+func (s *wizardService) getConversation(ctx context.Context, chatId int64) (*models.Conversation, types.Wizard, bool, error) {
+	conversation, err := s.conversationService.GetConversation(ctx, chatId)
+
+	if err != nil || conversation == nil {
+		return nil, types.Wizard{}, false, err
+	}
+
+	wizard, ok := s.getWizard(conversation.State)
+
+	return conversation, wizard, ok, nil
+}
+
+func (s *wizardService) getWizard(state constants.ConversationState) (types.Wizard, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	wizard, ok := s.wizards[state]
+
+	return wizard, ok
+}
@@ -0,0 +1,9 @@
+package types
+
+import "rezvin-pro-bot/src/constants"
+
+type AlertDigestEntry struct {
+	Kind       constants.AlertKind
+	Message    string
+	Suppressed int
+}
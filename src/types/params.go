@@ -5,29 +5,33 @@ import (
 )
 
 type Params struct {
-	ProgramId     uint
-	UserId        int64
-	ExerciseId    uint
-	UserMeasureId uint
-	UserProgramId uint
-	UserResultId  uint
-	MeasureId     uint
-	Limit         int
-	Offset        int
-	Reps          constants.Reps
+	ProgramId        uint
+	UserId           int64
+	ExerciseId       uint
+	UserMeasureId    uint
+	UserProgramId    uint
+	UserResultId     uint
+	MeasureId        uint
+	WorkoutSessionId uint
+	Limit            int
+	Offset           int
+	Reps             constants.Reps
+	RestSeconds      constants.RestSeconds
 }
 
 func NewEmptyParams() *Params {
 	return &Params{
-		ProgramId:     0,
-		UserId:        0,
-		ExerciseId:    0,
-		UserMeasureId: 0,
-		UserProgramId: 0,
-		UserResultId:  0,
-		MeasureId:     0,
-		Limit:         constants.DefaultLimit,
-		Offset:        constants.DefaultOffset,
-		Reps:          constants.Zero,
+		ProgramId:        0,
+		UserId:           0,
+		ExerciseId:       0,
+		UserMeasureId:    0,
+		UserProgramId:    0,
+		UserResultId:     0,
+		MeasureId:        0,
+		WorkoutSessionId: 0,
+		Limit:            constants.DefaultLimit,
+		Offset:           constants.DefaultOffset,
+		Reps:             constants.Zero,
+		RestSeconds:      0,
 	}
 }
@@ -0,0 +1,54 @@
+package types
+
+import (
+	"context"
+	tg_bot "github.com/go-telegram/bot"
+	"rezvin-pro-bot/src/constants"
+	"strconv"
+)
+
+type WizardData map[string]string
+
+func (d WizardData) String(key string) string {
+	return d[key]
+}
+
+func (d WizardData) Float(key string) float64 {
+	value, _ := strconv.ParseFloat(d[key], 64)
+	return value
+}
+
+type WizardValidator func(ctx context.Context, answer string) (string, error)
+
+type WizardStep struct {
+	Key      string
+	Prompt   func(ctx context.Context, data WizardData) string
+	Validate WizardValidator
+	// Format shows the stored value when the step is edited again, the value is shown as is without it.
+	Format func(ctx context.Context, value string) string
+}
+
+type Wizard struct {
+	State    constants.ConversationState
+	Steps    []WizardStep
+	Summary  func(ctx context.Context, data WizardData) string
+	Complete func(ctx context.Context, b *tg_bot.Bot, data WizardData)
+}
+
+func TextValidator(validate func(text string) (string, error)) WizardValidator {
+	return func(_ context.Context, answer string) (string, error) {
+		return validate(answer)
+	}
+}
+
+func NumberValidator(validate func(text string) (float64, error)) WizardValidator {
+	return func(_ context.Context, answer string) (string, error) {
+		value, err := validate(answer)
+
+		if err != nil {
+			return "", err
+		}
+
+		return strconv.FormatFloat(value, 'f', -1, 64), nil
+	}
+}
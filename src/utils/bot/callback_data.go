@@ -0,0 +1,202 @@
+package bot
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/globals"
+	"rezvin-pro-bot/src/types"
+	"strings"
+	"sync"
+)
+
+const (
+	callbackDataVersion   byte = 1
+	callbackDataSeparator      = "?"
+	callbackDataMacLength      = 4
+)
+
+type callbackDataField struct {
+	get func(params *types.Params) int64
+	set func(params *types.Params, value int64) error
+}
+
+// Field order is part of the wire format, append new fields to the end or bump callbackDataVersion.
+var callbackDataFields = []callbackDataField{
+	{
+		get: func(p *types.Params) int64 { return int64(p.ProgramId) },
+		set: func(p *types.Params, v int64) error { p.ProgramId = uint(v); return nil },
+	},
+	{
+		get: func(p *types.Params) int64 { return p.UserId },
+		set: func(p *types.Params, v int64) error { p.UserId = v; return nil },
+	},
+	{
+		get: func(p *types.Params) int64 { return int64(p.ExerciseId) },
+		set: func(p *types.Params, v int64) error { p.ExerciseId = uint(v); return nil },
+	},
+	{
+		get: func(p *types.Params) int64 { return int64(p.UserProgramId) },
+		set: func(p *types.Params, v int64) error { p.UserProgramId = uint(v); return nil },
+	},
+	{
+		get: func(p *types.Params) int64 { return int64(p.UserResultId) },
+		set: func(p *types.Params, v int64) error { p.UserResultId = uint(v); return nil },
+	},
+	{
+		get: func(p *types.Params) int64 { return int64(p.MeasureId) },
+		set: func(p *types.Params, v int64) error { p.MeasureId = uint(v); return nil },
+	},
+	{
+		get: func(p *types.Params) int64 { return int64(p.UserMeasureId) },
+		set: func(p *types.Params, v int64) error { p.UserMeasureId = uint(v); return nil },
+	},
+	{
+		get: func(p *types.Params) int64 { return int64(p.WorkoutSessionId) },
+		set: func(p *types.Params, v int64) error { p.WorkoutSessionId = uint(v); return nil },
+	},
+	{
+		get: func(p *types.Params) int64 { return int64(p.Limit) },
+		set: func(p *types.Params, v int64) error { p.Limit = int(v); return nil },
+	},
+	{
+		get: func(p *types.Params) int64 { return int64(p.Offset) },
+		set: func(p *types.Params, v int64) error { p.Offset = int(v); return nil },
+	},
+	{
+		get: func(p *types.Params) int64 { return int64(p.Reps) },
+		set: func(p *types.Params, v int64) error {
+			if v < 0 || constants.Reps(v) > constants.MaxReps {
+				return fmt.Errorf("invalid reps: %d", v)
+			}
+			p.Reps = constants.Reps(v)
+			return nil
+		},
+	},
+	{
+		get: func(p *types.Params) int64 { return int64(p.RestSeconds) },
+		set: func(p *types.Params, v int64) error {
+			if v < 0 || !constants.IsValidRestDuration(constants.RestSeconds(v)) {
+				return fmt.Errorf("invalid rest seconds: %d", v)
+			}
+			p.RestSeconds = constants.RestSeconds(v)
+			return nil
+		},
+	},
+}
+
+var ErrCallbackDataExpired = errors.New("callback data is expired or tampered")
+
+var callbackDataKey = sync.OnceValue(func() []byte {
+	mac := hmac.New(sha256.New, []byte(globals.GetBotToken()))
+	mac.Write([]byte("callback-data"))
+
+	return mac.Sum(nil)
+})
+
+func EncodeCallbackData(prefix string, params *types.Params) string {
+	if params == nil {
+		return prefix
+	}
+
+	var fields uint16
+	var values []int64
+
+	for i, field := range callbackDataFields {
+		value := field.get(params)
+
+		if value == 0 {
+			continue
+		}
+
+		fields |= 1 << i
+		values = append(values, value)
+	}
+
+	if fields == 0 {
+		return prefix
+	}
+
+	payload := []byte{callbackDataVersion}
+	payload = binary.BigEndian.AppendUint16(payload, fields)
+
+	for _, value := range values {
+		payload = binary.AppendVarint(payload, value)
+	}
+
+	payload = append(payload, signCallbackData(prefix, payload)...)
+
+	return prefix + callbackDataSeparator + base64.RawURLEncoding.EncodeToString(payload)
+}
+
+func DecodeCallbackData(data string) (*types.Params, error) {
+	params := types.NewEmptyParams()
+
+	prefix, encoded, found := strings.Cut(data, callbackDataSeparator)
+
+	if !found {
+		return params, nil
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+
+	if err != nil || len(payload) < 3+callbackDataMacLength {
+		return nil, ErrCallbackDataExpired
+	}
+
+	body, mac := payload[:len(payload)-callbackDataMacLength], payload[len(payload)-callbackDataMacLength:]
+
+	if body[0] != callbackDataVersion || !hmac.Equal(mac, signCallbackData(prefix, body)) {
+		return nil, ErrCallbackDataExpired
+	}
+
+	fields := binary.BigEndian.Uint16(body[1:3])
+	rest := body[3:]
+
+	if fields>>len(callbackDataFields) != 0 {
+		return nil, ErrCallbackDataExpired
+	}
+
+	for i, field := range callbackDataFields {
+		if fields&(1<<i) == 0 {
+			continue
+		}
+
+		value, n := binary.Varint(rest)
+
+		if n <= 0 {
+			return nil, ErrCallbackDataExpired
+		}
+
+		rest = rest[n:]
+
+		if err = field.set(params, value); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("unexpected trailing callback data: %d bytes", len(rest))
+	}
+
+	return params, nil
+}
+
+func CallbackDataPrefix(data string) string {
+	prefix, _, _ := strings.Cut(data, callbackDataSeparator)
+
+	return prefix
+}
+
+func signCallbackData(prefix string, body []byte) []byte {
+	mac := hmac.New(sha256.New, callbackDataKey())
+	mac.Write([]byte(prefix))
+	mac.Write([]byte{0})
+	mac.Write(body)
+
+	return mac.Sum(nil)[:callbackDataMacLength]
+}
@@ -0,0 +1,171 @@
+package bot
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/types"
+	"strings"
+	"testing"
+)
+
+func encodePayload(prefix string, body []byte) string {
+	payload := append(body, signCallbackData(prefix, body)...)
+
+	return prefix + callbackDataSeparator + base64.RawURLEncoding.EncodeToString(payload)
+}
+
+func TestCallbackDataRoundTrip(t *testing.T) {
+	full := &types.Params{
+		ProgramId:        4294967295,
+		UserId:           9007199254740993,
+		ExerciseId:       123456,
+		UserMeasureId:    99,
+		UserProgramId:    77,
+		UserResultId:     5000000,
+		MeasureId:        12,
+		WorkoutSessionId: 8,
+		Limit:            50,
+		Offset:           1000,
+		Reps:             constants.MaxReps,
+		RestSeconds:      180,
+	}
+
+	tests := []struct {
+		name   string
+		prefix string
+		params *types.Params
+	}{
+		{"default params", constants.UserProgramSelected, types.NewEmptyParams()},
+		{"single id", "ex", &types.Params{ExerciseId: 1, Limit: constants.DefaultLimit}},
+		{"negative user id", "cl", &types.Params{UserId: -1001234567890, Limit: constants.DefaultLimit}},
+		{"every field", "uwr", full},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := EncodeCallbackData(tt.prefix, tt.params)
+
+			if len(data) > 64 {
+				t.Errorf("callback data is %d bytes, Telegram allows 64", len(data))
+			}
+
+			if !strings.HasPrefix(data, tt.prefix+callbackDataSeparator) {
+				t.Fatalf("callback data %q does not start with the prefix", data)
+			}
+
+			got, err := DecodeCallbackData(data)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if *got != *tt.params {
+				t.Errorf("got %+v, want %+v", *got, *tt.params)
+			}
+		})
+	}
+}
+
+func TestEncodeCallbackDataWithoutParams(t *testing.T) {
+	tests := []struct {
+		name   string
+		params *types.Params
+	}{
+		{"nil params", nil},
+		{"zero params", &types.Params{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EncodeCallbackData("mm", tt.params); got != "mm" {
+				t.Errorf("got %q, want bare prefix", got)
+			}
+		})
+	}
+}
+
+func TestDecodeCallbackDataWithoutPayload(t *testing.T) {
+	got, err := DecodeCallbackData("mm")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if *got != *types.NewEmptyParams() {
+		t.Errorf("got %+v, want empty params", *got)
+	}
+}
+
+func TestDecodeCallbackDataRejectsTampering(t *testing.T) {
+	valid := EncodeCallbackData("ex", &types.Params{ExerciseId: 42, Offset: 5})
+	prefix, encoded, _ := strings.Cut(valid, callbackDataSeparator)
+	payload, _ := base64.RawURLEncoding.DecodeString(encoded)
+
+	flipped := append([]byte{}, payload...)
+	flipped[3] ^= 1
+
+	body := payload[:len(payload)-callbackDataMacLength]
+
+	otherVersion := append([]byte{}, body...)
+	otherVersion[0] = callbackDataVersion + 1
+
+	unknownField := append([]byte{}, body...)
+	binary.BigEndian.PutUint16(unknownField[1:3], 1<<len(callbackDataFields))
+
+	truncated := binary.BigEndian.AppendUint16([]byte{callbackDataVersion}, 1<<2)
+	truncated = append(truncated, 0x80)
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"flipped payload bit", prefix + callbackDataSeparator + base64.RawURLEncoding.EncodeToString(flipped)},
+		{"payload moved to another prefix", "pr" + callbackDataSeparator + encoded},
+		{"unsigned version bump", prefix + callbackDataSeparator + base64.RawURLEncoding.EncodeToString(append(otherVersion, payload[len(body):]...))},
+		{"signed unknown version", encodePayload(prefix, otherVersion)},
+		{"signed unknown field", encodePayload(prefix, unknownField)},
+		{"signed truncated varint", encodePayload(prefix, truncated)},
+		{"invalid base64", prefix + callbackDataSeparator + "***"},
+		{"too short", prefix + callbackDataSeparator + base64.RawURLEncoding.EncodeToString([]byte{callbackDataVersion, 0, 1})},
+		{"empty payload", prefix + callbackDataSeparator},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := DecodeCallbackData(tt.data)
+
+			if !errors.Is(err, ErrCallbackDataExpired) {
+				t.Errorf("got %+v, %v, want %v", params, err, ErrCallbackDataExpired)
+			}
+		})
+	}
+}
+
+func TestDecodeCallbackDataRejectsInvalidValues(t *testing.T) {
+	field := func(index int, value int64) []byte {
+		body := binary.BigEndian.AppendUint16([]byte{callbackDataVersion}, 1<<index)
+		return binary.AppendVarint(body, value)
+	}
+
+	tests := []struct {
+		name string
+		body []byte
+	}{
+		{"reps above max", field(10, int64(constants.MaxReps)+1)},
+		{"negative reps", field(10, -1)},
+		{"unknown rest duration", field(11, 45)},
+		{"trailing bytes", append(field(0, 1), 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := DecodeCallbackData(encodePayload("ex", tt.body))
+
+			if err == nil || errors.Is(err, ErrCallbackDataExpired) {
+				t.Errorf("got %+v, %v, want a validation error", params, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	tg_bot "github.com/go-telegram/bot"
+	"io"
+	"net/http"
+)
+
+func DownloadFile(ctx context.Context, b *tg_bot.Bot, fileId string) ([]byte, error) {
+	file, err := b.GetFile(ctx, &tg_bot.GetFileParams{FileID: fileId})
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.FileDownloadLink(file), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := http.DefaultClient.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download file %s: status %d", fileId, res.StatusCode)
+	}
+
+	return io.ReadAll(res.Body)
+}
@@ -0,0 +1,178 @@
+package chart
+
+import (
+	"bytes"
+	"image/png"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/models"
+	"rezvin-pro-bot/src/utils/messages"
+	"testing"
+	"time"
+)
+
+var day = time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+
+func TestGetBounds(t *testing.T) {
+	tests := []struct {
+		name         string
+		series       []Series
+		wantMinValue float64
+		wantMaxValue float64
+		wantSpan     time.Duration
+	}{
+		{
+			name:         "no points",
+			series:       nil,
+			wantMinValue: 0,
+			wantMaxValue: 1,
+			wantSpan:     24 * time.Hour,
+		},
+		{
+			name:         "single point",
+			series:       []Series{{Points: []Point{{Time: day, Value: 50}}}},
+			wantMinValue: 45,
+			wantMaxValue: 55,
+			wantSpan:     24 * time.Hour,
+		},
+		{
+			name:         "single zero point",
+			series:       []Series{{Points: []Point{{Time: day, Value: 0}}}},
+			wantMinValue: 0,
+			wantMaxValue: 1,
+			wantSpan:     24 * time.Hour,
+		},
+		{
+			name: "several series",
+			series: []Series{
+				{Points: []Point{{Time: day, Value: 60}}},
+				{Points: []Point{{Time: day.Add(48 * time.Hour), Value: 80}}},
+			},
+			wantMinValue: 58,
+			wantMaxValue: 82,
+			wantSpan:     48 * time.Hour,
+		},
+		{
+			name:         "padding clamped at zero",
+			series:       []Series{{Points: []Point{{Time: day, Value: 1}, {Time: day.Add(time.Hour), Value: 11}}}},
+			wantMinValue: 0,
+			wantMaxValue: 12,
+			wantSpan:     time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := getBounds(tt.series)
+
+			if b.minValue != tt.wantMinValue || b.maxValue != tt.wantMaxValue {
+				t.Errorf("values: got [%v, %v], want [%v, %v]", b.minValue, b.maxValue, tt.wantMinValue, tt.wantMaxValue)
+			}
+
+			if span := b.maxTime.Sub(b.minTime); span != tt.wantSpan {
+				t.Errorf("time span: got %s, want %s", span, tt.wantSpan)
+			}
+		})
+	}
+}
+
+func TestResultSeries(t *testing.T) {
+	entries := []models.UserResultHistory{
+		{Reps: 6, Weight: 100, LoggedAt: day},
+		{Reps: 6, Weight: 0, LoggedAt: day.Add(time.Hour)},
+		{Reps: 8, Weight: 90, LoggedAt: day},
+		{Reps: 12, Weight: 70, LoggedAt: day},
+	}
+
+	tests := []struct {
+		name       string
+		reps       []constants.Reps
+		unit       constants.WeightUnit
+		wantNames  []string
+		wantValues []float64
+	}{
+		{
+			name:       "kilograms",
+			reps:       []constants.Reps{6, 8, 10},
+			unit:       constants.KilogramUnit,
+			wantNames:  []string{messages.ResultChartLegend(6), messages.ResultChartLegend(8)},
+			wantValues: []float64{100, 90},
+		},
+		{
+			name:       "pounds",
+			reps:       []constants.Reps{12},
+			unit:       constants.PoundUnit,
+			wantNames:  []string{messages.ResultChartLegend(12)},
+			wantValues: []float64{154.3},
+		},
+		{
+			name: "no matching reps",
+			reps: []constants.Reps{1},
+			unit: constants.KilogramUnit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			series := ResultSeries(entries, tt.reps, tt.unit)
+
+			if len(series) != len(tt.wantNames) {
+				t.Fatalf("got %d series, want %d", len(series), len(tt.wantNames))
+			}
+
+			for i, s := range series {
+				if s.Name != tt.wantNames[i] {
+					t.Errorf("series %d name: got %q, want %q", i, s.Name, tt.wantNames[i])
+				}
+
+				if len(s.Points) != 1 || s.Points[0].Value != tt.wantValues[i] {
+					t.Errorf("series %d points: got %+v, want single value %v", i, s.Points, tt.wantValues[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMeasureSeries(t *testing.T) {
+	series := MeasureSeries([]models.UserMeasure{{Value: 80, CreatedAt: day}, {Value: 79.5, CreatedAt: day.Add(time.Hour)}})
+
+	if len(series) != 1 || len(series[0].Points) != 2 || series[0].Points[1].Value != 79.5 {
+		t.Errorf("unexpected measure series: %+v", series)
+	}
+}
+
+func TestRenderLineChart(t *testing.T) {
+	tests := []struct {
+		name   string
+		series []Series
+	}{
+		{"empty", nil},
+		{"single point", []Series{{Points: []Point{{Time: day, Value: 10}}}}},
+		{
+			"legend",
+			ResultSeries([]models.UserResultHistory{
+				{Reps: 6, Weight: 100, LoggedAt: day},
+				{Reps: 8, Weight: 90, LoggedAt: day.AddDate(1, 0, 0)},
+			}, []constants.Reps{6, 8}, constants.KilogramUnit),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := RenderLineChart(tt.series)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			img, err := png.Decode(bytes.NewReader(data))
+
+			if err != nil {
+				t.Fatalf("invalid png: %s", err)
+			}
+
+			if size := img.Bounds().Size(); size.X != width || size.Y != height {
+				t.Errorf("got %dx%d image, want %dx%d", size.X, size.Y, width, height)
+			}
+		})
+	}
+}
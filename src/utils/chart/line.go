@@ -0,0 +1,280 @@
+package chart
+
+import (
+	"bytes"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	width        = 800
+	height       = 450
+	marginLeft   = 70
+	marginRight  = 30
+	marginTop    = 40
+	marginBottom = 50
+	gridLines    = 5
+	timeTicks    = 4
+	fontSize     = 12
+)
+
+var (
+	backgroundColor = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	axisColor       = color.RGBA{R: 90, G: 90, B: 90, A: 255}
+	gridColor       = color.RGBA{R: 225, G: 225, B: 225, A: 255}
+	textColor       = color.RGBA{R: 40, G: 40, B: 40, A: 255}
+
+	// Go Regular covers Cyrillic, which the legend needs.
+	parseFont = sync.OnceValues(func() (*opentype.Font, error) {
+		return opentype.Parse(goregular.TTF)
+	})
+
+	palette = []color.RGBA{
+		{R: 31, G: 119, B: 180, A: 255},
+		{R: 255, G: 127, B: 14, A: 255},
+		{R: 44, G: 160, B: 44, A: 255},
+		{R: 214, G: 39, B: 40, A: 255},
+		{R: 148, G: 103, B: 189, A: 255},
+		{R: 140, G: 86, B: 75, A: 255},
+		{R: 227, G: 119, B: 194, A: 255},
+		{R: 23, G: 190, B: 207, A: 255},
+	}
+)
+
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+type Series struct {
+	Name   string
+	Points []Point
+}
+
+type canvas struct {
+	img  *image.RGBA
+	face font.Face
+}
+
+type bounds struct {
+	minTime, maxTime   time.Time
+	minValue, maxValue float64
+}
+
+func RenderLineChart(series []Series) ([]byte, error) {
+	f, err := parseFont()
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Faces keep glyph buffers and must not be shared between concurrent renders.
+	face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: fontSize, DPI: 72, Hinting: font.HintingFull})
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer face.Close()
+
+	c := canvas{img: image.NewRGBA(image.Rect(0, 0, width, height)), face: face}
+	draw.Draw(c.img, c.img.Bounds(), &image.Uniform{C: backgroundColor}, image.Point{}, draw.Src)
+
+	b := getBounds(series)
+
+	c.drawGrid(b)
+
+	for i, s := range series {
+		c.drawSeries(b, s, palette[i%len(palette)])
+	}
+
+	c.drawLegend(series)
+
+	var buf bytes.Buffer
+
+	if err := png.Encode(&buf, c.img); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func getBounds(series []Series) bounds {
+	b := bounds{minValue: math.Inf(1), maxValue: math.Inf(-1)}
+
+	for _, s := range series {
+		for _, p := range s.Points {
+			if b.minTime.IsZero() || p.Time.Before(b.minTime) {
+				b.minTime = p.Time
+			}
+
+			if p.Time.After(b.maxTime) {
+				b.maxTime = p.Time
+			}
+
+			b.minValue = math.Min(b.minValue, p.Value)
+			b.maxValue = math.Max(b.maxValue, p.Value)
+		}
+	}
+
+	if math.IsInf(b.minValue, 0) {
+		now := time.Now()
+		return bounds{minTime: now.Add(-12 * time.Hour), maxTime: now.Add(12 * time.Hour), minValue: 0, maxValue: 1}
+	}
+
+	if !b.maxTime.After(b.minTime) {
+		b.minTime = b.minTime.Add(-12 * time.Hour)
+		b.maxTime = b.maxTime.Add(12 * time.Hour)
+	}
+
+	padding := (b.maxValue - b.minValue) * 0.1
+
+	if padding == 0 {
+		padding = math.Max(math.Abs(b.maxValue)*0.1, 1)
+	}
+
+	b.minValue -= padding
+	b.maxValue += padding
+
+	if b.minValue < 0 && b.minValue+padding >= 0 {
+		b.minValue = 0
+	}
+
+	return b
+}
+
+func (b bounds) x(t time.Time) int {
+	ratio := float64(t.Sub(b.minTime)) / float64(b.maxTime.Sub(b.minTime))
+	return marginLeft + int(math.Round(ratio*float64(width-marginLeft-marginRight)))
+}
+
+func (b bounds) y(v float64) int {
+	ratio := (v - b.minValue) / (b.maxValue - b.minValue)
+	return height - marginBottom - int(math.Round(ratio*float64(height-marginTop-marginBottom)))
+}
+
+func (c canvas) drawGrid(b bounds) {
+	for i := 0; i <= gridLines; i++ {
+		value := b.minValue + (b.maxValue-b.minValue)*float64(i)/gridLines
+		y := b.y(value)
+
+		c.drawLine(marginLeft, y, width-marginRight, y, gridColor, 1)
+
+		label := strconv.FormatFloat(math.Round(value*10)/10, 'f', -1, 64)
+		c.drawText(marginLeft-8-c.textWidth(label), y+4, label)
+	}
+
+	layout := "02.01"
+
+	if b.minTime.Year() != b.maxTime.Year() {
+		layout = "02.01.06"
+	}
+
+	for i := 0; i <= timeTicks; i++ {
+		t := b.minTime.Add(time.Duration(float64(b.maxTime.Sub(b.minTime)) * float64(i) / timeTicks))
+		x := b.x(t)
+
+		c.drawLine(x, height-marginBottom, x, height-marginBottom+5, axisColor, 1)
+
+		label := t.Format(layout)
+		c.drawText(x-c.textWidth(label)/2, height-marginBottom+20, label)
+	}
+
+	c.drawLine(marginLeft, marginTop, marginLeft, height-marginBottom, axisColor, 1)
+	c.drawLine(marginLeft, height-marginBottom, width-marginRight, height-marginBottom, axisColor, 1)
+}
+
+func (c canvas) drawSeries(b bounds, s Series, col color.RGBA) {
+	for i, p := range s.Points {
+		x, y := b.x(p.Time), b.y(p.Value)
+
+		if i > 0 {
+			prev := s.Points[i-1]
+			c.drawLine(b.x(prev.Time), b.y(prev.Value), x, y, col, 2)
+		}
+
+		c.fillRect(x-3, y-3, x+3, y+3, col)
+	}
+}
+
+func (c canvas) drawLegend(series []Series) {
+	if len(series) < 2 {
+		return
+	}
+
+	x := marginLeft
+
+	for i, s := range series {
+		col := palette[i%len(palette)]
+
+		c.fillRect(x, 14, x+12, 24, col)
+		c.drawText(x+18, 24, s.Name)
+
+		x += 18 + c.textWidth(s.Name) + 20
+	}
+}
+
+func (c canvas) drawLine(x0, y0, x1, y1 int, col color.RGBA, thickness int) {
+	dx := int(math.Abs(float64(x1 - x0)))
+	dy := -int(math.Abs(float64(y1 - y0)))
+	sx, sy := 1, 1
+
+	if x0 > x1 {
+		sx = -1
+	}
+
+	if y0 > y1 {
+		sy = -1
+	}
+
+	e := dx + dy
+
+	for {
+		c.fillRect(x0, y0, x0+thickness-1, y0+thickness-1, col)
+
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+
+		e2 := 2 * e
+
+		if e2 >= dy {
+			e += dy
+			x0 += sx
+		}
+
+		if e2 <= dx {
+			e += dx
+			y0 += sy
+		}
+	}
+}
+
+func (c canvas) fillRect(x0, y0, x1, y1 int, col color.RGBA) {
+	draw.Draw(c.img, image.Rect(x0, y0, x1+1, y1+1), &image.Uniform{C: col}, image.Point{}, draw.Src)
+}
+
+func (c canvas) drawText(x, y int, text string) {
+	d := &font.Drawer{
+		Dst:  c.img,
+		Src:  &image.Uniform{C: textColor},
+		Face: c.face,
+		Dot:  fixed.P(x, y),
+	}
+
+	d.DrawString(text)
+}
+
+func (c canvas) textWidth(text string) int {
+	return font.MeasureString(c.face, text).Round()
+}
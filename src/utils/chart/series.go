@@ -0,0 +1,42 @@
+package chart
+
+import (
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/models"
+	"rezvin-pro-bot/src/utils/messages"
+	"rezvin-pro-bot/src/utils/units"
+)
+
+func MeasureSeries(userMeasures []models.UserMeasure) []Series {
+	points := make([]Point, 0, len(userMeasures))
+
+	for _, userMeasure := range userMeasures {
+		points = append(points, Point{Time: userMeasure.CreatedAt, Value: userMeasure.Value})
+	}
+
+	return []Series{{Points: points}}
+}
+
+func ResultSeries(entries []models.UserResultHistory, reps []constants.Reps, unit constants.WeightUnit) []Series {
+	series := make([]Series, 0, len(reps))
+
+	for _, r := range reps {
+		points := make([]Point, 0)
+
+		for _, entry := range entries {
+			if entry.Reps != uint(r) || entry.Weight <= 0 {
+				continue
+			}
+
+			points = append(points, Point{Time: entry.LoggedAt, Value: units.FromKilograms(entry.Weight, unit)})
+		}
+
+		if len(points) == 0 {
+			continue
+		}
+
+		series = append(series, Series{Name: messages.ResultChartLegend(r), Points: points})
+	}
+
+	return series
+}
@@ -0,0 +1,20 @@
+package utils_context
+
+import (
+	"context"
+	tg_models "github.com/go-telegram/bot/models"
+)
+
+func GetContextWithCallbackMessage(ctx context.Context, message *tg_models.Message) context.Context {
+	return context.WithValue(ctx, "CallbackMessage", message)
+}
+
+func GetCallbackMessageFromContext(ctx context.Context) *tg_models.Message {
+	result := ctx.Value("CallbackMessage")
+
+	if result == nil {
+		return nil
+	}
+
+	return result.(*tg_models.Message)
+}
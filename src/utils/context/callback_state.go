@@ -0,0 +1,17 @@
+package utils_context
+
+import "context"
+
+func GetContextWithCallbackStateData(ctx context.Context, data map[string]string) context.Context {
+	return context.WithValue(ctx, "CallbackStateData", data)
+}
+
+func GetCallbackStateDataFromContext(ctx context.Context) map[string]string {
+	result := ctx.Value("CallbackStateData")
+
+	if result == nil {
+		return map[string]string{}
+	}
+
+	return result.(map[string]string)
+}
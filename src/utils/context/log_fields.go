@@ -0,0 +1,29 @@
+package utils_context
+
+import "context"
+
+// GetContextWithLogFields merges fields into the ones already stored in ctx, so that nested
+// middlewares can add their own fields without losing the outer ones.
+func GetContextWithLogFields(ctx context.Context, fields map[string]any) context.Context {
+	merged := make(map[string]any, len(fields))
+
+	for key, value := range GetLogFieldsFromContext(ctx) {
+		merged[key] = value
+	}
+
+	for key, value := range fields {
+		merged[key] = value
+	}
+
+	return context.WithValue(ctx, "logFields", merged)
+}
+
+func GetLogFieldsFromContext(ctx context.Context) map[string]any {
+	result := ctx.Value("logFields")
+
+	if result == nil {
+		return nil
+	}
+
+	return result.(map[string]any)
+}
@@ -0,0 +1,20 @@
+package utils_context
+
+import (
+	"context"
+	"rezvin-pro-bot/src/constants"
+)
+
+func GetContextWithRestSeconds(ctx context.Context, seconds constants.RestSeconds) context.Context {
+	return context.WithValue(ctx, "RestSeconds", seconds)
+}
+
+func GetRestSecondsFromContext(ctx context.Context) constants.RestSeconds {
+	result := ctx.Value("RestSeconds")
+
+	if result == nil {
+		panic("RestSeconds not found in context. Error in code")
+	}
+
+	return result.(constants.RestSeconds)
+}
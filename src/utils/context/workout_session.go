@@ -0,0 +1,20 @@
+package utils_context
+
+import (
+	"context"
+	"rezvin-pro-bot/src/models"
+)
+
+func GetContextWithWorkoutSession(ctx context.Context, session *models.WorkoutSession) context.Context {
+	return context.WithValue(ctx, "WorkoutSession", session)
+}
+
+func GetWorkoutSessionFromContext(ctx context.Context) *models.WorkoutSession {
+	result := ctx.Value("WorkoutSession")
+
+	if result == nil {
+		panic("WorkoutSession not found in context. Error in code")
+	}
+
+	return result.(*models.WorkoutSession)
+}
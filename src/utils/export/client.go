@@ -0,0 +1,168 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"rezvin-pro-bot/src/models"
+	"strconv"
+	"time"
+)
+
+const utf8Bom = "\xef\xbb\xbf"
+
+type ClientData struct {
+	UserPrograms []models.UserProgram
+	UserResults  []models.UserResult
+	UserHistory  []models.UserResultHistory
+	UserMeasures []models.UserMeasure
+}
+
+func ClientArchiveName(user models.User, now time.Time) string {
+	return fmt.Sprintf("client_%d_%s.zip", user.Id, now.Format("2006-01-02"))
+}
+
+func ClientArchive(data ClientData) ([]byte, error) {
+	var buf bytes.Buffer
+
+	archive := zip.NewWriter(&buf)
+
+	files := []struct {
+		name string
+		rows [][]string
+	}{
+		{name: "programs.csv", rows: programRows(data)},
+		{name: "results.csv", rows: resultRows(data)},
+		{name: "history.csv", rows: historyRows(data)},
+		{name: "measures.csv", rows: measureRows(data)},
+	}
+
+	for _, file := range files {
+		if err := writeCsv(archive, file.name, file.rows); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func writeCsv(archive *zip.Writer, name string, rows [][]string) error {
+	w, err := archive.Create(name)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err = w.Write([]byte(utf8Bom)); err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+
+	writer := csv.NewWriter(w)
+
+	if err = writer.WriteAll(rows); err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func programRows(data ClientData) [][]string {
+	rows := [][]string{{"user_program_id", "program_id", "program", "assigned_at"}}
+
+	for _, userProgram := range data.UserPrograms {
+		rows = append(rows, []string{
+			uintText(userProgram.Id),
+			uintText(userProgram.ProgramId),
+			userProgram.Name(),
+			timeText(userProgram.CreatedAt),
+		})
+	}
+
+	return rows
+}
+
+func resultRows(data ClientData) [][]string {
+	programNames := programNames(data)
+
+	rows := [][]string{{"user_program_id", "program", "exercise_id", "exercise", "reps", "weight_kg", "updated_at"}}
+
+	for _, record := range data.UserResults {
+		rows = append(rows, []string{
+			uintText(record.UserProgramId),
+			programNames[record.UserProgramId],
+			uintText(record.ExerciseId),
+			record.Name(),
+			uintText(record.Reps),
+			floatText(record.Weight),
+			timeText(record.LoggedAt),
+		})
+	}
+
+	return rows
+}
+
+func historyRows(data ClientData) [][]string {
+	programNames := programNames(data)
+
+	rows := [][]string{{"user_program_id", "program", "exercise_id", "exercise", "reps", "weight_kg", "is_record", "entered_by", "entered_by_id", "created_at"}}
+
+	for _, record := range data.UserHistory {
+		rows = append(rows, []string{
+			uintText(record.UserProgramId),
+			programNames[record.UserProgramId],
+			uintText(record.ExerciseId),
+			record.Name(),
+			uintText(record.Reps),
+			floatText(record.Weight),
+			strconv.FormatBool(record.IsRecord),
+			string(record.EnteredBy),
+			strconv.FormatInt(record.EnteredById, 10),
+			timeText(record.LoggedAt),
+		})
+	}
+
+	return rows
+}
+
+func measureRows(data ClientData) [][]string {
+	rows := [][]string{{"measure_id", "measure", "value", "units", "created_at"}}
+
+	for _, userMeasure := range data.UserMeasures {
+		rows = append(rows, []string{
+			uintText(userMeasure.MeasureId),
+			userMeasure.Name(),
+			floatText(userMeasure.Value),
+			userMeasure.Units(),
+			timeText(userMeasure.CreatedAt),
+		})
+	}
+
+	return rows
+}
+
+func programNames(data ClientData) map[uint]string {
+	names := make(map[uint]string, len(data.UserPrograms))
+
+	for _, userProgram := range data.UserPrograms {
+		names[userProgram.Id] = userProgram.Name()
+	}
+
+	return names
+}
+
+func uintText(value uint) string {
+	return strconv.FormatUint(uint64(value), 10)
+}
+
+func floatText(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func timeText(value time.Time) string {
+	return value.Format(time.RFC3339)
+}
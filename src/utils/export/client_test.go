@@ -0,0 +1,166 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"reflect"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClientArchive(t *testing.T) {
+	assignedAt := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	loggedAt := time.Date(2024, 3, 5, 18, 30, 0, 0, time.UTC)
+	squat := models.Exercise{Id: 7, Name: "Присід"}
+
+	data := ClientData{
+		UserPrograms: []models.UserProgram{
+			{Id: 3, ProgramId: 2, Program: models.Program{Id: 2, Name: "Сила"}, CreatedAt: assignedAt},
+		},
+		UserResults: []models.UserResult{
+			{Id: 11, UserProgramId: 3, ExerciseId: 7, Reps: 5, Weight: 102.5, Exercise: squat, LoggedAt: loggedAt.Add(time.Hour)},
+		},
+		UserHistory: []models.UserResultHistory{
+			{Id: 21, UserResultId: 11, UserId: 42, UserProgramId: 3, ExerciseId: 7, Reps: 5, Weight: 100, IsRecord: true, EnteredBy: constants.ClientResultSource, EnteredById: 42, Exercise: squat, LoggedAt: loggedAt},
+			{Id: 22, UserResultId: 11, UserId: 42, UserProgramId: 3, ExerciseId: 7, Reps: 5, Weight: 102.5, IsRecord: true, EnteredBy: constants.TrainerResultSource, EnteredById: 1, Exercise: squat, LoggedAt: loggedAt.Add(time.Hour)},
+		},
+		UserMeasures: []models.UserMeasure{
+			{Id: 5, UserId: 42, MeasureId: 4, Value: 81.3, Measure: models.Measure{Id: 4, Name: "Вага", Units: "кг"}, CreatedAt: loggedAt},
+		},
+	}
+
+	archive, err := ClientArchive(data)
+
+	if err != nil {
+		t.Fatalf("ClientArchive: %v", err)
+	}
+
+	files := readArchive(t, archive)
+
+	tests := []struct {
+		name string
+		want [][]string
+	}{
+		{
+			name: "programs.csv",
+			want: [][]string{
+				{"user_program_id", "program_id", "program", "assigned_at"},
+				{"3", "2", "Сила", "2024-03-01T09:00:00Z"},
+			},
+		},
+		{
+			name: "results.csv",
+			want: [][]string{
+				{"user_program_id", "program", "exercise_id", "exercise", "reps", "weight_kg", "updated_at"},
+				{"3", "Сила", "7", "Присід", "5", "102.5", "2024-03-05T19:30:00Z"},
+			},
+		},
+		{
+			name: "history.csv",
+			want: [][]string{
+				{"user_program_id", "program", "exercise_id", "exercise", "reps", "weight_kg", "is_record", "entered_by", "entered_by_id", "created_at"},
+				{"3", "Сила", "7", "Присід", "5", "100", "true", "client", "42", "2024-03-05T18:30:00Z"},
+				{"3", "Сила", "7", "Присід", "5", "102.5", "true", "trainer", "1", "2024-03-05T19:30:00Z"},
+			},
+		},
+		{
+			name: "measures.csv",
+			want: [][]string{
+				{"measure_id", "measure", "value", "units", "created_at"},
+				{"4", "Вага", "81.3", "кг", "2024-03-05T18:30:00Z"},
+			},
+		},
+	}
+
+	if len(files) != len(tests) {
+		t.Errorf("got %d files in the archive, want %d", len(files), len(tests))
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, ok := files[tt.name]
+
+			if !ok {
+				t.Fatalf("%s is missing from the archive", tt.name)
+			}
+
+			if !strings.HasPrefix(content, utf8Bom) {
+				t.Errorf("%s does not start with a UTF-8 BOM", tt.name)
+			}
+
+			got, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(content, utf8Bom))).ReadAll()
+
+			if err != nil {
+				t.Fatalf("read %s: %v", tt.name, err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got rows %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientArchiveEmpty(t *testing.T) {
+	archive, err := ClientArchive(ClientData{})
+
+	if err != nil {
+		t.Fatalf("ClientArchive: %v", err)
+	}
+
+	for name, content := range readArchive(t, archive) {
+		got, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(content, utf8Bom))).ReadAll()
+
+		if err != nil {
+			t.Fatalf("read %s: %v", name, err)
+		}
+
+		if len(got) != 1 {
+			t.Errorf("%s: got %d rows, want only the header", name, len(got))
+		}
+	}
+}
+
+func TestClientArchiveName(t *testing.T) {
+	now := time.Date(2024, 3, 5, 23, 59, 0, 0, time.UTC)
+
+	if got, want := ClientArchiveName(models.User{Id: 42}, now), "client_42_2024-03-05.zip"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func readArchive(t *testing.T, archive []byte) map[string]string {
+	t.Helper()
+
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+
+	if err != nil {
+		t.Fatalf("open archive: %v", err)
+	}
+
+	files := make(map[string]string, len(reader.File))
+
+	for _, file := range reader.File {
+		rc, err := file.Open()
+
+		if err != nil {
+			t.Fatalf("open %s: %v", file.Name, err)
+		}
+
+		content, err := io.ReadAll(rc)
+		rc.Close()
+
+		if err != nil {
+			t.Fatalf("read %s: %v", file.Name, err)
+		}
+
+		files[file.Name] = string(content)
+	}
+
+	return files
+}
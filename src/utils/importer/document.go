@@ -0,0 +1,147 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+type Document struct {
+	Programs []ProgramSpec `json:"programs" yaml:"programs"`
+}
+
+type ProgramSpec struct {
+	Name      string         `json:"name" yaml:"name"`
+	RepScheme string         `json:"repScheme" yaml:"repScheme"`
+	Exercises []ExerciseSpec `json:"exercises" yaml:"exercises"`
+}
+
+type ExerciseSpec struct {
+	Name      string `json:"name" yaml:"name"`
+	RepScheme string `json:"repScheme" yaml:"repScheme"`
+}
+
+// DocumentError is a problem with the uploaded file itself, its message is meant
+// for the user.
+type DocumentError struct {
+	Err error
+}
+
+func (e *DocumentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *DocumentError) Unwrap() error {
+	return e.Err
+}
+
+var csvHeader = []string{"program", "program_rep_scheme", "exercise", "exercise_rep_scheme"}
+
+func Parse(filename string, data []byte) (*Document, error) {
+	var doc Document
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".json":
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("не вдалося прочитати JSON: %v", err)
+		}
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("не вдалося прочитати YAML: %v", err)
+		}
+	case ".csv":
+		parsed, err := parseCsv(data)
+
+		if err != nil {
+			return nil, err
+		}
+
+		doc = *parsed
+	default:
+		return nil, errors.New("підтримуються лише файли .yaml, .yml, .json та .csv")
+	}
+
+	return &doc, nil
+}
+
+func parseCsv(data []byte) (*Document, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+
+	if err != nil {
+		return nil, fmt.Errorf("не вдалося прочитати заголовок CSV: %v", err)
+	}
+
+	columns := make(map[string]int, len(header))
+
+	for i, column := range header {
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+
+	for _, column := range []string{"program", "exercise"} {
+		if _, ok := columns[column]; !ok {
+			return nil, fmt.Errorf("у CSV немає колонки %q, очікувані колонки: %s", column, strings.Join(csvHeader, ","))
+		}
+	}
+
+	doc := &Document{}
+	programIndex := make(map[string]int)
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("помилка в рядку %d CSV: %v", line, err)
+		}
+
+		field := func(name string) string {
+			i, ok := columns[name]
+
+			if !ok || i >= len(record) {
+				return ""
+			}
+
+			return strings.TrimSpace(record[i])
+		}
+
+		programName := field("program")
+
+		if programName == "" {
+			return nil, fmt.Errorf("у рядку %d CSV не вказано програму", line)
+		}
+
+		i, ok := programIndex[programName]
+
+		if !ok {
+			i = len(doc.Programs)
+			programIndex[programName] = i
+			doc.Programs = append(doc.Programs, ProgramSpec{Name: programName})
+		}
+
+		if repScheme := field("program_rep_scheme"); repScheme != "" {
+			doc.Programs[i].RepScheme = repScheme
+		}
+
+		if exerciseName := field("exercise"); exerciseName != "" {
+			doc.Programs[i].Exercises = append(doc.Programs[i].Exercises, ExerciseSpec{
+				Name:      exerciseName,
+				RepScheme: field("exercise_rep_scheme"),
+			})
+		}
+	}
+
+	return doc, nil
+}
@@ -0,0 +1,82 @@
+package importer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	want := &Document{Programs: []ProgramSpec{{
+		Name:      "Push",
+		RepScheme: "6,8",
+		Exercises: []ExerciseSpec{{Name: "Bench press"}, {Name: "Dips", RepScheme: "10,12"}},
+	}}}
+
+	tests := []struct {
+		name     string
+		filename string
+		data     string
+		want     *Document
+		wantErr  bool
+	}{
+		{
+			name:     "yaml",
+			filename: "programs.yaml",
+			data:     "programs:\n  - name: Push\n    repScheme: 6,8\n    exercises:\n      - name: Bench press\n      - name: Dips\n        repScheme: 10,12\n",
+			want:     want,
+		},
+		{
+			name:     "json with upper case extension",
+			filename: "programs.JSON",
+			data:     `{"programs":[{"name":"Push","repScheme":"6,8","exercises":[{"name":"Bench press"},{"name":"Dips","repScheme":"10,12"}]}]}`,
+			want:     want,
+		},
+		{
+			name:     "csv with bom and spaces",
+			filename: "programs.csv",
+			data:     "\xef\xbb\xbfprogram, program_rep_scheme, exercise, exercise_rep_scheme\nPush,\"6,8\",Bench press,\nPush,,Dips,\"10,12\"\n",
+			want: &Document{Programs: []ProgramSpec{{
+				Name:      "Push",
+				RepScheme: "6,8",
+				Exercises: []ExerciseSpec{{Name: "Bench press"}, {Name: "Dips", RepScheme: "10,12"}},
+			}}},
+		},
+		{
+			name:     "csv without optional columns",
+			filename: "programs.csv",
+			data:     "exercise,program\nSquat,Legs\n,Arms\n",
+			want: &Document{Programs: []ProgramSpec{
+				{Name: "Legs", Exercises: []ExerciseSpec{{Name: "Squat"}}},
+				{Name: "Arms"},
+			}},
+		},
+		{name: "unsupported extension", filename: "programs.txt", data: "programs: []", wantErr: true},
+		{name: "invalid yaml", filename: "programs.yml", data: "programs: [", wantErr: true},
+		{name: "invalid json", filename: "programs.json", data: "{", wantErr: true},
+		{name: "empty csv", filename: "programs.csv", data: "", wantErr: true},
+		{name: "csv without exercise column", filename: "programs.csv", data: "program\nPush\n", wantErr: true},
+		{name: "csv row without program", filename: "programs.csv", data: "program,exercise\n,Dips\n", wantErr: true},
+		{name: "csv with broken quotes", filename: "programs.csv", data: "program,exercise\n\"Push,Dips\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.filename, []byte(tt.data))
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package importer
+
+import (
+	"rezvin-pro-bot/src/models"
+)
+
+type ExistingProgram struct {
+	Program   models.Program
+	Exercises []models.Exercise
+}
+
+type Plan struct {
+	Programs []ProgramChange
+}
+
+type ProgramChange struct {
+	ProgramId    uint
+	Name         string
+	RepScheme    string
+	OldRepScheme string
+	Exercises    []ExerciseChange
+}
+
+type ExerciseChange struct {
+	ExerciseId   uint
+	Name         string
+	RepScheme    string
+	OldRepScheme string
+	Position     uint
+	OldPosition  uint
+}
+
+// BuildPlan orders the exercises of every program as listed in the document,
+// existing exercises missing from the document keep their relative order after them.
+func BuildPlan(doc Document, existing map[string]ExistingProgram) Plan {
+	plan := Plan{}
+
+	for _, spec := range doc.Programs {
+		change := ProgramChange{Name: spec.Name, RepScheme: spec.RepScheme}
+
+		current, ok := existing[spec.Name]
+
+		exercises := make(map[string]models.Exercise)
+
+		if ok {
+			change.ProgramId = current.Program.Id
+			change.OldRepScheme = current.Program.RepScheme
+
+			for _, exercise := range current.Exercises {
+				exercises[exercise.Name] = exercise
+			}
+		}
+
+		position := uint(0)
+
+		for _, exerciseSpec := range spec.Exercises {
+			position++
+
+			exerciseChange := ExerciseChange{Name: exerciseSpec.Name, RepScheme: exerciseSpec.RepScheme, Position: position}
+
+			if exercise, found := exercises[exerciseSpec.Name]; found {
+				delete(exercises, exerciseSpec.Name)
+
+				exerciseChange.ExerciseId = exercise.Id
+				exerciseChange.OldRepScheme = exercise.RepScheme
+				exerciseChange.OldPosition = exercise.Position
+
+				if !exerciseChange.RepSchemeChanged() && !exerciseChange.PositionChanged() {
+					continue
+				}
+			}
+
+			change.Exercises = append(change.Exercises, exerciseChange)
+		}
+
+		for _, exercise := range current.Exercises {
+			if _, missing := exercises[exercise.Name]; !missing {
+				continue
+			}
+
+			position++
+
+			if exercise.Position != position {
+				change.Exercises = append(change.Exercises, ExerciseChange{
+					ExerciseId:   exercise.Id,
+					Name:         exercise.Name,
+					OldRepScheme: exercise.RepScheme,
+					Position:     position,
+					OldPosition:  exercise.Position,
+				})
+			}
+		}
+
+		if change.IsNew() || change.RepSchemeChanged() || len(change.Exercises) > 0 {
+			plan.Programs = append(plan.Programs, change)
+		}
+	}
+
+	return plan
+}
+
+func (p Plan) IsEmpty() bool {
+	return len(p.Programs) == 0
+}
+
+func (p ProgramChange) IsNew() bool {
+	return p.ProgramId == 0
+}
+
+func (p ProgramChange) RepSchemeChanged() bool {
+	return !p.IsNew() && p.RepScheme != "" && p.RepScheme != p.OldRepScheme
+}
+
+func (e ExerciseChange) IsNew() bool {
+	return e.ExerciseId == 0
+}
+
+func (e ExerciseChange) RepSchemeChanged() bool {
+	return !e.IsNew() && e.RepScheme != "" && e.RepScheme != e.OldRepScheme
+}
+
+func (e ExerciseChange) PositionChanged() bool {
+	return !e.IsNew() && e.Position != e.OldPosition
+}
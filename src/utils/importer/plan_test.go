@@ -0,0 +1,130 @@
+package importer
+
+import (
+	"reflect"
+	"rezvin-pro-bot/src/models"
+	"testing"
+)
+
+func TestBuildPlan(t *testing.T) {
+	push := ExistingProgram{
+		Program: models.Program{Id: 1, Name: "Push", RepScheme: "6,8"},
+		Exercises: []models.Exercise{
+			{Id: 10, Name: "Bench press", ProgramId: 1, Position: 1},
+			{Id: 11, Name: "Dips", ProgramId: 1, RepScheme: "10", Position: 2},
+			{Id: 12, Name: "Flyes", ProgramId: 1, Position: 3},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		doc      Document
+		existing map[string]ExistingProgram
+		want     Plan
+	}{
+		{
+			name: "new program keeps document order",
+			doc: Document{Programs: []ProgramSpec{{
+				Name:      "Legs",
+				RepScheme: "5",
+				Exercises: []ExerciseSpec{{Name: "Squat"}, {Name: "Lunge", RepScheme: "12"}},
+			}}},
+			want: Plan{Programs: []ProgramChange{{
+				Name:      "Legs",
+				RepScheme: "5",
+				Exercises: []ExerciseChange{
+					{Name: "Squat", Position: 1},
+					{Name: "Lunge", RepScheme: "12", Position: 2},
+				},
+			}}},
+		},
+		{
+			name: "unchanged program is skipped",
+			doc: Document{Programs: []ProgramSpec{{
+				Name:      "Push",
+				Exercises: []ExerciseSpec{{Name: "Bench press"}, {Name: "Dips", RepScheme: "10"}, {Name: "Flyes"}},
+			}}},
+			existing: map[string]ExistingProgram{"Push": push},
+			want:     Plan{},
+		},
+		{
+			name:     "same rep scheme is not a change",
+			doc:      Document{Programs: []ProgramSpec{{Name: "Push", RepScheme: "6,8"}}},
+			existing: map[string]ExistingProgram{"Push": push},
+			want:     Plan{},
+		},
+		{
+			name: "rep scheme changes",
+			doc: Document{Programs: []ProgramSpec{{
+				Name:      "Push",
+				RepScheme: "5",
+				Exercises: []ExerciseSpec{{Name: "Bench press", RepScheme: "3"}, {Name: "Dips"}, {Name: "Flyes"}},
+			}}},
+			existing: map[string]ExistingProgram{"Push": push},
+			want: Plan{Programs: []ProgramChange{{
+				ProgramId:    1,
+				Name:         "Push",
+				RepScheme:    "5",
+				OldRepScheme: "6,8",
+				Exercises: []ExerciseChange{
+					{ExerciseId: 10, Name: "Bench press", RepScheme: "3", Position: 1, OldPosition: 1},
+				},
+			}}},
+		},
+		{
+			name: "reorders and moves missing exercises to the end",
+			doc: Document{Programs: []ProgramSpec{{
+				Name:      "Push",
+				Exercises: []ExerciseSpec{{Name: "Flyes"}, {Name: "Push-up"}, {Name: "Bench press"}},
+			}}},
+			existing: map[string]ExistingProgram{"Push": push},
+			want: Plan{Programs: []ProgramChange{{
+				ProgramId:    1,
+				Name:         "Push",
+				OldRepScheme: "6,8",
+				Exercises: []ExerciseChange{
+					{ExerciseId: 12, Name: "Flyes", Position: 1, OldPosition: 3},
+					{Name: "Push-up", Position: 2},
+					{ExerciseId: 10, Name: "Bench press", Position: 3, OldPosition: 1},
+					{ExerciseId: 11, Name: "Dips", OldRepScheme: "10", Position: 4, OldPosition: 2},
+				},
+			}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BuildPlan(tt.doc, tt.existing)
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExerciseChange(t *testing.T) {
+	tests := []struct {
+		name                string
+		change              ExerciseChange
+		wantRepSchemeChange bool
+		wantPositionChange  bool
+	}{
+		{"new exercise", ExerciseChange{RepScheme: "5", Position: 1}, false, false},
+		{"blank rep scheme keeps the old one", ExerciseChange{ExerciseId: 1, OldRepScheme: "5", Position: 1, OldPosition: 1}, false, false},
+		{"rep scheme changed", ExerciseChange{ExerciseId: 1, RepScheme: "6", OldRepScheme: "5", Position: 1, OldPosition: 1}, true, false},
+		{"position changed", ExerciseChange{ExerciseId: 1, Position: 2, OldPosition: 1}, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.change.RepSchemeChanged(); got != tt.wantRepSchemeChange {
+				t.Errorf("RepSchemeChanged: got %v, want %v", got, tt.wantRepSchemeChange)
+			}
+
+			if got := tt.change.PositionChanged(); got != tt.wantPositionChange {
+				t.Errorf("PositionChanged: got %v, want %v", got, tt.wantPositionChange)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"rezvin-pro-bot/src/utils/rep_scheme"
+	"strings"
+	"unicode/utf8"
+)
+
+const maxNameLength = 100
+
+func Normalize(doc *Document) error {
+	if len(doc.Programs) == 0 {
+		return errors.New("файл не містить жодної програми")
+	}
+
+	programNames := make(map[string]bool, len(doc.Programs))
+
+	for i := range doc.Programs {
+		program := &doc.Programs[i]
+		program.Name = strings.TrimSpace(program.Name)
+
+		if err := validateName(program.Name); err != nil {
+			return fmt.Errorf("програма №%d: %v", i+1, err)
+		}
+
+		if programNames[program.Name] {
+			return fmt.Errorf("програма %q вказана у файлі кілька разів", program.Name)
+		}
+
+		programNames[program.Name] = true
+
+		repScheme, err := normalizeRepScheme(program.RepScheme)
+
+		if err != nil {
+			return fmt.Errorf("програма %q: %v", program.Name, err)
+		}
+
+		program.RepScheme = repScheme
+
+		exerciseNames := make(map[string]bool, len(program.Exercises))
+
+		for j := range program.Exercises {
+			exercise := &program.Exercises[j]
+			exercise.Name = strings.TrimSpace(exercise.Name)
+
+			if err = validateName(exercise.Name); err != nil {
+				return fmt.Errorf("програма %q, вправа №%d: %v", program.Name, j+1, err)
+			}
+
+			if exerciseNames[exercise.Name] {
+				return fmt.Errorf("вправа %q вказана в програмі %q кілька разів", exercise.Name, program.Name)
+			}
+
+			exerciseNames[exercise.Name] = true
+
+			if exercise.RepScheme, err = normalizeRepScheme(exercise.RepScheme); err != nil {
+				return fmt.Errorf("програма %q, вправа %q: %v", program.Name, exercise.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateName(name string) error {
+	if name == "" {
+		return errors.New("назва не може бути порожньою")
+	}
+
+	if utf8.RuneCountInString(name) > maxNameLength {
+		return fmt.Errorf("назва довша за %d символів", maxNameLength)
+	}
+
+	return nil
+}
+
+func normalizeRepScheme(scheme string) (string, error) {
+	if strings.TrimSpace(scheme) == "" {
+		return "", nil
+	}
+
+	reps, err := rep_scheme.Parse(scheme)
+
+	if err != nil {
+		return "", fmt.Errorf("некоректна схема повторень %q", scheme)
+	}
+
+	return rep_scheme.Format(reps), nil
+}
@@ -0,0 +1,94 @@
+package importer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	longName := strings.Repeat("я", maxNameLength)
+
+	tests := []struct {
+		name    string
+		doc     Document
+		want    Document
+		wantErr bool
+	}{
+		{
+			name: "trims names and formats rep schemes",
+			doc: Document{Programs: []ProgramSpec{{
+				Name:      "  Push ",
+				RepScheme: "10/6 8",
+				Exercises: []ExerciseSpec{{Name: " Dips", RepScheme: "12;10"}, {Name: "Bench press"}},
+			}}},
+			want: Document{Programs: []ProgramSpec{{
+				Name:      "Push",
+				RepScheme: "6,8,10",
+				Exercises: []ExerciseSpec{{Name: "Dips", RepScheme: "10,12"}, {Name: "Bench press"}},
+			}}},
+		},
+		{
+			name: "name at max length",
+			doc:  Document{Programs: []ProgramSpec{{Name: longName}}},
+			want: Document{Programs: []ProgramSpec{{Name: longName}}},
+		},
+		{
+			name: "same exercise in different programs",
+			doc: Document{Programs: []ProgramSpec{
+				{Name: "A", Exercises: []ExerciseSpec{{Name: "Squat"}}},
+				{Name: "B", Exercises: []ExerciseSpec{{Name: "Squat"}}},
+			}},
+			want: Document{Programs: []ProgramSpec{
+				{Name: "A", Exercises: []ExerciseSpec{{Name: "Squat"}}},
+				{Name: "B", Exercises: []ExerciseSpec{{Name: "Squat"}}},
+			}},
+		},
+		{name: "no programs", doc: Document{}, wantErr: true},
+		{name: "blank program name", doc: Document{Programs: []ProgramSpec{{Name: "  "}}}, wantErr: true},
+		{name: "program name too long", doc: Document{Programs: []ProgramSpec{{Name: longName + "я"}}}, wantErr: true},
+		{
+			name:    "duplicate program after trimming",
+			doc:     Document{Programs: []ProgramSpec{{Name: "Push"}, {Name: " Push"}}},
+			wantErr: true,
+		},
+		{name: "invalid program rep scheme", doc: Document{Programs: []ProgramSpec{{Name: "Push", RepScheme: "0"}}}, wantErr: true},
+		{
+			name:    "blank exercise name",
+			doc:     Document{Programs: []ProgramSpec{{Name: "Push", Exercises: []ExerciseSpec{{Name: ""}}}}},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate exercise",
+			doc:     Document{Programs: []ProgramSpec{{Name: "Push", Exercises: []ExerciseSpec{{Name: "Dips"}, {Name: "Dips "}}}}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid exercise rep scheme",
+			doc:     Document{Programs: []ProgramSpec{{Name: "Push", Exercises: []ExerciseSpec{{Name: "Dips", RepScheme: "many"}}}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := tt.doc
+			err := Normalize(&doc)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", doc)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(doc, tt.want) {
+				t.Errorf("got %+v, want %+v", doc, tt.want)
+			}
+		})
+	}
+}
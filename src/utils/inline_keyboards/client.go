@@ -20,7 +20,7 @@ func ClientList(clients []models.User, totalClientCount int64, limit, offset int
 		clientKb = append(clientKb, []tg_models.InlineKeyboardButton{
 			{
 				Text:         client.GetPrivateName(),
-				CallbackData: bot_utils.AddParamsToQueryString(constants.ClientSelected, params),
+				CallbackData: bot_utils.EncodeCallbackData(constants.ClientSelected, params),
 			},
 		})
 	}
@@ -48,13 +48,19 @@ func ClientSelectedMenu(clientId int64) *tg_models.InlineKeyboardMarkup {
 	return &tg_models.InlineKeyboardMarkup{
 		InlineKeyboard: [][]tg_models.InlineKeyboardButton{
 			{
-				{Text: "📋 Програми клієнта", CallbackData: bot_utils.AddParamsToQueryString(constants.ClientProgramList, params)},
+				{Text: "📋 Програми клієнта", CallbackData: bot_utils.EncodeCallbackData(constants.ClientProgramList, params)},
 			},
 			{
-				{Text: "📏 Заміри клієнта", CallbackData: bot_utils.AddParamsToQueryString(constants.ClientMeasureList, params)},
+				{Text: "📏 Заміри клієнта", CallbackData: bot_utils.EncodeCallbackData(constants.ClientMeasureList, params)},
 			},
 			{
-				{Text: "➕ Призначити програму для клієнта", CallbackData: bot_utils.AddParamsToQueryString(constants.ClientProgramAdd, params)},
+				{Text: "🏋️ Тренування клієнта", CallbackData: bot_utils.EncodeCallbackData(constants.ClientWorkoutList, params)},
+			},
+			{
+				{Text: "➕ Призначити програму для клієнта", CallbackData: bot_utils.EncodeCallbackData(constants.ClientProgramAdd, params)},
+			},
+			{
+				{Text: "📤 Експорт даних клієнта", CallbackData: bot_utils.EncodeCallbackData(constants.ClientExport, params)},
 			},
 			{
 				{Text: "🔙 Назад", CallbackData: constants.BackToClientList},
@@ -21,7 +21,7 @@ func ClientMeasuresList(clientId int64, measures []models.Measure, totalMeasureC
 		measuresKb = append(measuresKb, []tg_models.InlineKeyboardButton{
 			{
 				Text:         measure.Name,
-				CallbackData: bot_utils.AddParamsToQueryString(constants.ClientMeasureSelected, params),
+				CallbackData: bot_utils.EncodeCallbackData(constants.ClientMeasureSelected, params),
 			},
 		})
 	}
@@ -60,16 +60,19 @@ func ClientMeasureMenu(clientId int64, measureId uint) *tg_models.InlineKeyboard
 	return &tg_models.InlineKeyboardMarkup{
 		InlineKeyboard: [][]tg_models.InlineKeyboardButton{
 			{
-				{Text: "🚀 Переглянути результати заміру", CallbackData: bot_utils.AddParamsToQueryString(constants.ClientMeasureResult, params)},
+				{Text: "🚀 Переглянути результати заміру", CallbackData: bot_utils.EncodeCallbackData(constants.ClientMeasureResult, params)},
 			},
 			{
-				{Text: "➕️ Внести результат заміру", CallbackData: bot_utils.AddParamsToQueryString(constants.ClientMeasureAdd, params)},
+				{Text: "📈 Графік заміру", CallbackData: bot_utils.EncodeCallbackData(constants.ClientMeasureChart, params)},
 			},
 			{
-				{Text: "➖ Видалити останній результат заміру", CallbackData: bot_utils.AddParamsToQueryString(constants.ClientMeasureDelete, params)},
+				{Text: "➕️ Внести результат заміру", CallbackData: bot_utils.EncodeCallbackData(constants.ClientMeasureAdd, params)},
 			},
 			{
-				{Text: "🔙 Назад", CallbackData: bot_utils.AddParamsToQueryString(constants.ClientMeasureList, params)},
+				{Text: "➖ Видалити останній результат заміру", CallbackData: bot_utils.EncodeCallbackData(constants.ClientMeasureDelete, params)},
+			},
+			{
+				{Text: "🔙 Назад", CallbackData: bot_utils.EncodeCallbackData(constants.ClientMeasureList, params)},
 			},
 		},
 	}
@@ -17,16 +17,16 @@ func ClientProgramMenu(clientId int64, program models.UserProgram) *tg_models.In
 	return &tg_models.InlineKeyboardMarkup{
 		InlineKeyboard: [][]tg_models.InlineKeyboardButton{
 			{
-				{Text: "🚀 Переглянути результати", CallbackData: bot_utils.AddParamsToQueryString(constants.ClientResultList, params)},
+				{Text: "🚀 Переглянути результати", CallbackData: bot_utils.EncodeCallbackData(constants.ClientResultList, params)},
 			},
 			{
-				{Text: "✍️ Внести результати", CallbackData: bot_utils.AddParamsToQueryString(constants.ClientResultExercisesList, params)},
+				{Text: "✍️ Внести результати", CallbackData: bot_utils.EncodeCallbackData(constants.ClientResultExercisesList, params)},
 			},
 			{
-				{Text: "➖ Видалити програму", CallbackData: bot_utils.AddParamsToQueryString(constants.ClientProgramDelete, params)},
+				{Text: "➖ Видалити програму", CallbackData: bot_utils.EncodeCallbackData(constants.ClientProgramDelete, params)},
 			},
 			{
-				{Text: "🔙 Назад", CallbackData: bot_utils.AddParamsToQueryString(constants.ClientProgramList, params)},
+				{Text: "🔙 Назад", CallbackData: bot_utils.EncodeCallbackData(constants.ClientProgramList, params)},
 			},
 		},
 	}
@@ -45,7 +45,7 @@ func ClientProgramList(clientId int64, programs []models.UserProgram, totalProgr
 		programKb = append(programKb, []tg_models.InlineKeyboardButton{
 			{
 				Text:         program.Name(),
-				CallbackData: bot_utils.AddParamsToQueryString(constants.ClientProgramSelected, params),
+				CallbackData: bot_utils.EncodeCallbackData(constants.ClientProgramSelected, params),
 			},
 		})
 	}
@@ -87,7 +87,7 @@ func ClientProgramAssignList(clientId int64, programs []models.Program, totalPro
 		programKb = append(programKb, []tg_models.InlineKeyboardButton{
 			{
 				Text:         program.Name,
-				CallbackData: bot_utils.AddParamsToQueryString(constants.ClientProgramAssign, params),
+				CallbackData: bot_utils.EncodeCallbackData(constants.ClientProgramAssign, params),
 			},
 		})
 	}
@@ -23,7 +23,7 @@ func ClientResultsExerciseList(clientId int64, userProgramId uint, exercises []m
 		exerciseKb = append(exerciseKb, []tg_models.InlineKeyboardButton{
 			{
 				Text:         exercise.Name,
-				CallbackData: bot_utils.AddParamsToQueryString(constants.ClientResultExerciseSelected, params),
+				CallbackData: bot_utils.EncodeCallbackData(constants.ClientResultExerciseSelected, params),
 			},
 		})
 	}
@@ -70,7 +70,11 @@ func ClientResultExerciseSelectedOk(clientId int64, records []models.UserResult)
 		recordsKb = append(recordsKb, []tg_models.InlineKeyboardButton{
 			{
 				Text:         fmt.Sprintf("%d повторень", record.Reps),
-				CallbackData: bot_utils.AddParamsToQueryString(constants.ClientResultExerciseReps, params),
+				CallbackData: bot_utils.EncodeCallbackData(constants.ClientResultExerciseReps, params),
+			},
+			{
+				Text:         "📜 Історія",
+				CallbackData: bot_utils.EncodeCallbackData(constants.ClientResultHistory, params),
 			},
 		})
 	}
@@ -80,7 +84,58 @@ func ClientResultExerciseSelectedOk(clientId int64, records []models.UserResult)
 	backParams.UserProgramId = records[0].UserProgramId
 	backParams.ExerciseId = records[0].ExerciseId
 
+	recordsKb = append(recordsKb, []tg_models.InlineKeyboardButton{
+		{Text: "📈 Графік прогресу", CallbackData: bot_utils.EncodeCallbackData(constants.ClientResultChart, backParams)},
+	})
+
 	return &tg_models.InlineKeyboardMarkup{
 		InlineKeyboard: append(recordsKb, GetBackButton(constants.ClientResultExercisesList, backParams)),
 	}
 }
+
+func ClientResultHistory(clientId int64, record models.UserResult, historyLen int, totalHistoryCount int64, limit, offset int) *tg_models.InlineKeyboardMarkup {
+	nextParams := types.NewEmptyParams()
+	nextParams.UserId = clientId
+	nextParams.UserProgramId = record.UserProgramId
+	nextParams.ExerciseId = record.ExerciseId
+	nextParams.UserResultId = record.Id
+
+	previousParams := types.NewEmptyParams()
+	previousParams.UserId = clientId
+	previousParams.UserProgramId = record.UserProgramId
+	previousParams.ExerciseId = record.ExerciseId
+	previousParams.UserResultId = record.Id
+
+	backParams := types.NewEmptyParams()
+	backParams.UserId = clientId
+	backParams.UserProgramId = record.UserProgramId
+	backParams.ExerciseId = record.ExerciseId
+
+	return &tg_models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]tg_models.InlineKeyboardButton{
+			GetPaginationButtons(
+				historyLen,
+				totalHistoryCount,
+				constants.ClientResultHistory,
+				limit,
+				offset,
+				nextParams,
+				previousParams,
+			),
+			GetBackButton(constants.ClientResultExerciseSelected, backParams),
+		},
+	}
+}
+
+func ClientResultChartOk(clientId int64, userProgramId, exerciseId uint) *tg_models.InlineKeyboardMarkup {
+	params := types.NewEmptyParams()
+	params.UserId = clientId
+	params.UserProgramId = userProgramId
+	params.ExerciseId = exerciseId
+
+	return &tg_models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]tg_models.InlineKeyboardButton{
+			GetOkButton(constants.ClientResultExerciseSelected, params),
+		},
+	}
+}
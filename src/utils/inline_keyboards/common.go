@@ -20,7 +20,7 @@ func GetPaginationButtons(
 
 		kb = append(kb, tg_models.InlineKeyboardButton{
 			Text:         "⬅️ Попередні",
-			CallbackData: bot_utils.AddParamsToQueryString(callBackData, previousParams),
+			CallbackData: bot_utils.EncodeCallbackData(callBackData, previousParams),
 		})
 	}
 
@@ -30,7 +30,7 @@ func GetPaginationButtons(
 
 		kb = append(kb, tg_models.InlineKeyboardButton{
 			Text:         "➡️ Наступні",
-			CallbackData: bot_utils.AddParamsToQueryString(callBackData, nextParams),
+			CallbackData: bot_utils.EncodeCallbackData(callBackData, nextParams),
 		})
 	}
 
@@ -39,12 +39,12 @@ func GetPaginationButtons(
 
 func GetBackButton(callBackData string, params *types.Params) []tg_models.InlineKeyboardButton {
 	return []tg_models.InlineKeyboardButton{
-		{Text: "🔙 Назад", CallbackData: bot_utils.AddParamsToQueryString(callBackData, params)},
+		{Text: "🔙 Назад", CallbackData: bot_utils.EncodeCallbackData(callBackData, params)},
 	}
 }
 
 func GetOkButton(callBackData string, params *types.Params) []tg_models.InlineKeyboardButton {
 	return []tg_models.InlineKeyboardButton{
-		{Text: "✅ Ок", CallbackData: bot_utils.AddParamsToQueryString(callBackData, params)},
+		{Text: "✅ Ок", CallbackData: bot_utils.EncodeCallbackData(callBackData, params)},
 	}
 }
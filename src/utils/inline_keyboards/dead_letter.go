@@ -0,0 +1,24 @@
+package inline_keyboards
+
+import (
+	tg_models "github.com/go-telegram/bot/models"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/types"
+)
+
+func DeadLetterList(deadLettersLen int, totalCount int64, limit, offset int) *tg_models.InlineKeyboardMarkup {
+	return &tg_models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]tg_models.InlineKeyboardButton{
+			GetPaginationButtons(
+				deadLettersLen,
+				totalCount,
+				constants.DeadLetterList,
+				limit,
+				offset,
+				types.NewEmptyParams(),
+				types.NewEmptyParams(),
+			),
+			GetBackButton(constants.MainBackToMain, types.NewEmptyParams()),
+		},
+	}
+}
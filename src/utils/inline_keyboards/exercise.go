@@ -1,14 +1,34 @@
 package inline_keyboards
 
 import (
+	"fmt"
 	tg_models "github.com/go-telegram/bot/models"
 	"rezvin-pro-bot/src/constants"
 	"rezvin-pro-bot/src/models"
 	"rezvin-pro-bot/src/types"
 	bot_utils "rezvin-pro-bot/src/utils/bot"
+	"rezvin-pro-bot/src/utils/rep_scheme"
 )
 
 func ExerciseDeleteList(programId uint, exercises []models.Exercise, totalExerciseCount int64, limit, offset int) *tg_models.InlineKeyboardMarkup {
+	return exerciseSelectList(programId, exercises, totalExerciseCount, limit, offset, constants.ExerciseDeleteItem, constants.ExerciseDelete)
+}
+
+func ExerciseRepSchemeList(program models.Program, exercises []models.Exercise, totalExerciseCount int64, limit, offset int) *tg_models.InlineKeyboardMarkup {
+	for i := range exercises {
+		exercises[i].Name = fmt.Sprintf("%s (%s)", exercises[i].Name, rep_scheme.Format(rep_scheme.ForExercise(program, exercises[i])))
+	}
+
+	return exerciseSelectList(program.Id, exercises, totalExerciseCount, limit, offset, constants.ExerciseRepScheme, constants.ExerciseRepSchemes)
+}
+
+func exerciseSelectList(
+	programId uint,
+	exercises []models.Exercise,
+	totalExerciseCount int64,
+	limit, offset int,
+	itemCallback, listCallback string,
+) *tg_models.InlineKeyboardMarkup {
 	exercisesLen := len(exercises)
 	exerciseKb := make([][]tg_models.InlineKeyboardButton, 0, exercisesLen)
 
@@ -21,7 +41,7 @@ func ExerciseDeleteList(programId uint, exercises []models.Exercise, totalExerci
 		exerciseKb = append(exerciseKb, []tg_models.InlineKeyboardButton{
 			{
 				Text:         exercise.Name,
-				CallbackData: bot_utils.AddParamsToQueryString(constants.ExerciseDeleteItem, params),
+				CallbackData: bot_utils.EncodeCallbackData(itemCallback, params),
 			},
 		})
 	}
@@ -35,7 +55,7 @@ func ExerciseDeleteList(programId uint, exercises []models.Exercise, totalExerci
 	exerciseKb = append(exerciseKb, GetPaginationButtons(
 		exercisesLen,
 		totalExerciseCount,
-		constants.ExerciseDelete,
+		listCallback,
 		limit,
 		offset,
 		nextParams,
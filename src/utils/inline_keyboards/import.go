@@ -0,0 +1,36 @@
+package inline_keyboards
+
+import (
+	tg_models "github.com/go-telegram/bot/models"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/types"
+)
+
+func ImportStart() *tg_models.InlineKeyboardMarkup {
+	return &tg_models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]tg_models.InlineKeyboardButton{
+			GetBackButton(constants.MainBackToMain, types.NewEmptyParams()),
+		},
+	}
+}
+
+func ImportConfirm(confirmCallbackData string) *tg_models.InlineKeyboardMarkup {
+	return &tg_models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]tg_models.InlineKeyboardButton{
+			{
+				{Text: "✅ Імпортувати", CallbackData: confirmCallbackData},
+			},
+			{
+				{Text: "❌ Скасувати", CallbackData: constants.ImportCancel},
+			},
+		},
+	}
+}
+
+func ImportOk() *tg_models.InlineKeyboardMarkup {
+	return &tg_models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]tg_models.InlineKeyboardButton{
+			GetOkButton(constants.ImportStart, types.NewEmptyParams()),
+		},
+	}
+}
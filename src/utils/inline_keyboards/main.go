@@ -21,6 +21,15 @@ func AdminMain() *tg_models.InlineKeyboardMarkup {
 			{
 				{Text: "🏋️ Клієнти", CallbackData: constants.ClientList},
 			},
+			{
+				{Text: "📥 Імпорт програм", CallbackData: constants.ImportStart},
+			},
+			{
+				{Text: "📭 Невдалі повідомлення", CallbackData: constants.DeadLetterList},
+			},
+			{
+				{Text: "⚙️ Налаштування", CallbackData: constants.SettingsMenu},
+			},
 			{
 				{Text: "🔙 Назад", CallbackData: constants.MainBackToStart},
 			},
@@ -50,6 +59,12 @@ func UserMenu() *tg_models.InlineKeyboardMarkup {
 			{
 				{Text: "⏱️ Заміри", CallbackData: constants.UserMeasureList},
 			},
+			{
+				{Text: "🏆 Мої рекорди", CallbackData: constants.UserResultRecords},
+			},
+			{
+				{Text: "⚙️ Налаштування", CallbackData: constants.SettingsMenu},
+			},
 			{
 				{Text: "🔙 Назад", CallbackData: constants.MainBackToStart},
 			},
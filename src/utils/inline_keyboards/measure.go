@@ -63,7 +63,7 @@ func MeasureList(measures []models.Measure, totalMeasureCount int64, limit, offs
 		measuresKb = append(measuresKb, []tg_models.InlineKeyboardButton{
 			{
 				Text:         measure.Name,
-				CallbackData: bot_utils.AddParamsToQueryString(constants.MeasureSelected, params),
+				CallbackData: bot_utils.EncodeCallbackData(constants.MeasureSelected, params),
 			},
 		})
 	}
@@ -91,13 +91,13 @@ func MeasureSelectedMenu(measureId uint) *tg_models.InlineKeyboardMarkup {
 	return &tg_models.InlineKeyboardMarkup{
 		InlineKeyboard: [][]tg_models.InlineKeyboardButton{
 			{
-				{Text: "⏱️ Перейменувати замір", CallbackData: bot_utils.AddParamsToQueryString(constants.MeasureRename, params)},
+				{Text: "⏱️ Перейменувати замір", CallbackData: bot_utils.EncodeCallbackData(constants.MeasureRename, params)},
 			},
 			{
-				{Text: "📏 Змінити одиниці виміру", CallbackData: bot_utils.AddParamsToQueryString(constants.MeasureChangeUnits, params)},
+				{Text: "📏 Змінити одиниці виміру", CallbackData: bot_utils.EncodeCallbackData(constants.MeasureChangeUnits, params)},
 			},
 			{
-				{Text: "❌ Видалити замір", CallbackData: bot_utils.AddParamsToQueryString(constants.MeasureDelete, params)},
+				{Text: "❌ Видалити замір", CallbackData: bot_utils.EncodeCallbackData(constants.MeasureDelete, params)},
 			},
 			{
 				{Text: "🔙 Назад", CallbackData: constants.BackToMeasureList},
@@ -20,7 +20,7 @@ func PendingUsersList(users []models.User, totalUsersCount int64, limit, offset
 		userKb = append(userKb, []tg_models.InlineKeyboardButton{
 			{
 				Text:         user.GetPrivateName(),
-				CallbackData: bot_utils.AddParamsToQueryString(constants.PendingUsersSelected, params),
+				CallbackData: bot_utils.EncodeCallbackData(constants.PendingUsersSelected, params),
 			},
 		})
 	}
@@ -48,10 +48,10 @@ func PendingUserDecide(user models.User) *tg_models.InlineKeyboardMarkup {
 	return &tg_models.InlineKeyboardMarkup{
 		InlineKeyboard: [][]tg_models.InlineKeyboardButton{
 			{
-				{Text: "✅ Підтвердити", CallbackData: bot_utils.AddParamsToQueryString(constants.PendingUsersApprove, params)},
+				{Text: "✅ Підтвердити", CallbackData: bot_utils.EncodeCallbackData(constants.PendingUsersApprove, params)},
 			},
 			{
-				{Text: "❌ Відхилити", CallbackData: bot_utils.AddParamsToQueryString(constants.PendingUsersDecline, params)},
+				{Text: "❌ Відхилити", CallbackData: bot_utils.EncodeCallbackData(constants.PendingUsersDecline, params)},
 			},
 			{
 				{Text: "🔙 Назад", CallbackData: constants.BackToPendingUsersList},
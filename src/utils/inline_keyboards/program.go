@@ -35,7 +35,7 @@ func ProgramList(programs []models.Program, totalProgramCount int64, limit, offs
 		programKb = append(programKb, []tg_models.InlineKeyboardButton{
 			{
 				Text:         program.Name,
-				CallbackData: bot_utils.AddParamsToQueryString(constants.ProgramSelected, params),
+				CallbackData: bot_utils.EncodeCallbackData(constants.ProgramSelected, params),
 			},
 		})
 	}
@@ -63,19 +63,28 @@ func ProgramSelectedMenu(programId uint) *tg_models.InlineKeyboardMarkup {
 	return &tg_models.InlineKeyboardMarkup{
 		InlineKeyboard: [][]tg_models.InlineKeyboardButton{
 			{
-				{Text: "📋 Список вправ", CallbackData: bot_utils.AddParamsToQueryString(constants.ExerciseList, params)},
+				{Text: "📋 Список вправ", CallbackData: bot_utils.EncodeCallbackData(constants.ExerciseList, params)},
 			},
 			{
-				{Text: "➕ Додати вправу", CallbackData: bot_utils.AddParamsToQueryString(constants.ExerciseAdd, params)},
+				{Text: "➕ Додати вправу", CallbackData: bot_utils.EncodeCallbackData(constants.ExerciseAdd, params)},
 			},
 			{
-				{Text: "➖ Видалити вправу", CallbackData: bot_utils.AddParamsToQueryString(constants.ExerciseDelete, params)},
+				{Text: "➖ Видалити вправу", CallbackData: bot_utils.EncodeCallbackData(constants.ExerciseDelete, params)},
 			},
 			{
-				{Text: "📝 Перейменувати програму", CallbackData: bot_utils.AddParamsToQueryString(constants.ProgramRename, params)},
+				{Text: "📝 Перейменувати програму", CallbackData: bot_utils.EncodeCallbackData(constants.ProgramRename, params)},
 			},
 			{
-				{Text: "❌ Видалити програму", CallbackData: bot_utils.AddParamsToQueryString(constants.ProgramDelete, params)},
+				{Text: "🔢 Схема повторень програми", CallbackData: bot_utils.EncodeCallbackData(constants.ProgramRepScheme, params)},
+			},
+			{
+				{Text: "🔢 Схема повторень вправи", CallbackData: bot_utils.EncodeCallbackData(constants.ExerciseRepSchemes, params)},
+			},
+			{
+				{Text: "🧮 Формула 1ПМ", CallbackData: bot_utils.EncodeCallbackData(constants.ProgramFormula, params)},
+			},
+			{
+				{Text: "❌ Видалити програму", CallbackData: bot_utils.EncodeCallbackData(constants.ProgramDelete, params)},
 			},
 			{
 				{Text: "🔙 Назад", CallbackData: constants.BackToProgramList},
@@ -111,3 +120,38 @@ func ProgramMenuOk() *tg_models.InlineKeyboardMarkup {
 		},
 	}
 }
+
+func ProgramFormulaMenu(program models.Program) *tg_models.InlineKeyboardMarkup {
+	params := types.NewEmptyParams()
+
+	params.ProgramId = program.Id
+
+	formulas := []struct {
+		text     string
+		callback string
+		formula  constants.OneRepMaxFormula
+	}{
+		{text: "Еплі", callback: constants.ProgramFormulaEpley, formula: constants.EpleyFormula},
+		{text: "Бжицкі", callback: constants.ProgramFormulaBrzycki, formula: constants.BrzyckiFormula},
+		{text: "Ломбарді", callback: constants.ProgramFormulaLombardi, formula: constants.LombardiFormula},
+		{text: "Найкраща оцінка", callback: constants.ProgramFormulaBest, formula: constants.BestFormula},
+	}
+
+	kb := make([][]tg_models.InlineKeyboardButton, 0, len(formulas)+1)
+
+	for _, f := range formulas {
+		text := f.text
+
+		if program.GetOneRepMaxFormula() == f.formula {
+			text = "✅ " + text
+		}
+
+		kb = append(kb, []tg_models.InlineKeyboardButton{
+			{Text: text, CallbackData: bot_utils.EncodeCallbackData(f.callback, params)},
+		})
+	}
+
+	return &tg_models.InlineKeyboardMarkup{
+		InlineKeyboard: append(kb, GetBackButton(constants.ProgramSelected, params)),
+	}
+}
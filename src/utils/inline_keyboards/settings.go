@@ -0,0 +1,40 @@
+package inline_keyboards
+
+import (
+	tg_models "github.com/go-telegram/bot/models"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/types"
+)
+
+func SettingsMenu(unit constants.WeightUnit) *tg_models.InlineKeyboardMarkup {
+	kgText := "Кілограми (кг)"
+	lbText := "Фунти (lb)"
+
+	if unit == constants.PoundUnit {
+		lbText = "✅ " + lbText
+	} else {
+		kgText = "✅ " + kgText
+	}
+
+	return &tg_models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]tg_models.InlineKeyboardButton{
+			{
+				{Text: kgText, CallbackData: constants.SettingsWeightUnitKg},
+			},
+			{
+				{Text: lbText, CallbackData: constants.SettingsWeightUnitLb},
+			},
+			{
+				{Text: "🔙 Назад", CallbackData: constants.MainBackToMain},
+			},
+		},
+	}
+}
+
+func SettingsOk() *tg_models.InlineKeyboardMarkup {
+	return &tg_models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]tg_models.InlineKeyboardButton{
+			GetOkButton(constants.SettingsMenu, types.NewEmptyParams()),
+		},
+	}
+}
@@ -16,16 +16,19 @@ func UserMeasureMenu(measureId uint) *tg_models.InlineKeyboardMarkup {
 	return &tg_models.InlineKeyboardMarkup{
 		InlineKeyboard: [][]tg_models.InlineKeyboardButton{
 			{
-				{Text: "🚀 Переглянути результати заміру", CallbackData: bot_utils.AddParamsToQueryString(constants.UserMeasureResult, params)},
+				{Text: "🚀 Переглянути результати заміру", CallbackData: bot_utils.EncodeCallbackData(constants.UserMeasureResult, params)},
 			},
 			{
-				{Text: "➕️ Внести результат заміру", CallbackData: bot_utils.AddParamsToQueryString(constants.UserMeasureAdd, params)},
+				{Text: "📈 Графік заміру", CallbackData: bot_utils.EncodeCallbackData(constants.UserMeasureChart, params)},
 			},
 			{
-				{Text: "➖ Видалити останній результат заміру", CallbackData: bot_utils.AddParamsToQueryString(constants.UserMeasureDelete, params)},
+				{Text: "➕️ Внести результат заміру", CallbackData: bot_utils.EncodeCallbackData(constants.UserMeasureAdd, params)},
 			},
 			{
-				{Text: "🔙 Назад", CallbackData: bot_utils.AddParamsToQueryString(constants.UserMeasureList, params)},
+				{Text: "➖ Видалити останній результат заміру", CallbackData: bot_utils.EncodeCallbackData(constants.UserMeasureDelete, params)},
+			},
+			{
+				{Text: "🔙 Назад", CallbackData: bot_utils.EncodeCallbackData(constants.UserMeasureList, params)},
 			},
 		},
 	}
@@ -55,7 +58,7 @@ func UserMeasuresList(measures []models.Measure, totalMeasureCount int64, limit,
 		measuresKb = append(measuresKb, []tg_models.InlineKeyboardButton{
 			{
 				Text:         measure.Name,
-				CallbackData: bot_utils.AddParamsToQueryString(constants.UserMeasureSelected, params),
+				CallbackData: bot_utils.EncodeCallbackData(constants.UserMeasureSelected, params),
 			},
 		})
 	}
@@ -21,7 +21,7 @@ func UserProgramList(programs []models.UserProgram, totalProgramCount int64, lim
 		programKb = append(programKb, []tg_models.InlineKeyboardButton{
 			{
 				Text:         program.Name(),
-				CallbackData: bot_utils.AddParamsToQueryString(constants.UserProgramSelected, params),
+				CallbackData: bot_utils.EncodeCallbackData(constants.UserProgramSelected, params),
 			},
 		})
 	}
@@ -54,10 +54,13 @@ func UserProgramMenu(userProgram models.UserProgram) *tg_models.InlineKeyboardMa
 	return &tg_models.InlineKeyboardMarkup{
 		InlineKeyboard: [][]tg_models.InlineKeyboardButton{
 			{
-				{Text: "🚀 Переглянути результати", CallbackData: bot_utils.AddParamsToQueryString(constants.UserResultList, params)},
+				{Text: "🚀 Переглянути результати", CallbackData: bot_utils.EncodeCallbackData(constants.UserResultList, params)},
 			},
 			{
-				{Text: "✍️ Внести результати", CallbackData: bot_utils.AddParamsToQueryString(constants.UserResultExerciseList, params)},
+				{Text: "✍️ Внести результати", CallbackData: bot_utils.EncodeCallbackData(constants.UserResultExerciseList, params)},
+			},
+			{
+				{Text: "🏋️ Почати тренування", CallbackData: bot_utils.EncodeCallbackData(constants.UserWorkoutStart, params)},
 			},
 			{
 				{Text: "🔙 Назад", CallbackData: constants.MainBackToMain},
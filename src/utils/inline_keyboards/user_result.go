@@ -22,7 +22,7 @@ func UserResultExerciseList(userProgramId uint, exercises []models.Exercise, tot
 		exerciseKb = append(exerciseKb, []tg_models.InlineKeyboardButton{
 			{
 				Text:         exercise.Name,
-				CallbackData: bot_utils.AddParamsToQueryString(constants.UserResultExerciseSelected, params),
+				CallbackData: bot_utils.EncodeCallbackData(constants.UserResultExerciseSelected, params),
 			},
 		})
 	}
@@ -65,7 +65,11 @@ func UserResultExerciseSelectedOk(records []models.UserResult) *tg_models.Inline
 		recordsKb = append(recordsKb, []tg_models.InlineKeyboardButton{
 			{
 				Text:         fmt.Sprintf("%d повторень", record.Reps),
-				CallbackData: bot_utils.AddParamsToQueryString(constants.UserResultExerciseReps, params),
+				CallbackData: bot_utils.EncodeCallbackData(constants.UserResultExerciseReps, params),
+			},
+			{
+				Text:         "📜 Історія",
+				CallbackData: bot_utils.EncodeCallbackData(constants.UserResultHistory, params),
 			},
 		})
 	}
@@ -74,7 +78,54 @@ func UserResultExerciseSelectedOk(records []models.UserResult) *tg_models.Inline
 	backParams.UserProgramId = records[0].UserProgramId
 	backParams.ExerciseId = records[0].ExerciseId
 
+	recordsKb = append(recordsKb, []tg_models.InlineKeyboardButton{
+		{Text: "📈 Графік прогресу", CallbackData: bot_utils.EncodeCallbackData(constants.UserResultChart, backParams)},
+	})
+
 	return &tg_models.InlineKeyboardMarkup{
 		InlineKeyboard: append(recordsKb, GetBackButton(constants.UserResultExerciseList, backParams)),
 	}
 }
+
+func UserResultHistory(record models.UserResult, historyLen int, totalHistoryCount int64, limit, offset int) *tg_models.InlineKeyboardMarkup {
+	nextParams := types.NewEmptyParams()
+	nextParams.UserProgramId = record.UserProgramId
+	nextParams.ExerciseId = record.ExerciseId
+	nextParams.UserResultId = record.Id
+
+	previousParams := types.NewEmptyParams()
+	previousParams.UserProgramId = record.UserProgramId
+	previousParams.ExerciseId = record.ExerciseId
+	previousParams.UserResultId = record.Id
+
+	backParams := types.NewEmptyParams()
+	backParams.UserProgramId = record.UserProgramId
+	backParams.ExerciseId = record.ExerciseId
+
+	return &tg_models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]tg_models.InlineKeyboardButton{
+			GetPaginationButtons(
+				historyLen,
+				totalHistoryCount,
+				constants.UserResultHistory,
+				limit,
+				offset,
+				nextParams,
+				previousParams,
+			),
+			GetBackButton(constants.UserResultExerciseSelected, backParams),
+		},
+	}
+}
+
+func UserResultChartOk(userProgramId, exerciseId uint) *tg_models.InlineKeyboardMarkup {
+	params := types.NewEmptyParams()
+	params.UserProgramId = userProgramId
+	params.ExerciseId = exerciseId
+
+	return &tg_models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]tg_models.InlineKeyboardButton{
+			GetOkButton(constants.UserResultExerciseSelected, params),
+		},
+	}
+}
@@ -0,0 +1,44 @@
+package inline_keyboards
+
+import (
+	tg_models "github.com/go-telegram/bot/models"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/types"
+	bot_utils "rezvin-pro-bot/src/utils/bot"
+)
+
+func WizardStep(canGoBack bool) *tg_models.InlineKeyboardMarkup {
+	kb := make([][]tg_models.InlineKeyboardButton, 0, 2)
+
+	if canGoBack {
+		kb = append(kb, wizardBackButton())
+	}
+
+	return &tg_models.InlineKeyboardMarkup{
+		InlineKeyboard: append(kb, wizardCancelButton()),
+	}
+}
+
+func WizardConfirm() *tg_models.InlineKeyboardMarkup {
+	return &tg_models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]tg_models.InlineKeyboardButton{
+			{
+				{Text: "✅ Підтвердити", CallbackData: bot_utils.EncodeCallbackData(constants.WizardConfirm, types.NewEmptyParams())},
+			},
+			wizardBackButton(),
+			wizardCancelButton(),
+		},
+	}
+}
+
+func wizardBackButton() []tg_models.InlineKeyboardButton {
+	return []tg_models.InlineKeyboardButton{
+		{Text: "⬅️ Назад", CallbackData: bot_utils.EncodeCallbackData(constants.WizardBack, types.NewEmptyParams())},
+	}
+}
+
+func wizardCancelButton() []tg_models.InlineKeyboardButton {
+	return []tg_models.InlineKeyboardButton{
+		{Text: "✖️ Скасувати", CallbackData: bot_utils.EncodeCallbackData(constants.WizardCancel, types.NewEmptyParams())},
+	}
+}
@@ -0,0 +1,169 @@
+package inline_keyboards
+
+import (
+	"fmt"
+	tg_models "github.com/go-telegram/bot/models"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/models"
+	"rezvin-pro-bot/src/types"
+	bot_utils "rezvin-pro-bot/src/utils/bot"
+)
+
+func UserWorkoutExercise(session models.WorkoutSession, hasSets, isLast bool) *tg_models.InlineKeyboardMarkup {
+	params := types.NewEmptyParams()
+	params.WorkoutSessionId = session.Id
+
+	kb := [][]tg_models.InlineKeyboardButton{
+		{
+			{Text: "➕ Додати підхід", CallbackData: bot_utils.EncodeCallbackData(constants.UserWorkoutAddSet, params)},
+		},
+	}
+
+	if hasSets {
+		restKb := make([]tg_models.InlineKeyboardButton, 0, len(constants.RestDurations))
+
+		for _, seconds := range constants.RestDurations {
+			restParams := types.NewEmptyParams()
+			restParams.WorkoutSessionId = session.Id
+			restParams.RestSeconds = seconds
+
+			restKb = append(restKb, tg_models.InlineKeyboardButton{
+				Text:         fmt.Sprintf("⏱️ %d с", seconds),
+				CallbackData: bot_utils.EncodeCallbackData(constants.UserWorkoutRest, restParams),
+			})
+		}
+
+		kb = append(kb, restKb)
+	}
+
+	if !isLast {
+		nextText := "⏭️ Пропустити вправу"
+
+		if hasSets {
+			nextText = "➡️ Наступна вправа"
+		}
+
+		kb = append(kb, []tg_models.InlineKeyboardButton{
+			{Text: nextText, CallbackData: bot_utils.EncodeCallbackData(constants.UserWorkoutNext, params)},
+		})
+	}
+
+	backParams := types.NewEmptyParams()
+	backParams.UserProgramId = session.UserProgramId
+
+	kb = append(kb,
+		[]tg_models.InlineKeyboardButton{
+			{Text: "🏁 Завершити тренування", CallbackData: bot_utils.EncodeCallbackData(constants.UserWorkoutFinish, params)},
+		},
+		[]tg_models.InlineKeyboardButton{
+			{Text: "❌ Скасувати тренування", CallbackData: bot_utils.EncodeCallbackData(constants.UserWorkoutCancel, params)},
+		},
+		GetBackButton(constants.UserProgramSelected, backParams),
+	)
+
+	return &tg_models.InlineKeyboardMarkup{
+		InlineKeyboard: kb,
+	}
+}
+
+func UserWorkoutRestStarted(sessionId uint) *tg_models.InlineKeyboardMarkup {
+	params := types.NewEmptyParams()
+	params.WorkoutSessionId = sessionId
+
+	return &tg_models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]tg_models.InlineKeyboardButton{
+			{
+				{Text: "⏹️ Зупинити таймер", CallbackData: bot_utils.EncodeCallbackData(constants.UserWorkoutRestCancel, params)},
+			},
+		},
+	}
+}
+
+func UserWorkoutResume(sessionId uint) *tg_models.InlineKeyboardMarkup {
+	params := types.NewEmptyParams()
+	params.WorkoutSessionId = sessionId
+
+	return &tg_models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]tg_models.InlineKeyboardButton{
+			{
+				{Text: "💪 Продовжити тренування", CallbackData: bot_utils.EncodeCallbackData(constants.UserWorkoutResume, params)},
+			},
+		},
+	}
+}
+
+func UserWorkoutActive(session models.WorkoutSession, userProgramId uint) *tg_models.InlineKeyboardMarkup {
+	params := types.NewEmptyParams()
+	params.WorkoutSessionId = session.Id
+
+	backParams := types.NewEmptyParams()
+	backParams.UserProgramId = userProgramId
+
+	return &tg_models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]tg_models.InlineKeyboardButton{
+			{
+				{Text: "💪 Продовжити тренування", CallbackData: bot_utils.EncodeCallbackData(constants.UserWorkoutResume, params)},
+			},
+			{
+				{Text: "🏁 Завершити тренування", CallbackData: bot_utils.EncodeCallbackData(constants.UserWorkoutFinish, params)},
+			},
+			{
+				{Text: "❌ Скасувати тренування", CallbackData: bot_utils.EncodeCallbackData(constants.UserWorkoutCancel, params)},
+			},
+			GetBackButton(constants.UserProgramSelected, backParams),
+		},
+	}
+}
+
+func ClientWorkoutList(clientId int64, sessions []models.WorkoutSession, totalSessionCount int64, limit, offset int) *tg_models.InlineKeyboardMarkup {
+	sessionsLen := len(sessions)
+	sessionKb := make([][]tg_models.InlineKeyboardButton, 0, sessionsLen)
+
+	for _, session := range sessions {
+		params := types.NewEmptyParams()
+
+		params.UserId = clientId
+		params.WorkoutSessionId = session.Id
+
+		sessionKb = append(sessionKb, []tg_models.InlineKeyboardButton{
+			{
+				Text:         fmt.Sprintf("%s - %s", session.StartedAt.Format("2006-01-02 15:04"), session.Name()),
+				CallbackData: bot_utils.EncodeCallbackData(constants.ClientWorkoutSelected, params),
+			},
+		})
+	}
+
+	nextParams := types.NewEmptyParams()
+	nextParams.UserId = clientId
+
+	previousParams := types.NewEmptyParams()
+	previousParams.UserId = clientId
+
+	sessionKb = append(sessionKb, GetPaginationButtons(
+		sessionsLen,
+		totalSessionCount,
+		constants.ClientWorkoutList,
+		limit,
+		offset,
+		nextParams,
+		previousParams,
+	))
+
+	backParams := types.NewEmptyParams()
+	backParams.UserId = clientId
+
+	return &tg_models.InlineKeyboardMarkup{
+		InlineKeyboard: append(sessionKb, GetBackButton(constants.ClientSelected, backParams)),
+	}
+}
+
+func ClientWorkoutSelectedOk(clientId int64) *tg_models.InlineKeyboardMarkup {
+	params := types.NewEmptyParams()
+	params.UserId = clientId
+
+	return &tg_models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]tg_models.InlineKeyboardButton{
+			GetOkButton(constants.ClientWorkoutList, params),
+		},
+	}
+}
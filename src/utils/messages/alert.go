@@ -0,0 +1,98 @@
+package messages
+
+import (
+	"fmt"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/globals"
+	"rezvin-pro-bot/src/types"
+	"rezvin-pro-bot/src/utils"
+	"slices"
+	"strings"
+)
+
+var alertKindTitles = map[constants.AlertKind]string{
+	constants.PanicAlertKind:      "Паніка",
+	constants.RepositoryAlertKind: "Помилка бази даних",
+	constants.TelegramAlertKind:   "Помилка відправки в Telegram",
+}
+
+func AlertMessage(kind constants.AlertKind, errText string, fields map[string]any, stack string) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("🚨 *%s* \\- бот %s\n", alertKindTitles[kind], utils.EscapeMarkdown(globals.AdminName)))
+	sb.WriteString(utils.EscapeMarkdown(truncateRunes(errText, constants.AlertErrorMaxLength)))
+	sb.WriteString("\n")
+
+	keys := make([]string, 0, len(fields))
+
+	for key := range fields {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		sb.WriteString(fmt.Sprintf("%s\\: %s\n", utils.EscapeMarkdown(key), utils.EscapeMarkdown(fmt.Sprint(fields[key]))))
+	}
+
+	if stack == "" {
+		return sb.String()
+	}
+
+	escapes := strings.Count(stack, "\\") + strings.Count(stack, "`")
+	limit := constants.AlertMessageMaxLength - len([]rune(sb.String())) - len("```\n```") - escapes - 1
+
+	sb.WriteString("```\n")
+	sb.WriteString(escapeCode(truncateRunes(stack, limit)))
+	sb.WriteString("```")
+
+	return sb.String()
+}
+
+func AlertDigestMessage(entries []types.AlertDigestEntry, dropped int) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("📋 *Зведення помилок* \\- бот %s\n", utils.EscapeMarkdown(globals.AdminName)))
+	sb.WriteString("Приглушені повторні помилки\\:\n")
+
+	for i, entry := range entries {
+		line := fmt.Sprintf(
+			"• %s ×%d\\: %s\n",
+			alertKindTitles[entry.Kind],
+			entry.Suppressed,
+			utils.EscapeMarkdown(truncateRunes(entry.Message, constants.AlertErrorMaxLength)),
+		)
+
+		// Keep some room for the trailing lines below.
+		if len([]rune(sb.String()))+len([]rune(line)) > constants.AlertMessageMaxLength-200 {
+			sb.WriteString(fmt.Sprintf("…та ще %d\n", len(entries)-i))
+			break
+		}
+
+		sb.WriteString(line)
+	}
+
+	if dropped > 0 {
+		sb.WriteString(fmt.Sprintf("Не вдалося поставити в чергу\\: %d\n", dropped))
+	}
+
+	return sb.String()
+}
+
+func truncateRunes(text string, limit int) string {
+	runes := []rune(text)
+
+	if limit < 0 {
+		limit = 0
+	}
+
+	if len(runes) <= limit {
+		return text
+	}
+
+	return string(runes[:limit]) + "…"
+}
+
+func escapeCode(text string) string {
+	return strings.NewReplacer("\\", "\\\\", "`", "\\`").Replace(text)
+}
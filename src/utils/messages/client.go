@@ -24,3 +24,13 @@ func ClientResultNotFoundMessage(id uint) string {
 func NoRecordsForClientProgramMessage(name, programName string) string {
 	return fmt.Sprintf("Записів не знайдено для програми \"*%s*\" клієнта \"*%s*\"\\", utils.EscapeMarkdown(programName), utils.EscapeMarkdown(name))
 }
+
+func ClientExportMessage(name string, programsCount, resultsCount, measuresCount int) string {
+	return fmt.Sprintf(
+		"📤 Дані клієнта \"*%s*\"\\: програм \\- %d, результатів \\- %d, замірів \\- %d\\.",
+		utils.EscapeMarkdown(name),
+		programsCount,
+		resultsCount,
+		measuresCount,
+	)
+}
@@ -66,3 +66,12 @@ func ClientMeasureResultMessage(name string, measure models.Measure, userMeasure
 
 	return sb.String()
 }
+
+func ClientMeasureChartMessage(name string, measure models.Measure) string {
+	return fmt.Sprintf(
+		"📈 Графік заміру \"*%s*\" \\(%s\\) клієнта \"*%s*\"\\.",
+		utils.EscapeMarkdown(measure.Name),
+		utils.EscapeMarkdown(measure.Units),
+		utils.EscapeMarkdown(name),
+	)
+}
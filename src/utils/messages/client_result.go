@@ -2,13 +2,19 @@ package messages
 
 import (
 	"fmt"
+	"rezvin-pro-bot/src/constants"
 	"rezvin-pro-bot/src/models"
 	"rezvin-pro-bot/src/utils"
-	"sort"
+	"rezvin-pro-bot/src/utils/units"
 	"strings"
 )
 
-func ClientProgramResultsMessage(name, programName string, records []models.UserResult) string {
+func ClientProgramResultsMessage(
+	name, programName string,
+	formula constants.OneRepMaxFormula,
+	records []models.UserResult,
+	unit constants.WeightUnit,
+) string {
 	var sb strings.Builder
 
 	sb.WriteString(fmt.Sprintf("Результати програми \"*%s*\" клієнта \"*%s*\"\\:", utils.EscapeMarkdown(programName), utils.EscapeMarkdown(name)))
@@ -21,23 +27,17 @@ func ClientProgramResultsMessage(name, programName string, records []models.User
 		groupByName[record.Exercise.Name] = append(groupByName[record.Exercise.Name], record)
 	}
 
-	sortedIds := make([]int, 0, len(groupById))
-
-	for id := range groupById {
-		sortedIds = append(sortedIds, int(id))
-	}
-
-	sort.Sort(sort.IntSlice(sortedIds))
-
-	for _, id := range sortedIds {
-		name := groupById[uint(id)]
+	for _, id := range sortedExerciseIds(records) {
+		name := groupById[id]
 		records := groupByName[name]
 
 		sb.WriteString(fmt.Sprintf("\n\n*%s*\\:", utils.EscapeMarkdown(name)))
 
 		for _, record := range records {
-			sb.WriteString(fmt.Sprintf("\n %d повторень \\- %d кг", record.Reps, record.Weight))
+			sb.WriteString(fmt.Sprintf("\n %d повторень \\- %s", record.Reps, weightText(record.Weight, unit)))
 		}
+
+		sb.WriteString(oneRepMaxLine(formula, records, unit))
 	}
 
 	return sb.String()
@@ -51,8 +51,23 @@ func ClientProgramResultsSelectExerciseMessage(name, programName string) string
 	return fmt.Sprintf("Вибери вправу з програми \"*%s*\" клієнта \"*%s*\"\\, які ти хочеш відредагувати:", utils.EscapeMarkdown(programName), utils.EscapeMarkdown(name))
 }
 
-func EnterClientResultMessage(name, exerciseName string) string {
-	return fmt.Sprintf("Введи результат для вправи \"*%s*\" клієнта \"*%s*\"\\:", utils.EscapeMarkdown(exerciseName), utils.EscapeMarkdown(name))
+func EnterClientResultMessage(name, exerciseName string, unit constants.WeightUnit) string {
+	return fmt.Sprintf(
+		"Введи результат для вправи \"*%s*\" клієнта \"*%s*\" в %s\\:",
+		utils.EscapeMarkdown(exerciseName),
+		utils.EscapeMarkdown(name),
+		units.UnitName(unit),
+	)
+}
+
+func ClientResultSummaryMessage(name, exerciseName string, reps uint, weight string) string {
+	return fmt.Sprintf(
+		"Перевір результат вправи \"*%s*\" клієнта \"*%s*\" на %d повторень\\: *%s*",
+		utils.EscapeMarkdown(exerciseName),
+		utils.EscapeMarkdown(name),
+		reps,
+		utils.EscapeMarkdown(weight),
+	)
 }
 
 func ClientProgramResultModifiedMessage(name, exerciseName string, reps uint) string {
@@ -60,5 +75,72 @@ func ClientProgramResultModifiedMessage(name, exerciseName string, reps uint) st
 }
 
 func ClientProgramResultExerciseSelectedMessage(name, exerciseName string) string {
-	return fmt.Sprintf("Вибери кількість повторень вправи \"*%s*\" ,результат яких потрібно змінити для клієнта \"*%s*\"\\.", utils.EscapeMarkdown(exerciseName), utils.EscapeMarkdown(name))
+	return fmt.Sprintf("Вибери кількість повторень вправи \"*%s*\" ,результат яких потрібно змінити для клієнта \"*%s*\", або переглянь історію результатів\\.", utils.EscapeMarkdown(exerciseName), utils.EscapeMarkdown(name))
+}
+
+func NoClientResultHistoryMessage(name, exerciseName string, reps uint) string {
+	return fmt.Sprintf("Історії результатів вправи \"*%s*\" на %d повторень клієнта \"*%s*\" ще немає\\.", utils.EscapeMarkdown(exerciseName), reps, utils.EscapeMarkdown(name))
+}
+
+func ClientResultHistoryMessage(
+	name, exerciseName string,
+	reps uint,
+	entries []models.UserResultHistory,
+	offset int,
+	unit constants.WeightUnit,
+) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf(
+		"Історія результатів вправи \"*%s*\" на %d повторень клієнта \"*%s*\"\\:\n",
+		utils.EscapeMarkdown(exerciseName),
+		reps,
+		utils.EscapeMarkdown(name),
+	))
+
+	for i, entry := range entries {
+		sb.WriteString(fmt.Sprintf(
+			"%d\\. %s \\- %s \\(%s\\)%s\n",
+			offset+i+1,
+			utils.EscapeMarkdown(entry.LoggedAt.Format("2006-01-02 15:04:05")),
+			weightText(entry.Weight, unit),
+			resultSourceName(entry),
+			recordMark(entry),
+		))
+	}
+
+	return sb.String()
+}
+
+func ClientPersonalRecordMessage(
+	name, exerciseName string,
+	reps uint,
+	weight, previousBest float64,
+	unit constants.WeightUnit,
+) string {
+	return fmt.Sprintf(
+		"🏆 Клієнт \"*%s*\" встановив новий особистий рекорд\\! Вправа \"*%s*\" на %d повторень \\- %s \\(попередній рекорд %s\\)\\.",
+		utils.EscapeMarkdown(name),
+		utils.EscapeMarkdown(exerciseName),
+		reps,
+		weightText(weight, unit),
+		weightText(previousBest, unit),
+	)
+}
+
+func NoClientResultChartMessage(name, exerciseName string) string {
+	return fmt.Sprintf(
+		"Для вправи \"*%s*\" клієнта \"*%s*\" ще немає результатів для графіка\\.",
+		utils.EscapeMarkdown(exerciseName),
+		utils.EscapeMarkdown(name),
+	)
+}
+
+func ClientResultChartMessage(name, exerciseName string, unit constants.WeightUnit) string {
+	return fmt.Sprintf(
+		"📈 Прогрес клієнта \"*%s*\" у вправі \"*%s*\"\\. Вага в %s для кожної кількості повторень\\.",
+		utils.EscapeMarkdown(name),
+		utils.EscapeMarkdown(exerciseName),
+		unitText(unit),
+	)
 }
@@ -1,5 +1,14 @@
 package messages
 
+import (
+	"fmt"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/models"
+	"rezvin-pro-bot/src/utils"
+	"rezvin-pro-bot/src/utils/strength"
+	"rezvin-pro-bot/src/utils/units"
+)
+
 func PressStartMessage() string {
 	return "Введи /start, щоб почати роботу\\."
 }
@@ -8,10 +17,26 @@ func ErrorMessage() string {
 	return "Виникла помилка\\. Спробуйте пізніше ще раз\\."
 }
 
+func NotFoundErrorMessage() string {
+	return "Запис не знайдено\\. Можливо, його вже видалено\\."
+}
+
+func ConflictErrorMessage() string {
+	return "Такий запис вже існує або пов'язаний з іншими даними\\."
+}
+
+func TransientErrorMessage() string {
+	return "База даних тимчасово недоступна\\. Спробуйте ще раз за хвилину\\."
+}
+
 func ParamsErrorMessage(err error) string {
 	return "Виникла помилка\\. Спробуйте пізніше ще раз\\. Повідомлення для розробників: " + err.Error()
 }
 
+func MenuExpiredMessage() string {
+	return "Це меню застаріло\\. Відкрийте головне меню та спробуйте ще раз\\."
+}
+
 func RequestTimeoutMessage() string {
 	return "Час відповіді на запит вичерпано\\. Спробуйте ще раз\\."
 }
@@ -27,3 +52,54 @@ func EmptyMessage() string {
 func AdminOnlyMessage() string {
 	return "Ця дія доступна тільки адміністраторам\\."
 }
+
+func resultSourceName(entry models.UserResultHistory) string {
+	if entry.IsEnteredByTrainer() {
+		return "вніс тренер"
+	}
+
+	return "вніс клієнт"
+}
+
+func recordMark(entry models.UserResultHistory) string {
+	if entry.IsRecord {
+		return " 🏆"
+	}
+
+	return ""
+}
+
+func oneRepMaxFormulaName(formula constants.OneRepMaxFormula) string {
+	switch formula {
+	case constants.EpleyFormula:
+		return "Еплі"
+	case constants.BrzyckiFormula:
+		return "Бжицкі"
+	case constants.LombardiFormula:
+		return "Ломбарді"
+	default:
+		return "найкраща оцінка"
+	}
+}
+
+func oneRepMaxLine(formula constants.OneRepMaxFormula, records []models.UserResult, unit constants.WeightUnit) string {
+	estimate := strength.EstimateFromResults(formula, records)
+
+	if estimate == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(
+		"\n 📈 1ПМ \\(%s\\) \\- %s",
+		oneRepMaxFormulaName(formula),
+		weightText(units.Round(estimate, 1), unit),
+	)
+}
+
+func weightText(kg float64, unit constants.WeightUnit) string {
+	return utils.EscapeMarkdown(units.FormatWeight(kg, unit))
+}
+
+func unitText(unit constants.WeightUnit) string {
+	return utils.EscapeMarkdown(units.UnitName(unit))
+}
@@ -0,0 +1,40 @@
+package messages
+
+import (
+	"fmt"
+	"rezvin-pro-bot/src/models"
+	"rezvin-pro-bot/src/utils"
+	"strings"
+)
+
+const deadLetterErrorMaxLength = 200
+
+func NoDeadLettersMessage() string {
+	return "Невдалих повідомлень немає\\."
+}
+
+func DeadLettersMessage(deadLetters []models.DeadLetter, offset int) string {
+	var sb strings.Builder
+
+	sb.WriteString("Повідомлення, які не вдалося доставити\\:\n")
+
+	for i, deadLetter := range deadLetters {
+		errText := []rune(deadLetter.Error)
+
+		if len(errText) > deadLetterErrorMaxLength {
+			errText = append(errText[:deadLetterErrorMaxLength], '…')
+		}
+
+		sb.WriteString(fmt.Sprintf(
+			"%d\\. %s \\- чат %s, %s, спроб\\: %d\n%s\n",
+			offset+i+1,
+			utils.EscapeMarkdown(deadLetter.CreatedAt.Format("2006-01-02 15:04:05")),
+			utils.EscapeMarkdown(fmt.Sprintf("%d", deadLetter.ChatId)),
+			utils.EscapeMarkdown(deadLetter.Method),
+			deadLetter.Attempts,
+			utils.EscapeMarkdown(string(errText)),
+		))
+	}
+
+	return sb.String()
+}
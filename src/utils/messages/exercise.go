@@ -20,12 +20,20 @@ func ExerciseSuccessfullyAddedMessage(exerciseName, programName string) string {
 	return fmt.Sprintf("Вправа \"*%s*\" успішно додана до програми \"*%s*\" \\.", utils.EscapeMarkdown(exerciseName), utils.EscapeMarkdown(programName))
 }
 
+func ExerciseAddSummaryMessage(exerciseName, programName string) string {
+	return fmt.Sprintf(
+		"Перевір нову вправу програми \"*%s*\"\\:\nНазва\\: *%s*",
+		utils.EscapeMarkdown(programName),
+		utils.EscapeMarkdown(exerciseName),
+	)
+}
+
 func NoExercisesMessage(programName string) string {
 	return fmt.Sprintf("Вправ не знайдено в програмі \"*%s*\"\\. Додай нову вправу і повтори спробу\\.", utils.EscapeMarkdown(programName))
 }
 
 func ExerciseNotFoundMessage(exerciseId uint) string {
-	return fmt.Sprintf("Вправа з id \"*%s*\" не знайдена\\.", exerciseId)
+	return fmt.Sprintf("Вправа з id \"*%d*\" не знайдена\\.", exerciseId)
 }
 
 func ExercisesMessage(programName string, exercises []models.Exercise) string {
@@ -49,3 +57,23 @@ func ExerciseDeleteMessage(programName string) string {
 func ExerciseSuccessfullyDeletedMessage(exerciseName, programName string) string {
 	return fmt.Sprintf("Вправа \"*%s*\" успішно видалена з програми \"*%s*\"\\.", utils.EscapeMarkdown(exerciseName), utils.EscapeMarkdown(programName))
 }
+
+func ExerciseRepSchemeSelectMessage(programName string) string {
+	return fmt.Sprintf("Вибери вправу програми \"*%s*\", для якої потрібно змінити схему повторень\\.", utils.EscapeMarkdown(programName))
+}
+
+func EnterExerciseRepSchemeMessage(exerciseName, repScheme string) string {
+	return fmt.Sprintf(
+		"Введи схему повторень для вправи \"*%s*\" через кому, наприклад 15,20, або \"\\-\", щоб використовувати схему програми\\. Поточна схема\\: *%s*\\.",
+		utils.EscapeMarkdown(exerciseName),
+		utils.EscapeMarkdown(repScheme),
+	)
+}
+
+func ExerciseRepSchemeChangedMessage(exerciseName, repScheme string) string {
+	return fmt.Sprintf(
+		"Схему повторень вправи \"*%s*\" змінено на *%s*\\. Результати клієнтів оновлено\\.",
+		utils.EscapeMarkdown(exerciseName),
+		utils.EscapeMarkdown(repScheme),
+	)
+}
@@ -0,0 +1,122 @@
+package messages
+
+import (
+	"fmt"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/utils"
+	"rezvin-pro-bot/src/utils/importer"
+	"strings"
+)
+
+func ImportInstructionsMessage() string {
+	var sb strings.Builder
+
+	sb.WriteString("📥 Надішли файл YAML, JSON або CSV з програмами та вправами\\.\n")
+	sb.WriteString("Порядок вправ у файлі зберігається, вправи програми, яких немає у файлі, переміщуються в кінець\\. ")
+	sb.WriteString("Існуючі програми та вправи не видаляються, додаються лише нові вправи та змінюються схеми повторень\\.\n\n")
+	sb.WriteString("Приклад YAML\\:\n")
+	sb.WriteString("```yaml\n")
+	sb.WriteString("programs:\n")
+	sb.WriteString("  - name: Push\n")
+	sb.WriteString("    repScheme: 6,8,10\n")
+	sb.WriteString("    exercises:\n")
+	sb.WriteString("      - name: Bench press\n")
+	sb.WriteString("      - name: Dips\n")
+	sb.WriteString("        repScheme: 10,12\n")
+	sb.WriteString("```\n")
+	sb.WriteString("JSON має таку саму структуру\\. CSV має містити заголовок\\:\n")
+	sb.WriteString("```csv\n")
+	sb.WriteString("program,program_rep_scheme,exercise,exercise_rep_scheme\n")
+	sb.WriteString("```")
+
+	return sb.String()
+}
+
+func ImportFileTooLargeMessage() string {
+	return fmt.Sprintf("Файл завеликий\\. Максимальний розмір \\- %d КБ\\.", constants.MaxImportFileSize/1024)
+}
+
+func ImportErrorMessage(err error) string {
+	return fmt.Sprintf("Файл не імпортовано\\: %s\\.", utils.EscapeMarkdown(err.Error()))
+}
+
+func ImportNothingToChangeMessage() string {
+	return "Усі програми та вправи з файлу вже існують\\. Змін немає\\."
+}
+
+func ImportNotFoundMessage() string {
+	return "Немає імпорту, що очікує підтвердження\\. Надішли файл ще раз\\."
+}
+
+func ImportCancelledMessage() string {
+	return "Імпорт скасовано\\."
+}
+
+func ImportPreviewMessage(plan importer.Plan) string {
+	return "Перевір зміни перед імпортом\\:\n\n" + importPlanText(plan)
+}
+
+func ImportAppliedMessage(plan importer.Plan) string {
+	return "✅ Імпорт завершено\\.\n\n" + importPlanText(plan)
+}
+
+func importPlanText(plan importer.Plan) string {
+	var sb strings.Builder
+
+	for _, program := range plan.Programs {
+		switch {
+		case program.IsNew():
+			sb.WriteString(fmt.Sprintf("➕ Нова програма *%s*%s\n", utils.EscapeMarkdown(program.Name), repSchemeText(program.RepScheme)))
+		case program.RepSchemeChanged():
+			sb.WriteString(fmt.Sprintf(
+				"✏️ Програма *%s*\\: схема %s → %s\n",
+				utils.EscapeMarkdown(program.Name),
+				utils.EscapeMarkdown(program.OldRepScheme),
+				utils.EscapeMarkdown(program.RepScheme),
+			))
+		default:
+			sb.WriteString(fmt.Sprintf("📖 Програма *%s*\n", utils.EscapeMarkdown(program.Name)))
+		}
+
+		for _, exercise := range program.Exercises {
+			if exercise.IsNew() {
+				sb.WriteString(fmt.Sprintf("    ➕ %s%s\n", utils.EscapeMarkdown(exercise.Name), repSchemeText(exercise.RepScheme)))
+				continue
+			}
+
+			if exercise.RepSchemeChanged() {
+				oldRepScheme := exercise.OldRepScheme
+
+				if oldRepScheme == "" {
+					oldRepScheme = "як у програмі"
+				}
+
+				sb.WriteString(fmt.Sprintf(
+					"    ✏️ %s\\: схема %s → %s\n",
+					utils.EscapeMarkdown(exercise.Name),
+					utils.EscapeMarkdown(oldRepScheme),
+					utils.EscapeMarkdown(exercise.RepScheme),
+				))
+			}
+
+			if exercise.PositionChanged() {
+				sb.WriteString(fmt.Sprintf(
+					"    ↕️ %s\\: позиція %d → %d\n",
+					utils.EscapeMarkdown(exercise.Name),
+					exercise.OldPosition,
+					exercise.Position,
+				))
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+func repSchemeText(repScheme string) string {
+	if repScheme == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(" \\(%s\\)", utils.EscapeMarkdown(repScheme))
+}
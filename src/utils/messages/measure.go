@@ -62,3 +62,11 @@ func MeasureUnitsChanged(measureName, oldUnits, newUnits string) string {
 		utils.EscapeMarkdown(newUnits),
 	)
 }
+
+func MeasureAddSummaryMessage(measureName, units string) string {
+	return fmt.Sprintf(
+		"Перевір новий замір\\:\nНазва\\: *%s*\nОдиниці виміру\\: *%s*",
+		utils.EscapeMarkdown(measureName),
+		utils.EscapeMarkdown(units),
+	)
+}
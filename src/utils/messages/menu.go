@@ -16,6 +16,8 @@ func UserMenuMessage(name string) string {
 	sb.WriteString("\\!\n")
 	sb.WriteString("Натисни \"📋 Мої програми\", щоб переглянути свої програми\\.\n")
 	sb.WriteString("Натисни \"⏱️ Заміри\", щоб переглянути свої заміри\\.\n")
+	sb.WriteString("Натисни \"🏆 Мої рекорди\", щоб переглянути свої особисті рекорди\\.\n")
+	sb.WriteString("Натисни \"⚙️ Налаштування\", щоб змінити одиниці ваги\\.\n")
 
 	return sb.String()
 }
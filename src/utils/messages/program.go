@@ -2,6 +2,7 @@ package messages
 
 import (
 	"fmt"
+	"rezvin-pro-bot/src/constants"
 	"rezvin-pro-bot/src/utils"
 )
 
@@ -21,6 +22,10 @@ func ProgramSuccessfullyAddedMessage(programName string) string {
 	return fmt.Sprintf("Програма \"*%s*\" успішно додана\\.", utils.EscapeMarkdown(programName))
 }
 
+func ProgramAddSummaryMessage(programName string) string {
+	return fmt.Sprintf("Перевір нову програму\\:\nНазва\\: *%s*", utils.EscapeMarkdown(programName))
+}
+
 func NoProgramsMessage() string {
 	return "Програм не знайдено\\. Створи нову програму і повтори спробу\\."
 }
@@ -44,3 +49,35 @@ func ProgramSuccessfullyRenamedMessage(oldProgramName, programName string) strin
 func ProgramSuccessfullyDeletedMessage(programName string) string {
 	return fmt.Sprintf("Програма \"*%s*\" успішно видалена\\.", utils.EscapeMarkdown(programName))
 }
+
+func SelectProgramFormulaMessage(programName string, formula constants.OneRepMaxFormula) string {
+	return fmt.Sprintf(
+		"Вибери формулу розрахунку 1ПМ для програми \"*%s*\"\\. Поточна формула\\: *%s*\\.",
+		utils.EscapeMarkdown(programName),
+		oneRepMaxFormulaName(formula),
+	)
+}
+
+func ProgramFormulaChangedMessage(programName string, formula constants.OneRepMaxFormula) string {
+	return fmt.Sprintf(
+		"Формулу розрахунку 1ПМ для програми \"*%s*\" змінено на *%s*\\.",
+		utils.EscapeMarkdown(programName),
+		oneRepMaxFormulaName(formula),
+	)
+}
+
+func EnterProgramRepSchemeMessage(programName, repScheme string) string {
+	return fmt.Sprintf(
+		"Введи схему повторень для програми \"*%s*\" через кому, наприклад 1,3,5\\. Поточна схема\\: *%s*\\.",
+		utils.EscapeMarkdown(programName),
+		utils.EscapeMarkdown(repScheme),
+	)
+}
+
+func ProgramRepSchemeChangedMessage(programName, repScheme string) string {
+	return fmt.Sprintf(
+		"Схему повторень програми \"*%s*\" змінено на *%s*\\. Результати клієнтів оновлено\\.",
+		utils.EscapeMarkdown(programName),
+		utils.EscapeMarkdown(repScheme),
+	)
+}
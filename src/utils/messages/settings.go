@@ -0,0 +1,15 @@
+package messages
+
+import (
+	"fmt"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/utils/units"
+)
+
+func SettingsMenuMessage(unit constants.WeightUnit) string {
+	return fmt.Sprintf("Налаштування\\.\nПоточні одиниці ваги\\: *%s*\\.\nВибери одиниці, в яких вводити та переглядати результати\\:", units.UnitName(unit))
+}
+
+func WeightUnitChangedMessage(unit constants.WeightUnit) string {
+	return fmt.Sprintf("Одиниці ваги змінено на *%s*\\.", units.UnitName(unit))
+}
@@ -54,3 +54,7 @@ func UserMeasureResultMessage(measure models.Measure, userMeasures []models.User
 
 	return sb.String()
 }
+
+func UserMeasureChartMessage(measure models.Measure) string {
+	return fmt.Sprintf("📈 Графік заміру \"*%s*\" \\(%s\\)\\.", utils.EscapeMarkdown(measure.Name), utils.EscapeMarkdown(measure.Units))
+}
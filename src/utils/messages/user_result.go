@@ -1,14 +1,23 @@
 package messages
 
 import (
+	"cmp"
 	"fmt"
+	"maps"
+	"rezvin-pro-bot/src/constants"
 	"rezvin-pro-bot/src/models"
 	"rezvin-pro-bot/src/utils"
-	"sort"
+	"rezvin-pro-bot/src/utils/units"
+	"slices"
 	"strings"
 )
 
-func UserProgramResultsMessage(programName string, records []models.UserResult) string {
+func UserProgramResultsMessage(
+	programName string,
+	formula constants.OneRepMaxFormula,
+	records []models.UserResult,
+	unit constants.WeightUnit,
+) string {
 	var sb strings.Builder
 
 	sb.WriteString(fmt.Sprintf("Результати програми \"*%s*\"\\:", utils.EscapeMarkdown(programName)))
@@ -21,23 +30,17 @@ func UserProgramResultsMessage(programName string, records []models.UserResult)
 		groupByName[record.Exercise.Name] = append(groupByName[record.Exercise.Name], record)
 	}
 
-	sortedIds := make([]int, 0, len(groupById))
-
-	for id := range groupById {
-		sortedIds = append(sortedIds, int(id))
-	}
-
-	sort.Sort(sort.IntSlice(sortedIds))
-
-	for _, id := range sortedIds {
-		name := groupById[uint(id)]
+	for _, id := range sortedExerciseIds(records) {
+		name := groupById[id]
 		records := groupByName[name]
 
 		sb.WriteString(fmt.Sprintf("\n\n*%s*\\:", utils.EscapeMarkdown(name)))
 
 		for _, record := range records {
-			sb.WriteString(fmt.Sprintf("\n %d повторень \\- %d кг", record.Reps, record.Weight))
+			sb.WriteString(fmt.Sprintf("\n %d повторень \\- %s", record.Reps, weightText(record.Weight, unit)))
 		}
+
+		sb.WriteString(oneRepMaxLine(formula, records, unit))
 	}
 
 	return sb.String()
@@ -47,14 +50,124 @@ func UserProgramResultsSelectExerciseMessage(programName string) string {
 	return fmt.Sprintf("Вибери вправу з програми \"*%s*\", яку ти хочеш відредагувати:", utils.EscapeMarkdown(programName))
 }
 
-func EnterUserResultMessage(exerciseName string) string {
-	return fmt.Sprintf("Введи результат для вправи \"*%s*\"\\:", utils.EscapeMarkdown(exerciseName))
+func EnterUserResultMessage(exerciseName string, unit constants.WeightUnit) string {
+	return fmt.Sprintf("Введи результат для вправи \"*%s*\" в %s\\:", utils.EscapeMarkdown(exerciseName), units.UnitName(unit))
 }
 
 func UserProgramResultModifiedMessage(exerciseName string, reps uint) string {
 	return fmt.Sprintf("Результати вправи \"*%s*\"на %d повторень успішно змінено\\.", utils.EscapeMarkdown(exerciseName), reps)
 }
 
+func UserResultSummaryMessage(exerciseName string, reps uint, weight string) string {
+	return fmt.Sprintf(
+		"Перевір результат вправи \"*%s*\" на %d повторень\\: *%s*",
+		utils.EscapeMarkdown(exerciseName),
+		reps,
+		utils.EscapeMarkdown(weight),
+	)
+}
+
 func UserProgramResultExerciseSelectedMessage(exerciseName string) string {
-	return fmt.Sprintf("Вибери кількість повторень вправи \"*%s*\" ,результат яких потрібно змінити \\.", utils.EscapeMarkdown(exerciseName))
+	return fmt.Sprintf("Вибери кількість повторень вправи \"*%s*\" ,результат яких потрібно змінити, або переглянь історію результатів \\.", utils.EscapeMarkdown(exerciseName))
+}
+
+func NoUserResultHistoryMessage(exerciseName string, reps uint) string {
+	return fmt.Sprintf("Історії результатів вправи \"*%s*\" на %d повторень ще немає\\.", utils.EscapeMarkdown(exerciseName), reps)
+}
+
+func UserResultHistoryMessage(
+	exerciseName string,
+	reps uint,
+	entries []models.UserResultHistory,
+	offset int,
+	unit constants.WeightUnit,
+) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Історія результатів вправи \"*%s*\" на %d повторень\\:\n", utils.EscapeMarkdown(exerciseName), reps))
+
+	for i, entry := range entries {
+		sb.WriteString(fmt.Sprintf(
+			"%d\\. %s \\- %s \\(%s\\)%s\n",
+			offset+i+1,
+			utils.EscapeMarkdown(entry.LoggedAt.Format("2006-01-02 15:04:05")),
+			weightText(entry.Weight, unit),
+			resultSourceName(entry),
+			recordMark(entry),
+		))
+	}
+
+	return sb.String()
+}
+
+func UserPersonalRecordMessage(exerciseName string, reps uint, weight, previousBest float64, unit constants.WeightUnit) string {
+	return fmt.Sprintf(
+		"🏆 Вітаю, новий особистий рекорд\\! Вправа \"*%s*\" на %d повторень \\- %s \\(попередній рекорд %s\\)\\.",
+		utils.EscapeMarkdown(exerciseName),
+		reps,
+		weightText(weight, unit),
+		weightText(previousBest, unit),
+	)
+}
+
+func NoUserRecordsMessage() string {
+	return "У тебе ще немає рекордів\\. Внось результати вправ, щоб їх побачити\\."
+}
+
+func UserRecordsMessage(records []models.UserResultHistory, unit constants.WeightUnit) string {
+	var sb strings.Builder
+
+	sb.WriteString("🏆 Твої особисті рекорди\\:")
+
+	lastExerciseId := uint(0)
+
+	for _, record := range records {
+		if record.ExerciseId != lastExerciseId {
+			sb.WriteString(fmt.Sprintf("\n\n*%s*\\:", utils.EscapeMarkdown(record.Name())))
+			lastExerciseId = record.ExerciseId
+		}
+
+		sb.WriteString(fmt.Sprintf(
+			"\n %d повторень \\- %s \\(%s\\)",
+			record.Reps,
+			weightText(record.Weight, unit),
+			utils.EscapeMarkdown(record.LoggedAt.Format("2006-01-02")),
+		))
+	}
+
+	return sb.String()
+}
+
+func NoUserResultChartMessage(exerciseName string) string {
+	return fmt.Sprintf("Для вправи \"*%s*\" ще немає результатів для графіка\\.", utils.EscapeMarkdown(exerciseName))
+}
+
+func UserResultChartMessage(exerciseName string, unit constants.WeightUnit) string {
+	return fmt.Sprintf("📈 Прогрес у вправі \"*%s*\"\\. Вага в %s для кожної кількості повторень\\.", utils.EscapeMarkdown(exerciseName), unitText(unit))
+}
+
+// ResultChartLegend is drawn onto the chart image, so it is plain text rather than MarkdownV2.
+func ResultChartLegend(reps constants.Reps) string {
+	return fmt.Sprintf("%d повторень", reps)
+}
+
+// sortedExerciseIds lists the exercises of the records in program order.
+func sortedExerciseIds(records []models.UserResult) []uint {
+	exercises := make(map[uint]models.Exercise)
+
+	for _, record := range records {
+		exercises[record.ExerciseId] = record.Exercise
+	}
+
+	ids := slices.Collect(maps.Keys(exercises))
+
+	slices.SortFunc(ids, func(a, b uint) int {
+		if c := cmp.Compare(exercises[a].Position, exercises[b].Position); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(a, b)
+	})
+
+	return ids
 }
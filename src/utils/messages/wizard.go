@@ -0,0 +1,22 @@
+package messages
+
+import (
+	"fmt"
+	"rezvin-pro-bot/src/utils"
+)
+
+func WizardCurrentValueMessage(prompt, value string) string {
+	return fmt.Sprintf("%s\nПоточне значення\\: *%s*", prompt, utils.EscapeMarkdown(value))
+}
+
+func WizardConfirmHintMessage() string {
+	return "Підтверди введені дані або зміни їх кнопками під повідомленням\\."
+}
+
+func WizardCancelledMessage() string {
+	return "Дію скасовано\\."
+}
+
+func WizardNotFoundMessage() string {
+	return "Немає активної дії\\."
+}
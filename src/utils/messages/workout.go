@@ -0,0 +1,132 @@
+package messages
+
+import (
+	"fmt"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/models"
+	"rezvin-pro-bot/src/utils"
+	"strings"
+	"time"
+)
+
+func WorkoutSessionNotFoundMessage(id uint) string {
+	return fmt.Sprintf("Тренування з id %d не знайдено\\.", id)
+}
+
+func WorkoutNoExercisesMessage(programName string) string {
+	return fmt.Sprintf("У програмі \"*%s*\" немає вправ\\. Звернись до тренера\\.", utils.EscapeMarkdown(programName))
+}
+
+func WorkoutSessionNotActiveMessage() string {
+	return "Це тренування вже завершене або скасоване\\."
+}
+
+func WorkoutExerciseMessage(
+	programName, exerciseName string,
+	position, total int,
+	sets []models.WorkoutSet,
+	unit constants.WeightUnit,
+) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("🏋️ Тренування за програмою \"*%s*\"\n", utils.EscapeMarkdown(programName)))
+	sb.WriteString(fmt.Sprintf("Вправа %d з %d\\: *%s*\n", position, total, utils.EscapeMarkdown(exerciseName)))
+
+	if len(sets) == 0 {
+		sb.WriteString("\nПідходів ще немає\\. Додай підхід або пропусти вправу\\.")
+		return sb.String()
+	}
+
+	sb.WriteString("\nПідходи\\:")
+
+	for _, set := range sets {
+		sb.WriteString(fmt.Sprintf("\n %d\\. %s", set.SetNumber, setText(set, unit)))
+	}
+
+	return sb.String()
+}
+
+func EnterWorkoutSetMessage(exerciseName string, setNumber int, unit constants.WeightUnit) string {
+	return fmt.Sprintf(
+		"Введи підхід №%d вправи \"*%s*\" у форматі повторення x вага в %s, наприклад 8x60\\:",
+		setNumber,
+		utils.EscapeMarkdown(exerciseName),
+		unitText(unit),
+	)
+}
+
+func RestTimerStartedMessage(seconds uint) string {
+	return fmt.Sprintf("⏱️ Відпочинок %d с\\. Я напишу, коли час вийде\\.", seconds)
+}
+
+func RestTimerFinishedMessage(seconds uint) string {
+	return fmt.Sprintf("🔔 Відпочинок %d с завершено\\! Час для наступного підходу\\.", seconds)
+}
+
+func WorkoutSessionFinishedMessage(session models.WorkoutSession, unit constants.WeightUnit) string {
+	return "✅ Тренування завершено\\!\n\n" + workoutSummary(session, unit)
+}
+
+func WorkoutSessionActiveMessage(programName string) string {
+	return fmt.Sprintf(
+		"У тебе вже є незавершене тренування за програмою \"*%s*\"\\. Продовж його, заверши або скасуй, щоб почати нове\\.",
+		utils.EscapeMarkdown(programName),
+	)
+}
+
+func WorkoutSessionCancelledMessage(programName string) string {
+	return fmt.Sprintf("Тренування за програмою \"*%s*\" скасовано\\.", utils.EscapeMarkdown(programName))
+}
+
+func NoClientWorkoutSessionsMessage(name string) string {
+	return fmt.Sprintf("Клієнт \"*%s*\" ще не завершив жодного тренування\\.", utils.EscapeMarkdown(name))
+}
+
+func SelectClientWorkoutSessionMessage(name string) string {
+	return fmt.Sprintf("Вибери тренування клієнта \"*%s*\"\\:", utils.EscapeMarkdown(name))
+}
+
+func ClientWorkoutSessionMessage(name string, session models.WorkoutSession, unit constants.WeightUnit) string {
+	return fmt.Sprintf("Тренування клієнта \"*%s*\"\n\n", utils.EscapeMarkdown(name)) + workoutSummary(session, unit)
+}
+
+func workoutSummary(session models.WorkoutSession, unit constants.WeightUnit) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Програма\\: *%s*\n", utils.EscapeMarkdown(session.Name())))
+	sb.WriteString(fmt.Sprintf("Початок\\: %s\n", utils.EscapeMarkdown(session.StartedAt.Format("2006-01-02 15:04"))))
+	sb.WriteString(fmt.Sprintf("Тривалість\\: %s\n", durationText(session.Duration())))
+	sb.WriteString(fmt.Sprintf("Підходів\\: %d", len(session.Sets)))
+
+	lastExerciseId := uint(0)
+
+	for _, set := range session.Sets {
+		if set.ExerciseId != lastExerciseId {
+			sb.WriteString(fmt.Sprintf("\n\n*%s*\\:", utils.EscapeMarkdown(set.Name())))
+			lastExerciseId = set.ExerciseId
+		}
+
+		sb.WriteString(fmt.Sprintf("\n %d\\. %s", set.SetNumber, setText(set, unit)))
+	}
+
+	return sb.String()
+}
+
+func setText(set models.WorkoutSet, unit constants.WeightUnit) string {
+	if set.Weight == 0 {
+		return fmt.Sprintf("%d повторень", set.Reps)
+	}
+
+	return fmt.Sprintf("%d повторень x %s", set.Reps, weightText(set.Weight, unit))
+}
+
+func durationText(d time.Duration) string {
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+
+	if hours > 0 {
+		return fmt.Sprintf("%d год %d хв", hours, minutes)
+	}
+
+	return fmt.Sprintf("%d хв", minutes)
+}
@@ -0,0 +1,110 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTokenBucketReserve(t *testing.T) {
+	tests := []struct {
+		name      string
+		capacity  int
+		perSecond float64
+		reserved  int
+		elapsed   time.Duration
+		wantDelay time.Duration
+	}{
+		{name: "full bucket", capacity: 3, perSecond: 1, reserved: 0, wantDelay: 0},
+		{name: "last token of burst", capacity: 3, perSecond: 1, reserved: 2, wantDelay: 0},
+		{name: "burst exhausted", capacity: 3, perSecond: 1, reserved: 3, wantDelay: time.Second},
+		{name: "faster rate", capacity: 30, perSecond: 30, reserved: 30, wantDelay: time.Second / 30},
+		{name: "partially refilled", capacity: 1, perSecond: 1, reserved: 1, elapsed: 750 * time.Millisecond, wantDelay: 250 * time.Millisecond},
+		{name: "refilled", capacity: 1, perSecond: 1, reserved: 1, elapsed: time.Second, wantDelay: 0},
+		{name: "refill is capped", capacity: 2, perSecond: 1, reserved: 2, elapsed: time.Hour, wantDelay: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := NewTokenBucket(tt.capacity, tt.perSecond)
+
+			for i := 0; i < tt.reserved; i++ {
+				tb.reserve()
+			}
+
+			tb.last = tb.last.Add(-tt.elapsed)
+
+			got := tb.reserve()
+
+			if diff := got - tt.wantDelay; diff < -10*time.Millisecond || diff > 10*time.Millisecond {
+				t.Errorf("got delay %s, want %s", got, tt.wantDelay)
+			}
+		})
+	}
+}
+
+func TestTokenBucketRefillCap(t *testing.T) {
+	tb := NewTokenBucket(2, 1)
+	tb.last = tb.last.Add(-time.Hour)
+
+	for i := 0; i < 2; i++ {
+		if delay := tb.reserve(); delay != 0 {
+			t.Fatalf("reserve %d: got delay %s, want none", i, delay)
+		}
+	}
+
+	if delay := tb.reserve(); delay == 0 {
+		t.Fatal("expected the bucket to be empty after its capacity was used")
+	}
+}
+
+func TestTokenBucketIsIdle(t *testing.T) {
+	tb := NewTokenBucket(2, 1)
+
+	if !tb.IsIdle() {
+		t.Fatal("new bucket should be idle")
+	}
+
+	tb.reserve()
+
+	if tb.IsIdle() {
+		t.Fatal("bucket with a reserved token should not be idle")
+	}
+
+	tb.last = tb.last.Add(-time.Second)
+
+	if !tb.IsIdle() {
+		t.Fatal("refilled bucket should be idle")
+	}
+}
+
+func TestTokenBucketWait(t *testing.T) {
+	tb := NewTokenBucket(1, 1000)
+
+	if err := tb.Wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	start := time.Now()
+
+	if err := tb.Wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("waited %s for a token refilled every millisecond", elapsed)
+	}
+}
+
+func TestTokenBucketWaitCancelled(t *testing.T) {
+	tb := NewTokenBucket(1, 0.001)
+	tb.reserve()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := tb.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want %v", err, context.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"context"
+	"rezvin-pro-bot/src/constants"
+	"sync"
+	"time"
+)
+
+type ChatLimiter struct {
+	mu      sync.Mutex
+	global  *TokenBucket
+	chats   map[int64]*TokenBucket
+	cleaned time.Time
+}
+
+func NewChatLimiter() *ChatLimiter {
+	return &ChatLimiter{
+		global:  NewTokenBucket(constants.GlobalMessagesPerSecond, constants.GlobalMessagesPerSecond),
+		chats:   make(map[int64]*TokenBucket),
+		cleaned: time.Now(),
+	}
+}
+
+func (l *ChatLimiter) Wait(ctx context.Context, chatId int64) error {
+	err := l.chat(chatId).Wait(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	return l.global.Wait(ctx)
+}
+
+func (l *ChatLimiter) chat(chatId int64) *TokenBucket {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.evictIdle()
+
+	bucket, ok := l.chats[chatId]
+
+	if !ok {
+		bucket = newChatBucket(chatId)
+		l.chats[chatId] = bucket
+	}
+
+	return bucket
+}
+
+func (l *ChatLimiter) evictIdle() {
+	if time.Since(l.cleaned) < constants.ChatRateLimiterIdleAfter {
+		return
+	}
+
+	for chatId, bucket := range l.chats {
+		if bucket.IsIdle() {
+			delete(l.chats, chatId)
+		}
+	}
+
+	l.cleaned = time.Now()
+}
+
+func newChatBucket(chatId int64) *TokenBucket {
+	if chatId < 0 {
+		return NewTokenBucket(constants.GroupMessagesPerMinute, constants.GroupMessagesPerMinute/60.0)
+	}
+
+	return NewTokenBucket(constants.ChatMessagesBurst, constants.ChatMessagesPerSecond)
+}
@@ -0,0 +1,91 @@
+package ratelimit
+
+import (
+	"context"
+	"rezvin-pro-bot/src/constants"
+	"testing"
+	"time"
+)
+
+func TestNewChatBucket(t *testing.T) {
+	tests := []struct {
+		name         string
+		chatId       int64
+		wantCapacity float64
+		wantRate     float64
+	}{
+		{"private chat", 42, constants.ChatMessagesBurst, constants.ChatMessagesPerSecond},
+		{"group chat", -1001, constants.GroupMessagesPerMinute, constants.GroupMessagesPerMinute / 60.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bucket := newChatBucket(tt.chatId)
+
+			if bucket.capacity != tt.wantCapacity || bucket.rate != tt.wantRate {
+				t.Errorf("got capacity %v rate %v, want capacity %v rate %v", bucket.capacity, bucket.rate, tt.wantCapacity, tt.wantRate)
+			}
+		})
+	}
+}
+
+func TestChatLimiterSeparatesChats(t *testing.T) {
+	l := NewChatLimiter()
+
+	for i := 0; i < constants.ChatMessagesBurst; i++ {
+		if err := l.Wait(context.Background(), 1); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	if delay := l.chat(1).reserve(); delay == 0 {
+		t.Fatal("expected chat 1 to be out of tokens")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := l.Wait(ctx, 2); err != nil {
+		t.Fatalf("chat 2 should not be limited by chat 1: %s", err)
+	}
+}
+
+func TestChatLimiterGlobalLimit(t *testing.T) {
+	l := NewChatLimiter()
+
+	for chatId := int64(1); chatId <= constants.GlobalMessagesPerSecond; chatId++ {
+		if err := l.Wait(context.Background(), chatId); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+
+	if err := l.Wait(ctx, constants.GlobalMessagesPerSecond+1); err == nil {
+		t.Fatal("expected the global bucket to limit a new chat")
+	}
+}
+
+func TestChatLimiterEvictsIdleChats(t *testing.T) {
+	l := NewChatLimiter()
+
+	idle := l.chat(1)
+	busy := l.chat(2)
+	busy.reserve()
+
+	l.cleaned = l.cleaned.Add(-constants.ChatRateLimiterIdleAfter)
+	l.chat(3)
+
+	if _, ok := l.chats[1]; ok {
+		t.Error("idle chat was not evicted")
+	}
+
+	if l.chats[2] != busy {
+		t.Error("busy chat was evicted")
+	}
+
+	if l.chat(1) == idle {
+		t.Error("evicted chat should get a new bucket")
+	}
+}
@@ -0,0 +1,92 @@
+package rep_scheme
+
+import (
+	"fmt"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/models"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+func Parse(scheme string) ([]constants.Reps, error) {
+	fields := strings.FieldsFunc(scheme, func(r rune) bool {
+		return r == ',' || r == '/' || r == ' ' || r == ';'
+	})
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("empty rep scheme")
+	}
+
+	reps := make([]constants.Reps, 0, len(fields))
+
+	for _, field := range fields {
+		value, err := strconv.ParseUint(field, 10, 32)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid reps %q: %v", field, err)
+		}
+
+		if value == 0 || constants.Reps(value) > constants.MaxReps {
+			return nil, fmt.Errorf("reps %d out of range", value)
+		}
+
+		if !slices.Contains(reps, constants.Reps(value)) {
+			reps = append(reps, constants.Reps(value))
+		}
+	}
+
+	if len(reps) > constants.MaxRepSchemeLength {
+		return nil, fmt.Errorf("too many reps in scheme: %d", len(reps))
+	}
+
+	slices.Sort(reps)
+
+	return reps, nil
+}
+
+func Format(reps []constants.Reps) string {
+	parts := make([]string, 0, len(reps))
+
+	for _, rep := range reps {
+		parts = append(parts, strconv.FormatUint(uint64(rep), 10))
+	}
+
+	return strings.Join(parts, ",")
+}
+
+func ForProgram(program models.Program) []constants.Reps {
+	reps, err := Parse(program.RepScheme)
+
+	if err != nil {
+		reps, _ = Parse(constants.DefaultRepScheme)
+	}
+
+	return reps
+}
+
+func ForExercise(program models.Program, exercise models.Exercise) []constants.Reps {
+	if exercise.RepScheme == "" {
+		return ForProgram(program)
+	}
+
+	reps, err := Parse(exercise.RepScheme)
+
+	if err != nil {
+		return ForProgram(program)
+	}
+
+	return reps
+}
+
+func FilterResults(program models.Program, records []models.UserResult) []models.UserResult {
+	filtered := make([]models.UserResult, 0, len(records))
+
+	for _, record := range records {
+		if slices.Contains(ForExercise(program, record.Exercise), constants.Reps(record.Reps)) {
+			filtered = append(filtered, record)
+		}
+	}
+
+	return filtered
+}
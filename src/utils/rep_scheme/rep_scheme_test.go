@@ -0,0 +1,112 @@
+package rep_scheme
+
+import (
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/models"
+	"slices"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		scheme  string
+		want    []constants.Reps
+		wantErr bool
+	}{
+		{name: "comma separated", scheme: "6,8,10,12", want: []constants.Reps{6, 8, 10, 12}},
+		{name: "mixed separators", scheme: "12/10; 8 6", want: []constants.Reps{6, 8, 10, 12}},
+		{name: "duplicates removed", scheme: "5,5,3", want: []constants.Reps{3, 5}},
+		{name: "single rep", scheme: "1", want: []constants.Reps{1}},
+		{name: "max reps", scheme: "100", want: []constants.Reps{constants.MaxReps}},
+		{name: "max length", scheme: "1,2,3,4,5,6,7,8", want: []constants.Reps{1, 2, 3, 4, 5, 6, 7, 8}},
+		{name: "empty", scheme: "", wantErr: true},
+		{name: "only separators", scheme: " , ;", wantErr: true},
+		{name: "not a number", scheme: "6,eight", wantErr: true},
+		{name: "negative", scheme: "-6", wantErr: true},
+		{name: "zero", scheme: "0,6", wantErr: true},
+		{name: "above max reps", scheme: "101", wantErr: true},
+		{name: "too many reps", scheme: "1,2,3,4,5,6,7,8,9", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.scheme)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		reps []constants.Reps
+		want string
+	}{
+		{"empty", nil, ""},
+		{"single", []constants.Reps{5}, "5"},
+		{"several", []constants.Reps{6, 8, 10}, "6,8,10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Format(tt.reps); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestForExercise(t *testing.T) {
+	defaults, _ := Parse(constants.DefaultRepScheme)
+
+	tests := []struct {
+		name     string
+		program  models.Program
+		exercise models.Exercise
+		want     []constants.Reps
+	}{
+		{"program scheme", models.Program{RepScheme: "3,5"}, models.Exercise{}, []constants.Reps{3, 5}},
+		{"exercise overrides program", models.Program{RepScheme: "3,5"}, models.Exercise{RepScheme: "10,15"}, []constants.Reps{10, 15}},
+		{"invalid exercise falls back to program", models.Program{RepScheme: "3,5"}, models.Exercise{RepScheme: "abc"}, []constants.Reps{3, 5}},
+		{"invalid program falls back to default", models.Program{RepScheme: "abc"}, models.Exercise{}, defaults},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ForExercise(tt.program, tt.exercise); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterResults(t *testing.T) {
+	program := models.Program{RepScheme: "6,8"}
+	records := []models.UserResult{
+		{Reps: 6},
+		{Reps: 10},
+		{Reps: 10, Exercise: models.Exercise{RepScheme: "10"}},
+		{Reps: 8},
+	}
+
+	got := FilterResults(program, records)
+
+	if len(got) != 3 || got[0].Reps != 6 || got[1].Reps != 10 || got[2].Reps != 8 {
+		t.Errorf("unexpected filtered results: %+v", got)
+	}
+}
@@ -0,0 +1,58 @@
+package strength
+
+import (
+	"math"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/models"
+)
+
+func Epley(weight float64, reps uint) float64 {
+	if weight <= 0 || reps == 0 {
+		return 0
+	}
+
+	if reps == 1 {
+		return weight
+	}
+
+	return weight * (1 + float64(reps)/30)
+}
+
+func Brzycki(weight float64, reps uint) float64 {
+	if weight <= 0 || reps == 0 || reps >= 37 {
+		return 0
+	}
+
+	return weight * 36 / (37 - float64(reps))
+}
+
+func Lombardi(weight float64, reps uint) float64 {
+	if weight <= 0 || reps == 0 {
+		return 0
+	}
+
+	return weight * math.Pow(float64(reps), 0.1)
+}
+
+func Estimate(formula constants.OneRepMaxFormula, weight float64, reps uint) float64 {
+	switch formula {
+	case constants.EpleyFormula:
+		return Epley(weight, reps)
+	case constants.BrzyckiFormula:
+		return Brzycki(weight, reps)
+	case constants.LombardiFormula:
+		return Lombardi(weight, reps)
+	default:
+		return math.Max(Epley(weight, reps), math.Max(Brzycki(weight, reps), Lombardi(weight, reps)))
+	}
+}
+
+func EstimateFromResults(formula constants.OneRepMaxFormula, records []models.UserResult) float64 {
+	var best float64
+
+	for _, record := range records {
+		best = math.Max(best, Estimate(formula, record.Weight, record.Reps))
+	}
+
+	return best
+}
@@ -0,0 +1,93 @@
+package strength
+
+import (
+	"math"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/models"
+	"testing"
+)
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestFormulas(t *testing.T) {
+	tests := []struct {
+		name    string
+		formula func(float64, uint) float64
+		weight  float64
+		reps    uint
+		want    float64
+	}{
+		{"epley single rep", Epley, 100, 1, 100},
+		{"epley ten reps", Epley, 100, 10, 100 * (1 + 10.0/30)},
+		{"epley zero reps", Epley, 100, 0, 0},
+		{"epley zero weight", Epley, 0, 5, 0},
+		{"epley negative weight", Epley, -10, 5, 0},
+		{"brzycki single rep", Brzycki, 100, 1, 100},
+		{"brzycki ten reps", Brzycki, 100, 10, 100 * 36.0 / 27},
+		{"brzycki last valid rep", Brzycki, 100, 36, 3600},
+		{"brzycki reps out of range", Brzycki, 100, 37, 0},
+		{"brzycki zero reps", Brzycki, 100, 0, 0},
+		{"lombardi single rep", Lombardi, 100, 1, 100},
+		{"lombardi ten reps", Lombardi, 100, 10, 100 * math.Pow(10, 0.1)},
+		{"lombardi zero weight", Lombardi, 0, 10, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.formula(tt.weight, tt.reps); !almostEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEstimate(t *testing.T) {
+	tests := []struct {
+		name    string
+		formula constants.OneRepMaxFormula
+		weight  float64
+		reps    uint
+		want    float64
+	}{
+		{"epley", constants.EpleyFormula, 100, 10, Epley(100, 10)},
+		{"brzycki", constants.BrzyckiFormula, 100, 10, Brzycki(100, 10)},
+		{"lombardi", constants.LombardiFormula, 100, 10, Lombardi(100, 10)},
+		{"best picks maximum", constants.BestFormula, 100, 10, Brzycki(100, 10)},
+		{"unknown falls back to best", "unknown", 100, 10, Brzycki(100, 10)},
+		{"best beyond brzycki range", constants.BestFormula, 100, 40, Epley(100, 40)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Estimate(tt.formula, tt.weight, tt.reps); !almostEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEstimateFromResults(t *testing.T) {
+	tests := []struct {
+		name    string
+		records []models.UserResult
+		want    float64
+	}{
+		{"no records", nil, 0},
+		{"empty results", []models.UserResult{{Reps: 6}, {Reps: 8}}, 0},
+		{
+			"best record wins",
+			[]models.UserResult{{Reps: 1, Weight: 100}, {Reps: 10, Weight: 90}},
+			Epley(90, 10),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EstimateFromResults(constants.EpleyFormula, tt.records); !almostEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package units
+
+import (
+	"math"
+	"rezvin-pro-bot/src/constants"
+	"strconv"
+)
+
+const kilogramsPerPound = 0.45359237
+
+func Round(value float64, precision int) float64 {
+	factor := math.Pow(10, float64(precision))
+
+	return math.Round(value*factor) / factor
+}
+
+func ToKilograms(value float64, unit constants.WeightUnit) float64 {
+	if unit == constants.PoundUnit {
+		return Round(value*kilogramsPerPound, 2)
+	}
+
+	return Round(value, 2)
+}
+
+func FromKilograms(kg float64, unit constants.WeightUnit) float64 {
+	if unit == constants.PoundUnit {
+		return Round(kg/kilogramsPerPound, 1)
+	}
+
+	return Round(kg, 2)
+}
+
+func MaxWeight(unit constants.WeightUnit) float64 {
+	return math.Floor(FromKilograms(constants.MaxWeightKg, unit))
+}
+
+func UnitName(unit constants.WeightUnit) string {
+	if unit == constants.PoundUnit {
+		return "lb"
+	}
+
+	return "кг"
+}
+
+func FormatWeight(kg float64, unit constants.WeightUnit) string {
+	return strconv.FormatFloat(FromKilograms(kg, unit), 'f', -1, 64) + " " + UnitName(unit)
+}
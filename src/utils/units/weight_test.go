@@ -0,0 +1,104 @@
+package units
+
+import (
+	"rezvin-pro-bot/src/constants"
+	"testing"
+)
+
+func TestRound(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     float64
+		precision int
+		want      float64
+	}{
+		{"two digits", 1.005001, 2, 1.01},
+		{"one digit", 45.36, 1, 45.4},
+		{"zero precision", 2.5, 0, 3},
+		{"negative", -1.255, 1, -1.3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Round(tt.value, tt.precision); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConversion(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  float64
+		unit   constants.WeightUnit
+		wantKg float64
+	}{
+		{"kilograms unchanged", 100, constants.KilogramUnit, 100},
+		{"kilograms rounded", 100.004, constants.KilogramUnit, 100},
+		{"pounds", 100, constants.PoundUnit, 45.36},
+		{"one pound", 1, constants.PoundUnit, 0.45},
+		{"zero", 0, constants.PoundUnit, 0},
+		{"unknown unit treated as kilograms", 10, "st", 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToKilograms(tt.value, tt.unit); got != tt.wantKg {
+				t.Errorf("ToKilograms got %v, want %v", got, tt.wantKg)
+			}
+		})
+	}
+}
+
+func TestFromKilograms(t *testing.T) {
+	tests := []struct {
+		name string
+		kg   float64
+		unit constants.WeightUnit
+		want float64
+	}{
+		{"kilograms", 80.5, constants.KilogramUnit, 80.5},
+		{"pounds", 100, constants.PoundUnit, 220.5},
+		{"pounds round trip", 45.36, constants.PoundUnit, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FromKilograms(tt.kg, tt.unit); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaxWeight(t *testing.T) {
+	if got := MaxWeight(constants.KilogramUnit); got != constants.MaxWeightKg {
+		t.Errorf("kilograms: got %v, want %v", got, constants.MaxWeightKg)
+	}
+
+	if got := MaxWeight(constants.PoundUnit); got != 2204 {
+		t.Errorf("pounds: got %v, want 2204", got)
+	}
+}
+
+func TestFormatWeight(t *testing.T) {
+	tests := []struct {
+		name string
+		kg   float64
+		unit constants.WeightUnit
+		want string
+	}{
+		{"kilograms", 82.5, constants.KilogramUnit, "82.5 кг"},
+		{"whole kilograms", 100, constants.KilogramUnit, "100 кг"},
+		{"pounds", 100, constants.PoundUnit, "220.5 lb"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatWeight(tt.kg, tt.unit); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
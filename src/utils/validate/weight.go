@@ -2,22 +2,29 @@ package validate_data
 
 import (
 	"fmt"
+	"math"
+	"rezvin-pro-bot/src/constants"
+	"rezvin-pro-bot/src/utils/rep_scheme"
+	"rezvin-pro-bot/src/utils/units"
 	"strconv"
 	"strings"
 )
 
-func ValidateWeightAnswer(text string) (int, error) {
-	weight, err := strconv.Atoi(strings.TrimSpace(text))
+func ValidateWeightAnswer(text string, unit constants.WeightUnit) (float64, error) {
+	maxWeight := units.MaxWeight(unit)
+	errMsg := fmt.Errorf("введіть число від 0 до %s %s", strconv.FormatFloat(maxWeight, 'f', -1, 64), units.UnitName(unit))
 
-	if err != nil {
-		return 0, fmt.Errorf("введіть число від 0 до 1000")
+	weight, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(text), ",", "."), 64)
+
+	if err != nil || math.IsNaN(weight) {
+		return 0, errMsg
 	}
 
-	if weight < 0 || weight > 1000 {
-		return 0, fmt.Errorf("введіть число від 0 до 1000")
+	if weight < 0 || weight > maxWeight {
+		return 0, errMsg
 	}
 
-	return weight, nil
+	return units.ToKilograms(weight, unit), nil
 }
 
 func ValidateValueAnswer(text string) (float64, error) {
@@ -41,3 +48,47 @@ func ValidateStringAnswer(text string) (string, error) {
 
 	return text, nil
 }
+
+func ValidateRepSchemeAnswer(text string) (string, error) {
+	reps, err := rep_scheme.Parse(text)
+
+	if err != nil {
+		return "", fmt.Errorf("введіть від 1 до %d чисел від 1 до %d через кому, наприклад 6,8,10,12", constants.MaxRepSchemeLength, constants.MaxReps)
+	}
+
+	return rep_scheme.Format(reps), nil
+}
+
+func ValidateSetAnswer(text string, unit constants.WeightUnit) (uint, float64, error) {
+	errMsg := fmt.Errorf("введіть підхід у форматі повторення x вага, наприклад 8x60")
+
+	normalized := strings.ToLower(strings.TrimSpace(text))
+
+	for _, separator := range []string{"х", "×", "*"} {
+		normalized = strings.ReplaceAll(normalized, separator, "x")
+	}
+
+	parts := strings.Split(normalized, "x")
+
+	if len(parts) > 2 {
+		return 0, 0, errMsg
+	}
+
+	reps, err := strconv.ParseUint(strings.TrimSpace(parts[0]), 10, 32)
+
+	if err != nil || reps == 0 || constants.Reps(reps) > constants.MaxReps {
+		return 0, 0, errMsg
+	}
+
+	if len(parts) == 1 {
+		return uint(reps), 0, nil
+	}
+
+	weight, err := ValidateWeightAnswer(parts[1], unit)
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return uint(reps), weight, nil
+}